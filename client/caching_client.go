@@ -0,0 +1,289 @@
+package abcicli
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/abci/types"
+)
+
+var _ Client = (*cachingClient)(nil)
+
+// A Client decorator that caches Query responses, and optionally CheckTx
+// responses, in an LRU cache of limited size.
+// Queries pinned to a height are cached until evicted; queries for the
+// latest height, and CheckTx responses, until the next Commit.
+// Only successful responses to height-pinned queries are cached,
+// as a height that isn't committed yet may become queryable later.
+type cachingClient struct {
+	Client
+
+	size         int
+	cacheCheckTx bool
+
+	mtx     sync.Mutex
+	lru     *list.List               // most recently used first
+	entries map[string]*list.Element // key -> *cacheEntry
+	hits    int64
+	misses  int64
+	commits int64                                 // number of invalidations
+	resCb   func(*types.Request, *types.Response) // listens to all callbacks
+}
+
+type cacheEntry struct {
+	key    string
+	res    *types.Response
+	pinned bool // survives Commit
+}
+
+// CacheStats are the statistics of a caching client.
+type CacheStats struct {
+	Hits    int64 // calls answered from the cache
+	Misses  int64 // calls forwarded to the client
+	Entries int   // responses in the cache
+}
+
+// NewCachingClient wraps the client with a cache of at most size responses.
+// The cache takes over the client's response callback,
+// so SetResponseCallback must be called on the caching client.
+func NewCachingClient(client Client, size int, options ...CachingClientOption) *cachingClient {
+	cli := &cachingClient{
+		Client:  client,
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+	for _, option := range options {
+		option(cli)
+	}
+	client.SetResponseCallback(cli.didRecvResponse)
+	return cli
+}
+
+// CachingClientOption sets an optional parameter on the cachingClient.
+type CachingClientOption func(*cachingClient)
+
+// CacheCheckTx caches CheckTx responses until the next Commit.
+// NOTE: a tx checked again before the next Commit doesn't reach the app,
+// so only use this with apps whose CheckTx doesn't depend on previous txs.
+func CacheCheckTx() CachingClientOption {
+	return func(cli *cachingClient) {
+		cli.cacheCheckTx = true
+	}
+}
+
+// Stats returns the hit/miss statistics of the cache.
+func (cli *cachingClient) Stats() CacheStats {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return CacheStats{
+		Hits:    cli.hits,
+		Misses:  cli.misses,
+		Entries: cli.lru.Len(),
+	}
+}
+
+// Set listener for all responses, including those from the cache.
+// NOTE: callback may get internally generated flush responses.
+func (cli *cachingClient) SetResponseCallback(resCb Callback) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	cli.resCb = resCb
+}
+
+// didRecvResponse caches the responses from the client,
+// and invalidates the cache on Commit.
+func (cli *cachingClient) didRecvResponse(req *types.Request, res *types.Response) {
+	cli.mtx.Lock()
+	switch r := res.Value.(type) {
+	case *types.Response_Query:
+		query := req.GetQuery()
+		pinned := query.Height > 0
+		if !pinned || r.Query.IsOK() {
+			cli.add(queryKey(*query), res, pinned)
+		}
+	case *types.Response_CheckTx:
+		if cli.cacheCheckTx {
+			cli.add(checkTxKey(*req.GetCheckTx()), res, false)
+		}
+	case *types.Response_Commit:
+		cli.invalidate()
+	}
+	resCb := cli.resCb
+	cli.mtx.Unlock()
+
+	if resCb != nil {
+		resCb(req, res)
+	}
+}
+
+//----------------------------------------
+// LRU cache, the caller must hold cli.mtx
+// Responses are copied in and out of the cache, so callers
+// modifying them don't modify the cached ones.
+
+func (cli *cachingClient) get(key string) *types.Response {
+	elem, ok := cli.entries[key]
+	if !ok {
+		cli.misses++
+		return nil
+	}
+	cli.hits++
+	cli.lru.MoveToFront(elem)
+	return proto.Clone(elem.Value.(*cacheEntry).res).(*types.Response)
+}
+
+func (cli *cachingClient) add(key string, res *types.Response, pinned bool) {
+	if cli.size <= 0 {
+		return
+	}
+	res = proto.Clone(res).(*types.Response)
+	if elem, ok := cli.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.res, entry.pinned = res, pinned
+		cli.lru.MoveToFront(elem)
+		return
+	}
+	cli.entries[key] = cli.lru.PushFront(&cacheEntry{key, res, pinned})
+	for cli.lru.Len() > cli.size {
+		cli.remove(cli.lru.Back())
+	}
+}
+
+func (cli *cachingClient) remove(elem *list.Element) {
+	delete(cli.entries, elem.Value.(*cacheEntry).key)
+	cli.lru.Remove(elem)
+}
+
+// invalidate removes the responses that don't survive a Commit.
+func (cli *cachingClient) invalidate() {
+	cli.commits++
+	var next *list.Element
+	for elem := cli.lru.Front(); elem != nil; elem = next {
+		next = elem.Next()
+		if !elem.Value.(*cacheEntry).pinned {
+			cli.remove(elem)
+		}
+	}
+}
+
+// lookup returns the cached response, if any,
+// and the number of invalidations so far.
+func (cli *cachingClient) lookup(key string) (*types.Response, int64) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.get(key), cli.commits
+}
+
+// addIfNoCommit caches a response received by a Sync call,
+// unless a Commit went through since the call was made.
+func (cli *cachingClient) addIfNoCommit(commits int64, key string, res *types.Response, pinned bool) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if commits == cli.commits || pinned {
+		cli.add(key, res, pinned)
+	}
+}
+
+func queryKey(req types.RequestQuery) string {
+	return fmt.Sprintf("q/%d/%t/%q/%X", req.Height, req.Prove, req.Path, req.Data)
+}
+
+func checkTxKey(req types.RequestCheckTx) string {
+	return fmt.Sprintf("c/%d/%X", req.Type, req.Tx)
+}
+
+// cachedReqRes returns a done ReqRes for a response from the cache,
+// notifying the response callback like the client would.
+func (cli *cachingClient) cachedReqRes(req *types.Request, res *types.Response) *ReqRes {
+	reqres := NewReqRes(req)
+	reqres.Response = res
+	reqres.Done()
+	reqres.SetDone()
+
+	cli.mtx.Lock()
+	resCb := cli.resCb
+	cli.mtx.Unlock()
+	if resCb != nil {
+		resCb(req, res)
+	}
+	return reqres
+}
+
+//----------------------------------------
+
+func (cli *cachingClient) QueryAsync(req types.RequestQuery) *ReqRes {
+	if res, _ := cli.lookup(queryKey(req)); res != nil {
+		return cli.cachedReqRes(types.ToRequestQuery(req), res)
+	}
+	return cli.Client.QueryAsync(req)
+}
+
+func (cli *cachingClient) CheckTxAsync(req types.RequestCheckTx) *ReqRes {
+	if cli.cacheCheckTx {
+		if res, _ := cli.lookup(checkTxKey(req)); res != nil {
+			return cli.cachedReqRes(types.ToRequestCheckTx(req), res)
+		}
+	}
+	return cli.Client.CheckTxAsync(req)
+}
+
+// CommitAsync invalidates the cache right away, and again when the
+// response is received, so no response from before the Commit is kept.
+func (cli *cachingClient) CommitAsync() *ReqRes {
+	cli.mtx.Lock()
+	cli.invalidate()
+	cli.mtx.Unlock()
+	return cli.Client.CommitAsync()
+}
+
+func (cli *cachingClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
+	key := queryKey(req)
+	cached, commits := cli.lookup(key)
+	if cached != nil {
+		return cached.GetQuery(), nil
+	}
+	res, err := cli.Client.QuerySync(req)
+	if err != nil {
+		return nil, err
+	}
+	// the local client doesn't call the response callback for Sync calls,
+	// so the response is cached here too
+	pinned := req.Height > 0
+	if !pinned || res.IsOK() {
+		cli.addIfNoCommit(commits, key, types.ToResponseQuery(*res), pinned)
+	}
+	return res, nil
+}
+
+func (cli *cachingClient) CheckTxSync(req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	if !cli.cacheCheckTx {
+		return cli.Client.CheckTxSync(req)
+	}
+	key := checkTxKey(req)
+	cached, commits := cli.lookup(key)
+	if cached != nil {
+		return cached.GetCheckTx(), nil
+	}
+	res, err := cli.Client.CheckTxSync(req)
+	if err != nil {
+		return nil, err
+	}
+	cli.addIfNoCommit(commits, key, types.ToResponseCheckTx(*res), false)
+	return res, nil
+}
+
+func (cli *cachingClient) CommitSync() (*types.ResponseCommit, error) {
+	cli.mtx.Lock()
+	cli.invalidate()
+	cli.mtx.Unlock()
+	res, err := cli.Client.CommitSync()
+
+	cli.mtx.Lock()
+	cli.invalidate()
+	cli.mtx.Unlock()
+	return res, err
+}
@@ -0,0 +1,97 @@
+package abcicli_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/types"
+)
+
+// queryCountApp counts the queries, and answers with the committed height.
+type queryCountApp struct {
+	types.BaseApplication
+	height  int64
+	queries int
+}
+
+func (app *queryCountApp) Query(req types.RequestQuery) types.ResponseQuery {
+	app.queries++
+	if req.Height > app.height {
+		return types.ResponseQuery{Code: 1, Log: "height not committed"}
+	}
+	return types.ResponseQuery{Height: app.height}
+}
+
+func (app *queryCountApp) Commit() types.ResponseCommit {
+	app.height++
+	return types.ResponseCommit{}
+}
+
+func TestCachingClient(t *testing.T) {
+	app := &queryCountApp{height: 1}
+	c := abcicli.NewCachingClient(abcicli.NewLocalClient(nil, app), 2)
+	var callbacks int
+	c.SetResponseCallback(func(*types.Request, *types.Response) { callbacks++ })
+
+	query := func(height int64) *types.ResponseQuery {
+		res, err := c.QuerySync(types.RequestQuery{Path: "/p", Height: height})
+		require.NoError(t, err)
+		return res
+	}
+
+	// latest is cached until commit
+	assert.EqualValues(t, 1, query(0).Height)
+	assert.EqualValues(t, 1, query(0).Height)
+	assert.Equal(t, 1, app.queries)
+	_, err := c.CommitSync()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, query(0).Height)
+	assert.Equal(t, 2, app.queries)
+
+	// pinned heights survive commits, unless not committed yet
+	assert.EqualValues(t, 2, query(1).Height)
+	assert.False(t, query(3).IsOK())
+	c.CommitAsync()
+	query(1)
+	assert.True(t, query(3).IsOK())
+	assert.Equal(t, 5, app.queries)
+
+	// the least recently used response is evicted
+	query(1)
+	query(2)
+	query(3)
+	assert.Equal(t, 7, app.queries)
+
+	stats := c.Stats()
+	assert.Equal(t, abcicli.CacheStats{Hits: 3, Misses: 7, Entries: 2}, stats)
+
+	// callbacks are forwarded, also for hits
+	assert.True(t, callbacks > 0)
+	before := callbacks
+	c.QueryAsync(types.RequestQuery{Path: "/p", Height: 1})
+	assert.Equal(t, before+1, callbacks)
+}
+
+func TestCachingClientCopiesResponses(t *testing.T) {
+	app := &queryCountApp{height: 1}
+	c := abcicli.NewCachingClient(abcicli.NewLocalClient(nil, app), 2)
+	req := types.RequestQuery{Path: "/p"}
+
+	res, err := c.QuerySync(req)
+	require.NoError(t, err)
+	res.Height = 42
+	res.Value = []byte("mutated")
+
+	res, err = c.QuerySync(req)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Height)
+	assert.Nil(t, res.Value)
+	res.Log = "mutated"
+
+	reqres := c.QueryAsync(req)
+	assert.Equal(t, "", reqres.Response.GetQuery().Log)
+	assert.Equal(t, 1, app.queries)
+}
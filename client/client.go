@@ -2,6 +2,7 @@ package abcicli
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/tendermint/abci/types"
@@ -9,14 +10,15 @@ import (
 )
 
 const (
-	dialRetryIntervalSeconds = 3
-	echoRetryIntervalSeconds = 1
+	dialRetryIntervalSeconds        = 3
+	healthCheckRetryIntervalSeconds = 1
 )
 
 // Client defines an interface for an ABCI client.
 // All `Async` methods return a `ReqRes` object.
 // All `Sync` methods return the appropriate protobuf ResponseXxx struct and an error.
-// Note these are client errors, eg. ABCI socket connectivity issues.
+// The response is nil if and only if the error is not.
+// Note these are client errors, eg. ABCI socket connectivity issues, see errors.go.
 // Application-related errors are reflected in response via ABCI error codes and logs.
 type Client interface {
 	cmn.Service
@@ -29,36 +31,56 @@ type Client interface {
 	InfoAsync(types.RequestInfo) *ReqRes
 	SetOptionAsync(types.RequestSetOption) *ReqRes
 	DeliverTxAsync(tx []byte) *ReqRes
-	CheckTxAsync(tx []byte) *ReqRes
+	CheckTxAsync(types.RequestCheckTx) *ReqRes
 	QueryAsync(types.RequestQuery) *ReqRes
 	CommitAsync() *ReqRes
 	InitChainAsync(types.RequestInitChain) *ReqRes
 	BeginBlockAsync(types.RequestBeginBlock) *ReqRes
 	EndBlockAsync(types.RequestEndBlock) *ReqRes
+	DeliverTxBatchAsync(types.RequestDeliverTxBatch) *ReqRes
+	CheckTxBatchAsync(types.RequestCheckTxBatch) *ReqRes
+	ListSnapshotsAsync(types.RequestListSnapshots) *ReqRes
+	OfferSnapshotAsync(types.RequestOfferSnapshot) *ReqRes
+	LoadSnapshotChunkAsync(types.RequestLoadSnapshotChunk) *ReqRes
+	ApplySnapshotChunkAsync(types.RequestApplySnapshotChunk) *ReqRes
+	PrepareProposalAsync(types.RequestPrepareProposal) *ReqRes
+	ProcessProposalAsync(types.RequestProcessProposal) *ReqRes
 
 	FlushSync() error
 	EchoSync(msg string) (*types.ResponseEcho, error)
 	InfoSync(types.RequestInfo) (*types.ResponseInfo, error)
 	SetOptionSync(types.RequestSetOption) (*types.ResponseSetOption, error)
 	DeliverTxSync(tx []byte) (*types.ResponseDeliverTx, error)
-	CheckTxSync(tx []byte) (*types.ResponseCheckTx, error)
+	CheckTxSync(types.RequestCheckTx) (*types.ResponseCheckTx, error)
 	QuerySync(types.RequestQuery) (*types.ResponseQuery, error)
 	CommitSync() (*types.ResponseCommit, error)
 	InitChainSync(types.RequestInitChain) (*types.ResponseInitChain, error)
 	BeginBlockSync(types.RequestBeginBlock) (*types.ResponseBeginBlock, error)
 	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
+	DeliverTxBatchSync(types.RequestDeliverTxBatch) (*types.ResponseDeliverTxBatch, error)
+	CheckTxBatchSync(types.RequestCheckTxBatch) (*types.ResponseCheckTxBatch, error)
+	ListSnapshotsSync(types.RequestListSnapshots) (*types.ResponseListSnapshots, error)
+	OfferSnapshotSync(types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error)
+	LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunkSync(types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
+	PrepareProposalSync(types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error)
+	ProcessProposalSync(types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
 }
 
 //----------------------------------------
 
 // NewClient returns a new ABCI client of the specified transport type.
-// It returns an error if the transport is not "socket" or "grpc"
+// It returns an error if the transport is not "socket", "grpc" or "failover".
+// The "failover" transport takes a comma-separated list of socket addresses,
+// the first one being the primary (see NewFailoverClient).
 func NewClient(addr, transport string, mustConnect bool) (client Client, err error) {
 	switch transport {
 	case "socket":
 		client = NewSocketClient(addr, mustConnect)
 	case "grpc":
 		client = NewGRPCClient(addr, mustConnect)
+	case "failover":
+		client = NewFailoverClient(strings.Split(addr, ","), mustConnect)
 	default:
 		err = fmt.Errorf("Unknown abci transport %s", transport)
 	}
@@ -71,10 +93,14 @@ type Callback func(*types.Request, *types.Response)
 
 //----------------------------------------
 
+// ReqRes is a request and its response, once received.
+// A request that fails without a response, eg. because the client stopped,
+// has Err set instead, and its callback is never called.
 type ReqRes struct {
 	*types.Request
 	*sync.WaitGroup
-	*types.Response // Not set atomically, so be sure to use WaitGroup.
+	*types.Response       // Not set atomically, so be sure to use WaitGroup.
+	Err             error // Set instead of Response on failure, likewise.
 
 	mtx  sync.Mutex
 	done bool                  // Gets set to true once *after* WaitGroup.Done().
@@ -93,7 +119,7 @@ func NewReqRes(req *types.Request) *ReqRes {
 }
 
 // Sets the callback for this ReqRes atomically.
-// If reqRes is already done, calls cb immediately.
+// If reqRes is already done, calls cb immediately, unless it failed.
 // NOTE: reqRes.cb should not change if reqRes.done.
 // NOTE: only one callback is supported.
 func (reqRes *ReqRes) SetCallback(cb func(res *types.Response)) {
@@ -101,7 +127,9 @@ func (reqRes *ReqRes) SetCallback(cb func(res *types.Response)) {
 
 	if reqRes.done {
 		reqRes.mtx.Unlock()
-		cb(reqRes.Response)
+		if reqRes.Response != nil {
+			cb(reqRes.Response)
+		}
 		return
 	}
 
@@ -122,6 +150,23 @@ func (reqRes *ReqRes) SetDone() {
 	reqRes.mtx.Unlock()
 }
 
+// Sets reqRes as done and returns its callback atomically,
+// so a callback set concurrently is called exactly once.
+func (reqRes *ReqRes) setDoneAndGetCallback() func(*types.Response) {
+	reqRes.mtx.Lock()
+	defer reqRes.mtx.Unlock()
+	reqRes.done = true
+	return reqRes.cb
+}
+
+// fail completes reqRes without a response, recording the error.
+// Its callback is not called.
+func (reqRes *ReqRes) fail(err error) {
+	reqRes.Err = err
+	reqRes.Done()
+	reqRes.SetDone()
+}
+
 func waitGroup1() (wg *sync.WaitGroup) {
 	wg = &sync.WaitGroup{}
 	wg.Add(1)
@@ -0,0 +1,91 @@
+package abcicli
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Client errors are one of the types below, so callers can tell
+// them apart with type assertions, eg. an app crash from a network blip.
+
+// errClientStopped is the cause of ErrConnectionLost
+// for calls that were pending when the client was stopped.
+var errClientStopped = errors.New("client stopped")
+
+// errNotServing is the cause of ErrTimeout for a gRPC server
+// still not serving when the client gives up connecting.
+var errNotServing = errors.New("server not serving")
+
+// ErrConnectionLost is returned when the connection to the app is lost,
+// or the client was stopped, before a response was received.
+type ErrConnectionLost struct {
+	Err error // the underlying error
+}
+
+func (e ErrConnectionLost) Error() string {
+	return fmt.Sprintf("abci connection lost: %v", e.Err)
+}
+
+// ErrProtocolViolation is returned when the app breaks the ABCI protocol,
+// eg. by sending a response that doesn't match the request.
+type ErrProtocolViolation struct {
+	Reason string
+}
+
+func (e ErrProtocolViolation) Error() string {
+	return fmt.Sprintf("abci protocol violation: %s", e.Reason)
+}
+
+// ErrAppException is returned when the app answers with an exception,
+// eg. because it panicked.
+type ErrAppException struct {
+	Message string // the message of the exception
+}
+
+func (e ErrAppException) Error() string {
+	return fmt.Sprintf("abci app exception: %s", e.Message)
+}
+
+// ErrQueueFull is returned when a request can't be queued because the
+// client's request queue is full. It is also reported by Error()
+// while the queue is saturated.
+type ErrQueueFull struct {
+	Size int // capacity of the request queue
+}
+
+func (e ErrQueueFull) Error() string {
+	return fmt.Sprintf("abci client request queue is full (size %d)", e.Size)
+}
+
+// ErrTimeout is returned when a call doesn't complete in time.
+type ErrTimeout struct {
+	Err error // the underlying error
+}
+
+func (e ErrTimeout) Error() string {
+	return fmt.Sprintf("abci call timed out: %v", e.Err)
+}
+
+// ErrUnsupportedCall is returned for calls the client doesn't make,
+// eg. consensus calls on a failover client that doesn't pin them to the primary.
+type ErrUnsupportedCall struct {
+	Method string
+}
+
+func (e ErrUnsupportedCall) Error() string {
+	return fmt.Sprintf("abci client doesn't support %s", e.Method)
+}
+
+// ErrIncompatibleVersion is returned by CheckVersion when the server speaks
+// an ABCI version the client can't talk to, or doesn't report its version.
+type ErrIncompatibleVersion struct {
+	Client string // ABCI version of the client
+	Server string // ABCI version of the server, empty if not reported
+}
+
+func (e ErrIncompatibleVersion) Error() string {
+	if e.Server == "" {
+		return fmt.Sprintf("abci server doesn't report its ABCI version, it is probably older than the client's %s", e.Client)
+	}
+	return fmt.Sprintf("abci server version %s is incompatible with the client's %s", e.Server, e.Client)
+}
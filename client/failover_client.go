@@ -0,0 +1,732 @@
+package abcicli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tendermint/abci/types"
+	cmn "github.com/tendermint/tmlibs/common"
+)
+
+const (
+	failoverHealthCheckIntervalSeconds = 1
+	failoverHealthCheckTimeoutSeconds  = 3
+	failoverLatencyWeight              = 0.2 // weight of the latest sample in the latency average
+
+	// the message of the health check echoes, which aren't passed on
+	// to the response callback
+	failoverHealthCheckMessage = "abci.failoverClient health check"
+)
+
+// FailoverStrategy decides which healthy endpoint a read-only call goes to.
+type FailoverStrategy int
+
+const (
+	// RoundRobin spreads calls evenly across the healthy endpoints.
+	RoundRobin FailoverStrategy = iota
+	// LeastLatency sends calls to the healthy endpoint that answers fastest.
+	LeastLatency
+)
+
+var errNoHealthyEndpoint = errors.New("no healthy endpoint")
+
+var _ Client = (*failoverClient)(nil)
+
+// A client for several replicas of the same app.
+// Read-only calls (Echo, Info, Query) go to a healthy endpoint,
+// picked by the strategy, and Sync calls fail over to the next one on error.
+// Endpoints are health-checked with Echo, and reconnected when they fail.
+// Calls that change the state of the app (SetOption, CheckTx and the consensus
+// calls) are rejected with ErrUnsupportedCall, unless they are pinned to the
+// primary endpoint, the first one, which they never fail over from.
+// So are the state sync calls, as replicas may have different snapshots.
+type failoverClient struct {
+	cmn.BaseService
+
+	addrs             []string
+	transport         string
+	mustConnect       bool
+	strategy          FailoverStrategy
+	pinToPrimary      bool
+	healthInterval    time.Duration
+	healthTimeout     time.Duration
+	newEndpointClient func(addr string) (Client, error)
+
+	mtx       sync.Mutex
+	endpoints []*endpoint
+	next      int                                   // next endpoint for round robin
+	resCb     func(*types.Request, *types.Response) // listens to all callbacks
+}
+
+// endpoint is the client for one replica and its health.
+type endpoint struct {
+	addr string
+
+	mtx     sync.Mutex
+	client  Client
+	healthy bool
+	latency time.Duration // moving average of the call latency
+}
+
+// NewFailoverClient returns a client for the apps at the given addresses,
+// the first one being the primary. Endpoints use the socket transport unless
+// FailoverTransport is given. If mustConnect is true, Start fails unless at
+// least one endpoint could be connected to.
+func NewFailoverClient(addrs []string, mustConnect bool, options ...FailoverClientOption) *failoverClient {
+	cli := &failoverClient{
+		addrs:          addrs,
+		transport:      "socket",
+		mustConnect:    mustConnect,
+		strategy:       RoundRobin,
+		healthInterval: failoverHealthCheckIntervalSeconds * time.Second,
+		healthTimeout:  failoverHealthCheckTimeoutSeconds * time.Second,
+	}
+	cli.newEndpointClient = func(addr string) (Client, error) {
+		// a grpc client waits for the server to be healthy, so it is
+		// bounded like a health check, not to hang on a replica that is down
+		if cli.transport == "grpc" {
+			return NewGRPCClient(addr, true, GRPCConnectTimeout(cli.healthTimeout)), nil
+		}
+		return NewClient(addr, cli.transport, true)
+	}
+	cli.BaseService = *cmn.NewBaseService(nil, "failoverClient", cli)
+	for _, option := range options {
+		option(cli)
+	}
+	return cli
+}
+
+func (cli *failoverClient) OnStart() error {
+	if err := cli.BaseService.OnStart(); err != nil {
+		return err
+	}
+	if len(cli.addrs) == 0 {
+		return errors.New("abci.failoverClient has no endpoints")
+	}
+
+	endpoints := make([]*endpoint, len(cli.addrs))
+	healthy := 0
+	for i, addr := range cli.addrs {
+		endpoints[i] = &endpoint{addr: addr}
+		if cli.connect(endpoints[i]) {
+			healthy++
+		}
+	}
+	if healthy == 0 && cli.mustConnect {
+		for _, e := range endpoints {
+			e.stop()
+		}
+		return fmt.Errorf("abci.failoverClient failed to connect to %v", strings.Join(cli.addrs, ","))
+	}
+
+	cli.mtx.Lock()
+	cli.endpoints = endpoints
+	cli.mtx.Unlock()
+
+	go cli.healthCheckRoutine()
+	return nil
+}
+
+func (cli *failoverClient) OnStop() {
+	cli.BaseService.OnStop()
+	for _, e := range cli.getEndpoints() {
+		e.stop()
+	}
+}
+
+// Error returns an error if no endpoint is healthy.
+func (cli *failoverClient) Error() error {
+	for _, e := range cli.getEndpoints() {
+		if e.isHealthy() {
+			return nil
+		}
+	}
+	return ErrConnectionLost{errNoHealthyEndpoint}
+}
+
+// Set listener for all responses
+// NOTE: callback may get internally generated flush responses.
+func (cli *failoverClient) SetResponseCallback(resCb Callback) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	cli.resCb = resCb
+}
+
+// forwardResponse passes the responses of the endpoints on to the response
+// callback, but for the health checks.
+func (cli *failoverClient) forwardResponse(req *types.Request, res *types.Response) {
+	if echo := req.GetEcho(); echo != nil && echo.Message == failoverHealthCheckMessage {
+		return
+	}
+	cli.mtx.Lock()
+	resCb := cli.resCb
+	cli.mtx.Unlock()
+	if resCb != nil {
+		resCb(req, res)
+	}
+}
+
+//----------------------------------------
+// Endpoints
+
+// connect starts a new client for the endpoint, replacing the old one.
+func (cli *failoverClient) connect(e *endpoint) bool {
+	e.stop()
+
+	client, err := cli.newEndpointClient(e.addr)
+	if err != nil {
+		cli.Logger.Error("Error creating endpoint client", "addr", e.addr, "err", err)
+		return false
+	}
+	client.SetLogger(cli.Logger.With("endpoint", e.addr))
+	client.SetResponseCallback(cli.forwardResponse)
+	if err := client.Start(); err != nil {
+		cli.Logger.Error("Error connecting to endpoint", "addr", e.addr, "err", err)
+		return false
+	}
+
+	e.mtx.Lock()
+	e.client = client
+	e.mtx.Unlock()
+	return cli.checkHealth(e)
+}
+
+// checkHealth echoes the endpoint and records its health and latency.
+func (cli *failoverClient) checkHealth(e *endpoint) bool {
+	client := e.getClient()
+	if client == nil {
+		return false
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.EchoSync(failoverHealthCheckMessage)
+		done <- err
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(cli.healthTimeout):
+		err = ErrTimeout{errors.New("health check")}
+		client.Stop() // releases the pending echo
+	}
+	if err != nil {
+		cli.Logger.Error("Endpoint is not healthy", "addr", e.addr, "err", err)
+		e.setHealthy(false)
+		return false
+	}
+
+	e.recordLatency(time.Since(start))
+	e.setHealthy(true)
+	return true
+}
+
+// healthCheckRoutine checks the healthy endpoints and reconnects the others.
+func (cli *failoverClient) healthCheckRoutine() {
+	for {
+		select {
+		case <-cli.Quit():
+			return
+		case <-time.After(cli.healthInterval):
+		}
+
+		for _, e := range cli.getEndpoints() {
+			if needsReconnect(e.getClient()) {
+				if cli.connect(e) {
+					cli.Logger.Info("Reconnected to endpoint", "addr", e.addr)
+				}
+				continue
+			}
+			cli.checkHealth(e)
+		}
+	}
+}
+
+// A client needs a new connection once it is stopped, or has failed.
+// A full request queue is only a temporary failure.
+func needsReconnect(client Client) bool {
+	if client == nil || !client.IsRunning() {
+		return true
+	}
+	switch client.Error().(type) {
+	case nil, ErrQueueFull:
+		return false
+	}
+	return true
+}
+
+func (cli *failoverClient) getEndpoints() []*endpoint {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.endpoints
+}
+
+func (cli *failoverClient) primary() Client {
+	endpoints := cli.getEndpoints()
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return endpoints[0].getClient()
+}
+
+// candidates returns the healthy endpoints, in the order they should be tried.
+func (cli *failoverClient) candidates() []*endpoint {
+	cli.mtx.Lock()
+	var healthy []*endpoint
+	for _, e := range cli.endpoints {
+		if e.isHealthy() {
+			healthy = append(healthy, e)
+		}
+	}
+	if len(healthy) == 0 {
+		cli.mtx.Unlock()
+		return nil
+	}
+	start := cli.next % len(healthy)
+	cli.next++
+	cli.mtx.Unlock()
+
+	switch cli.strategy {
+	case LeastLatency:
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].getLatency() < healthy[j].getLatency()
+		})
+	default:
+		healthy = append(healthy[start:], healthy[:start]...)
+	}
+	return healthy
+}
+
+// pick returns the client of the endpoint for the next read-only call.
+func (cli *failoverClient) pick() Client {
+	for _, e := range cli.candidates() {
+		if client := e.getClient(); client != nil {
+			return client
+		}
+	}
+	return nil
+}
+
+// route makes a read-only call, failing over to the next healthy endpoint
+// on error. The endpoints that fail are marked as unhealthy.
+func (cli *failoverClient) route(call func(Client) error) error {
+	err := error(ErrConnectionLost{errNoHealthyEndpoint})
+	for _, e := range cli.candidates() {
+		client := e.getClient()
+		if client == nil {
+			continue
+		}
+		start := time.Now()
+		if err = call(client); err == nil {
+			e.recordLatency(time.Since(start))
+			return nil
+		}
+		cli.Logger.Error("Call failed, failing over", "addr", e.addr, "err", err)
+		e.setHealthy(false)
+	}
+	return err
+}
+
+// pinned returns the primary's client for calls that change the state of
+// the app, or an error if they are not allowed.
+func (cli *failoverClient) pinned(method string) (Client, error) {
+	if !cli.pinToPrimary {
+		return nil, ErrUnsupportedCall{method}
+	}
+	client := cli.primary()
+	if client == nil {
+		return nil, ErrConnectionLost{errClientStopped}
+	}
+	return client, nil
+}
+
+func (e *endpoint) getClient() Client {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return e.client
+}
+
+func (e *endpoint) isHealthy() bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return e.healthy
+}
+
+func (e *endpoint) setHealthy(healthy bool) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.healthy = healthy
+}
+
+func (e *endpoint) getLatency() time.Duration {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return e.latency
+}
+
+func (e *endpoint) recordLatency(latency time.Duration) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.latency == 0 {
+		e.latency = latency
+		return
+	}
+	e.latency = time.Duration(failoverLatencyWeight*float64(latency) + (1-failoverLatencyWeight)*float64(e.latency))
+}
+
+func (e *endpoint) stop() {
+	e.mtx.Lock()
+	client := e.client
+	e.client = nil
+	e.healthy = false
+	e.mtx.Unlock()
+	if client != nil && client.IsRunning() {
+		client.Stop()
+	}
+}
+
+//----------------------------------------
+
+// FlushAsync flushes all the endpoints.
+// The returned ReqRes is done once they are all flushed.
+func (cli *failoverClient) FlushAsync() *ReqRes {
+	reqres := NewReqRes(types.ToRequestFlush())
+	var flushes []*ReqRes
+	for _, e := range cli.getEndpoints() {
+		if client := e.getClient(); client != nil {
+			flushes = append(flushes, client.FlushAsync())
+		}
+	}
+	go func() {
+		for _, flush := range flushes {
+			flush.Wait()
+		}
+		reqres.Response = types.ToResponseFlush()
+		reqres.Done()
+		if cb := reqres.setDoneAndGetCallback(); cb != nil {
+			cb(reqres.Response)
+		}
+	}()
+	return reqres
+}
+
+func (cli *failoverClient) EchoAsync(msg string) *ReqRes {
+	client := cli.pick()
+	if client == nil {
+		return rejectedReqRes(ErrConnectionLost{errNoHealthyEndpoint}, types.ToRequestEcho(msg))
+	}
+	return client.EchoAsync(msg)
+}
+
+func (cli *failoverClient) InfoAsync(req types.RequestInfo) *ReqRes {
+	client := cli.pick()
+	if client == nil {
+		return rejectedReqRes(ErrConnectionLost{errNoHealthyEndpoint}, types.ToRequestInfo(req))
+	}
+	return client.InfoAsync(req)
+}
+
+func (cli *failoverClient) QueryAsync(req types.RequestQuery) *ReqRes {
+	client := cli.pick()
+	if client == nil {
+		return rejectedReqRes(ErrConnectionLost{errNoHealthyEndpoint}, types.ToRequestQuery(req))
+	}
+	return client.QueryAsync(req)
+}
+
+func (cli *failoverClient) SetOptionAsync(req types.RequestSetOption) *ReqRes {
+	client, err := cli.pinned("SetOption")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestSetOption(req))
+	}
+	return client.SetOptionAsync(req)
+}
+
+func (cli *failoverClient) DeliverTxAsync(tx []byte) *ReqRes {
+	client, err := cli.pinned("DeliverTx")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestDeliverTx(tx))
+	}
+	return client.DeliverTxAsync(tx)
+}
+
+func (cli *failoverClient) CheckTxAsync(req types.RequestCheckTx) *ReqRes {
+	client, err := cli.pinned("CheckTx")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestCheckTx(req))
+	}
+	return client.CheckTxAsync(req)
+}
+
+func (cli *failoverClient) CommitAsync() *ReqRes {
+	client, err := cli.pinned("Commit")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestCommit())
+	}
+	return client.CommitAsync()
+}
+
+func (cli *failoverClient) InitChainAsync(req types.RequestInitChain) *ReqRes {
+	client, err := cli.pinned("InitChain")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestInitChain(req))
+	}
+	return client.InitChainAsync(req)
+}
+
+func (cli *failoverClient) BeginBlockAsync(req types.RequestBeginBlock) *ReqRes {
+	client, err := cli.pinned("BeginBlock")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestBeginBlock(req))
+	}
+	return client.BeginBlockAsync(req)
+}
+
+func (cli *failoverClient) EndBlockAsync(req types.RequestEndBlock) *ReqRes {
+	client, err := cli.pinned("EndBlock")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestEndBlock(req))
+	}
+	return client.EndBlockAsync(req)
+}
+
+func (cli *failoverClient) DeliverTxBatchAsync(req types.RequestDeliverTxBatch) *ReqRes {
+	client, err := cli.pinned("DeliverTxBatch")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestDeliverTxBatch(req))
+	}
+	return client.DeliverTxBatchAsync(req)
+}
+
+func (cli *failoverClient) CheckTxBatchAsync(req types.RequestCheckTxBatch) *ReqRes {
+	client, err := cli.pinned("CheckTxBatch")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestCheckTxBatch(req))
+	}
+	return client.CheckTxBatchAsync(req)
+}
+
+func (cli *failoverClient) ListSnapshotsAsync(req types.RequestListSnapshots) *ReqRes {
+	client, err := cli.pinned("ListSnapshots")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestListSnapshots(req))
+	}
+	return client.ListSnapshotsAsync(req)
+}
+
+func (cli *failoverClient) OfferSnapshotAsync(req types.RequestOfferSnapshot) *ReqRes {
+	client, err := cli.pinned("OfferSnapshot")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestOfferSnapshot(req))
+	}
+	return client.OfferSnapshotAsync(req)
+}
+
+func (cli *failoverClient) LoadSnapshotChunkAsync(req types.RequestLoadSnapshotChunk) *ReqRes {
+	client, err := cli.pinned("LoadSnapshotChunk")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestLoadSnapshotChunk(req))
+	}
+	return client.LoadSnapshotChunkAsync(req)
+}
+
+func (cli *failoverClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotChunk) *ReqRes {
+	client, err := cli.pinned("ApplySnapshotChunk")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestApplySnapshotChunk(req))
+	}
+	return client.ApplySnapshotChunkAsync(req)
+}
+
+func (cli *failoverClient) PrepareProposalAsync(req types.RequestPrepareProposal) *ReqRes {
+	client, err := cli.pinned("PrepareProposal")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestPrepareProposal(req))
+	}
+	return client.PrepareProposalAsync(req)
+}
+
+func (cli *failoverClient) ProcessProposalAsync(req types.RequestProcessProposal) *ReqRes {
+	client, err := cli.pinned("ProcessProposal")
+	if err != nil {
+		return rejectedReqRes(err, types.ToRequestProcessProposal(req))
+	}
+	return client.ProcessProposalAsync(req)
+}
+
+// rejectedReqRes returns a ReqRes that failed with the error.
+func rejectedReqRes(err error, req *types.Request) *ReqRes {
+	reqres := NewReqRes(req)
+	reqres.fail(err)
+	return reqres
+}
+
+//----------------------------------------
+
+// FlushSync flushes all the endpoints,
+// and returns an error only if none of them could be flushed.
+func (cli *failoverClient) FlushSync() error {
+	err := error(ErrConnectionLost{errNoHealthyEndpoint})
+	for _, e := range cli.getEndpoints() {
+		client := e.getClient()
+		if client == nil {
+			continue
+		}
+		if flushErr := client.FlushSync(); flushErr != nil {
+			e.setHealthy(false)
+		} else {
+			err = nil
+		}
+	}
+	return err
+}
+
+func (cli *failoverClient) EchoSync(msg string) (res *types.ResponseEcho, err error) {
+	err = cli.route(func(client Client) (err error) {
+		res, err = client.EchoSync(msg)
+		return err
+	})
+	return res, err
+}
+
+func (cli *failoverClient) InfoSync(req types.RequestInfo) (res *types.ResponseInfo, err error) {
+	err = cli.route(func(client Client) (err error) {
+		res, err = client.InfoSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *failoverClient) QuerySync(req types.RequestQuery) (res *types.ResponseQuery, err error) {
+	err = cli.route(func(client Client) (err error) {
+		res, err = client.QuerySync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *failoverClient) SetOptionSync(req types.RequestSetOption) (*types.ResponseSetOption, error) {
+	client, err := cli.pinned("SetOption")
+	if err != nil {
+		return nil, err
+	}
+	return client.SetOptionSync(req)
+}
+
+func (cli *failoverClient) DeliverTxSync(tx []byte) (*types.ResponseDeliverTx, error) {
+	client, err := cli.pinned("DeliverTx")
+	if err != nil {
+		return nil, err
+	}
+	return client.DeliverTxSync(tx)
+}
+
+func (cli *failoverClient) CheckTxSync(req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	client, err := cli.pinned("CheckTx")
+	if err != nil {
+		return nil, err
+	}
+	return client.CheckTxSync(req)
+}
+
+func (cli *failoverClient) CommitSync() (*types.ResponseCommit, error) {
+	client, err := cli.pinned("Commit")
+	if err != nil {
+		return nil, err
+	}
+	return client.CommitSync()
+}
+
+func (cli *failoverClient) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
+	client, err := cli.pinned("InitChain")
+	if err != nil {
+		return nil, err
+	}
+	return client.InitChainSync(req)
+}
+
+func (cli *failoverClient) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
+	client, err := cli.pinned("BeginBlock")
+	if err != nil {
+		return nil, err
+	}
+	return client.BeginBlockSync(req)
+}
+
+func (cli *failoverClient) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+	client, err := cli.pinned("EndBlock")
+	if err != nil {
+		return nil, err
+	}
+	return client.EndBlockSync(req)
+}
+
+func (cli *failoverClient) DeliverTxBatchSync(req types.RequestDeliverTxBatch) (*types.ResponseDeliverTxBatch, error) {
+	client, err := cli.pinned("DeliverTxBatch")
+	if err != nil {
+		return nil, err
+	}
+	return client.DeliverTxBatchSync(req)
+}
+
+func (cli *failoverClient) CheckTxBatchSync(req types.RequestCheckTxBatch) (*types.ResponseCheckTxBatch, error) {
+	client, err := cli.pinned("CheckTxBatch")
+	if err != nil {
+		return nil, err
+	}
+	return client.CheckTxBatchSync(req)
+}
+
+func (cli *failoverClient) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	client, err := cli.pinned("ListSnapshots")
+	if err != nil {
+		return nil, err
+	}
+	return client.ListSnapshotsSync(req)
+}
+
+func (cli *failoverClient) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	client, err := cli.pinned("OfferSnapshot")
+	if err != nil {
+		return nil, err
+	}
+	return client.OfferSnapshotSync(req)
+}
+
+func (cli *failoverClient) LoadSnapshotChunkSync(req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	client, err := cli.pinned("LoadSnapshotChunk")
+	if err != nil {
+		return nil, err
+	}
+	return client.LoadSnapshotChunkSync(req)
+}
+
+func (cli *failoverClient) ApplySnapshotChunkSync(req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	client, err := cli.pinned("ApplySnapshotChunk")
+	if err != nil {
+		return nil, err
+	}
+	return client.ApplySnapshotChunkSync(req)
+}
+
+func (cli *failoverClient) PrepareProposalSync(req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	client, err := cli.pinned("PrepareProposal")
+	if err != nil {
+		return nil, err
+	}
+	return client.PrepareProposalSync(req)
+}
+
+func (cli *failoverClient) ProcessProposalSync(req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	client, err := cli.pinned("ProcessProposal")
+	if err != nil {
+		return nil, err
+	}
+	return client.ProcessProposalSync(req)
+}
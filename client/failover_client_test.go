@@ -0,0 +1,152 @@
+package abcicli_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmn "github.com/tendermint/tmlibs/common"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/server"
+	"github.com/tendermint/abci/types"
+)
+
+// infoApp tells which replica answered.
+type infoApp struct {
+	types.BaseApplication
+	name string
+}
+
+func (app infoApp) Info(req types.RequestInfo) types.ResponseInfo {
+	return types.ResponseInfo{Data: app.name}
+}
+
+func startReplica(t *testing.T, name string) (string, cmn.Service) {
+	socket := fmt.Sprintf("unix://test-failover-%s.sock", name)
+	srv, err := server.NewServer(socket, "socket", infoApp{name: name})
+	require.NoError(t, err)
+	srv.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, srv.Start())
+	return socket, srv
+}
+
+func TestFailoverClient(t *testing.T) {
+	addrA, srvA := startReplica(t, "a")
+	defer srvA.Stop()
+	addrB, srvB := startReplica(t, "b")
+	defer srvB.Stop()
+
+	c, err := abcicli.NewClient(addrA+","+addrB, "failover", true)
+	require.NoError(t, err)
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	// round robin across both replicas
+	seen := make(map[string]int)
+	for i := 0; i < 4; i++ {
+		res, err := c.InfoSync(types.RequestInfo{})
+		require.NoError(t, err)
+		seen[res.Data]++
+	}
+	assert.Equal(t, map[string]int{"a": 2, "b": 2}, seen)
+
+	// consensus calls are rejected
+	_, err = c.CommitSync()
+	assert.Equal(t, abcicli.ErrUnsupportedCall{"Commit"}, err)
+	reqres := c.CommitAsync()
+	reqres.Wait()
+	assert.Nil(t, reqres.Response)
+	assert.Equal(t, abcicli.ErrUnsupportedCall{"Commit"}, reqres.Err)
+
+	// calls fail over to the replica that is left
+	srvA.Stop()
+	for i := 0; i < 4; i++ {
+		res, err := c.InfoSync(types.RequestInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, "b", res.Data)
+	}
+	assert.NoError(t, c.Error())
+}
+
+func TestFailoverClientPinToPrimary(t *testing.T) {
+	addrA, srvA := startReplica(t, "pa")
+	defer srvA.Stop()
+	addrB, srvB := startReplica(t, "pb")
+	defer srvB.Stop()
+
+	c := abcicli.NewFailoverClient([]string{addrA, addrB}, true,
+		abcicli.FailoverPinToPrimary(),
+		abcicli.FailoverRouting(abcicli.LeastLatency),
+		abcicli.FailoverHealthCheck(50*time.Millisecond, time.Second))
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	_, err := c.CommitSync()
+	assert.NoError(t, err)
+
+	// the primary is not failed over from
+	srvA.Stop()
+	time.Sleep(200 * time.Millisecond)
+	_, err = c.CommitSync()
+	assert.Error(t, err)
+	res, err := c.InfoSync(types.RequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "pb", res.Data)
+}
+
+func TestFailoverClientHealthChecksNotForwarded(t *testing.T) {
+	addr, srv := startReplica(t, "h")
+	defer srv.Stop()
+
+	c := abcicli.NewFailoverClient([]string{addr}, true,
+		abcicli.FailoverHealthCheck(10*time.Millisecond, time.Second))
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	echoes := make(chan string, 100)
+	c.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if echo := res.GetEcho(); echo != nil {
+			echoes <- echo.Message
+		}
+	})
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	_, err := c.EchoSync("hello")
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	require.Len(t, echoes, 1)
+	assert.Equal(t, "hello", <-echoes)
+}
+
+func TestFailoverClientGRPCReplicaDown(t *testing.T) {
+	socket := "unix://test-failover-grpc.sock"
+	srv := server.NewGRPCServer(socket, types.NewGRPCApplication(infoApp{name: "g"}))
+	srv.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	// the replica that is down doesn't hang the start
+	c := abcicli.NewFailoverClient([]string{"unix://test-failover-grpc-down.sock", socket}, true,
+		abcicli.FailoverTransport("grpc"),
+		abcicli.FailoverHealthCheck(50*time.Millisecond, 500*time.Millisecond))
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	started := make(chan error, 1)
+	go func() { started <- c.Start() }()
+	select {
+	case err := <-started:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Start hung on a replica that is down")
+	}
+	defer c.Stop()
+
+	res, err := c.InfoSync(types.RequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "g", res.Data)
+}
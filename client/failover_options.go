@@ -0,0 +1,41 @@
+package abcicli
+
+import (
+	"time"
+)
+
+// FailoverClientOption sets an optional parameter on the failoverClient.
+type FailoverClientOption func(*failoverClient)
+
+// FailoverRouting sets how read-only calls are spread across the
+// healthy endpoints. Defaults to RoundRobin.
+func FailoverRouting(strategy FailoverStrategy) FailoverClientOption {
+	return func(cli *failoverClient) {
+		cli.strategy = strategy
+	}
+}
+
+// FailoverTransport sets the transport of the endpoints, "socket" or "grpc".
+// Defaults to "socket".
+func FailoverTransport(transport string) FailoverClientOption {
+	return func(cli *failoverClient) {
+		cli.transport = transport
+	}
+}
+
+// FailoverPinToPrimary sends the calls that change the state of the app
+// to the primary endpoint, instead of rejecting them.
+func FailoverPinToPrimary() FailoverClientOption {
+	return func(cli *failoverClient) {
+		cli.pinToPrimary = true
+	}
+}
+
+// FailoverHealthCheck sets how often the endpoints are health-checked,
+// and how long a health check may take. Defaults to 1s and 3s.
+func FailoverHealthCheck(interval, timeout time.Duration) FailoverClientOption {
+	return func(cli *failoverClient) {
+		cli.healthInterval = interval
+		cli.healthTimeout = timeout
+	}
+}
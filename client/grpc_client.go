@@ -1,6 +1,8 @@
 package abcicli
 
 import (
+	"container/list"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -8,33 +10,67 @@ import (
 
 	context "golang.org/x/net/context"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/tendermint/abci/types"
 	cmn "github.com/tendermint/tmlibs/common"
 )
 
+const grpcMaxConcurrentCalls = 16 // calls in flight at once
+
 var _ Client = (*grpcClient)(nil)
 
 // A stripped copy of the remoteClient that makes
-// synchronous calls using grpc
+// calls using grpc, pipelining XxxAsync calls
 type grpcClient struct {
 	cmn.BaseService
-	mustConnect bool
+	mustConnect    bool
+	checkVersion   bool          // check the ABCI version of the server on start
+	connectTimeout time.Duration // how long Start waits for the server to be healthy, if > 0
 
+	conn   *grpc.ClientConn
 	client types.ABCIApplicationClient
 
-	mtx   sync.Mutex
-	addr  string
-	err   error
-	resCb func(*types.Request, *types.Response) // listens to all callbacks
+	creds              credentials.TransportCredentials
+	dialOpts           []grpc.DialOption
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+	maxPendingCalls    int
+	maxConcurrentCalls int
+
+	pendingSem chan struct{} // bounds the number of outstanding calls
+	callSem    chan struct{} // bounds the number of calls in flight
+
+	mtx         sync.Mutex
+	addr        string
+	err         error
+	ctx         context.Context                       // cancelled on stop to release pending calls
+	cancel      context.CancelFunc                    // cancels ctx
+	reqSent     *list.List                            // pending calls, in submission order
+	released    []*ReqRes                             // finished calls whose callbacks are to be invoked
+	delivering  bool                                  // whether a go-routine is invoking callbacks
+	lastOrdered chan struct{}                         // closed when the last consensus call finishes
+	resCb       func(*types.Request, *types.Response) // listens to all callbacks
 }
 
-func NewGRPCClient(addr string, mustConnect bool) *grpcClient {
+func NewGRPCClient(addr string, mustConnect bool, options ...GRPCClientOption) *grpcClient {
 	cli := &grpcClient{
 		addr:        addr,
 		mustConnect: mustConnect,
+
+		maxPendingCalls:    reqQueueSize,
+		maxConcurrentCalls: grpcMaxConcurrentCalls,
+
+		reqSent: list.New(),
 	}
 	cli.BaseService = *cmn.NewBaseService(nil, "grpcClient", cli)
+	for _, option := range options {
+		option(cli)
+	}
+	cli.pendingSem = make(chan struct{}, cli.maxPendingCalls)
+	cli.callSem = make(chan struct{}, cli.maxConcurrentCalls)
 	return cli
 }
 
@@ -48,7 +84,7 @@ func (cli *grpcClient) OnStart() error {
 	}
 RETRY_LOOP:
 	for {
-		conn, err := grpc.Dial(cli.addr, grpc.WithInsecure(), grpc.WithDialer(dialerFunc))
+		conn, err := grpc.Dial(cli.addr, cli.grpcDialOptions()...)
 		if err != nil {
 			if cli.mustConnect {
 				return err
@@ -58,45 +94,141 @@ RETRY_LOOP:
 			continue RETRY_LOOP
 		}
 
-		cli.Logger.Info("Dialed server. Waiting for echo.", "addr", cli.addr)
+		cli.Logger.Info("Dialed server. Waiting for health check.", "addr", cli.addr)
 		client := types.NewABCIApplicationClient(conn)
-
-	ENSURE_CONNECTED:
-		for {
-			_, err := client.Echo(context.Background(), &types.RequestEcho{"hello"}, grpc.FailFast(true))
-			if err == nil {
-				break ENSURE_CONNECTED
-			}
-			cli.Logger.Error("Echo failed", "err", err)
-			time.Sleep(time.Second * echoRetryIntervalSeconds)
+		if err := cli.waitForHealth(conn, client); err != nil {
+			conn.Close()
+			return err
 		}
 
+		cli.mtx.Lock()
+		cli.conn = conn
 		cli.client = client
+		cli.ctx, cli.cancel = context.WithCancel(context.Background())
+		cli.lastOrdered = nil
+		cli.mtx.Unlock()
 		return nil
 	}
 }
 
+// Start starts the client, then checks the ABCI version of the server
+// if required, stopping the client if it isn't compatible.
+// The check needs the client running, so it can't be done in OnStart.
+func (cli *grpcClient) Start() error {
+	if err := cli.BaseService.Start(); err != nil {
+		return err
+	}
+	if cli.checkVersion {
+		if _, err := CheckVersion(cli); err != nil {
+			cli.StopForError(err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (cli *grpcClient) grpcDialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{grpc.WithDialer(dialerFunc)}
+	if cli.creds != nil {
+		opts = append(opts, grpc.WithTransportCredentials(cli.creds))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if len(cli.unaryInterceptors) > 0 {
+		opts = append(opts, grpc.WithUnaryInterceptor(chainUnaryClientInterceptors(cli.unaryInterceptors)))
+	}
+	if len(cli.streamInterceptors) > 0 {
+		opts = append(opts, grpc.WithStreamInterceptor(chainStreamClientInterceptors(cli.streamInterceptors)))
+	}
+	return append(opts, cli.dialOpts...)
+}
+
+// waitForHealth checks the health of the server until it is serving.
+// It gives up when the client is stopped, or after the connect timeout.
+func (cli *grpcClient) waitForHealth(conn *grpc.ClientConn, client types.ABCIApplicationClient) error {
+	ctx := context.Background()
+	if cli.connectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cli.connectTimeout)
+		defer cancel()
+	}
+	healthClient := healthpb.NewHealthClient(conn)
+	for {
+		ok, err := checkHealth(ctx, healthClient, client)
+		if ok {
+			return nil
+		}
+		if err != nil {
+			cli.Logger.Error("Health check failed", "err", err)
+		} else {
+			cli.Logger.Info("Application not ready yet", "addr", cli.addr)
+			err = errNotServing
+		}
+
+		select {
+		case <-cli.Quit():
+			return ErrConnectionLost{errClientStopped}
+		case <-ctx.Done():
+			return ErrTimeout{fmt.Errorf("abci.grpcClient failed to connect to %v: %v", cli.addr, err)}
+		case <-time.After(time.Second * healthCheckRetryIntervalSeconds):
+		}
+	}
+}
+
+// checkHealth asks the server's gRPC health service whether the ABCI service
+// is serving. Servers without a health service are probed with Echo instead.
+func checkHealth(ctx context.Context, healthClient healthpb.HealthClient, client types.ABCIApplicationClient) (bool, error) {
+	res, err := healthClient.Check(ctx,
+		&healthpb.HealthCheckRequest{Service: types.ABCIApplicationServiceName}, grpc.FailFast(true))
+	if grpc.Code(err) == codes.Unimplemented {
+		_, err = client.Echo(ctx, &types.RequestEcho{"hello"}, grpc.FailFast(true))
+		return err == nil, err
+	}
+	if err != nil {
+		return false, err
+	}
+	return res.Status == healthpb.HealthCheckResponse_SERVING, nil
+}
+
+// OnStop cancels pending calls, which then fail with an error,
+// and closes the connection.
 func (cli *grpcClient) OnStop() {
 	cli.BaseService.OnStop()
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
-	// TODO: how to close conn? its not a net.Conn and grpc doesn't expose a Close()
-	/*if cli.client.conn != nil {
-		cli.client.conn.Close()
-	}*/
+	if cli.cancel != nil {
+		cli.cancel()
+	}
+	if cli.conn != nil {
+		if err := cli.conn.Close(); err != nil {
+			cli.Logger.Error("Error closing connection", "err", err)
+		}
+		cli.conn = nil
+	}
 }
 
-func (cli *grpcClient) StopForError(err error) {
+// OnReset clears the error, so the client can be started again.
+func (cli *grpcClient) OnReset() error {
 	cli.mtx.Lock()
-	if !cli.IsRunning() {
-		return
-	}
+	defer cli.mtx.Unlock()
+	cli.err = nil
+	return nil
+}
 
+// Stop the client and set the error.
+// The error is set even if the client is already stopped,
+// so calls failing after Stop don't return empty responses without an error.
+func (cli *grpcClient) StopForError(err error) {
+	cli.mtx.Lock()
 	if cli.err == nil {
 		cli.err = err
 	}
 	cli.mtx.Unlock()
 
+	if !cli.IsRunning() {
+		return
+	}
+
 	cli.Logger.Error(fmt.Sprintf("Stopping abci.grpcClient for error: %v", err.Error()))
 	cli.Stop()
 }
@@ -116,186 +248,532 @@ func (cli *grpcClient) SetResponseCallback(resCb Callback) {
 }
 
 //----------------------------------------
-// GRPC calls are synchronous, but some callbacks expect to be called asynchronously
-// (eg. the mempool expects to be able to lock to remove bad txs from cache).
-// To accommodate, we finish each call in its own go-routine,
-// which is expensive, but easy - if you want something better, use the socket protocol!
-// maybe one day, if people really want it, we use grpc streams,
-// but hopefully not :D
+// GRPC calls are synchronous, so each XxxAsync call runs in its own go-routine
+// and returns right away. At most maxPendingCalls calls may be outstanding
+// (further XxxAsync calls block), and at most maxConcurrentCalls of them are
+// in flight at once. Consensus calls (InitChain, BeginBlock, DeliverTx, EndBlock,
+// Commit) are executed one at a time, in submission order.
+// Whatever the order calls complete in, waiters are released and callbacks
+// are invoked in submission order, like with the socket client
+// (eg. the mempool expects CheckTx responses in the order it sent the txs).
+
+// grpcCall makes the actual unary call for a request.
+type grpcCall func(context.Context, types.ABCIApplicationClient) (*types.Response, error)
+
+// pendingCall is a ReqRes waiting for its callbacks to be invoked.
+type pendingCall struct {
+	reqres   *ReqRes
+	finished bool
+}
 
 func (cli *grpcClient) EchoAsync(msg string) *ReqRes {
 	req := types.ToRequestEcho(msg)
-	res, err := cli.client.Echo(context.Background(), req.GetEcho(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_Echo{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.Echo(ctx, req.GetEcho(), grpc.FailFast(true))
+		return &types.Response{&types.Response_Echo{res}}, err
+	})
 }
 
 func (cli *grpcClient) FlushAsync() *ReqRes {
 	req := types.ToRequestFlush()
-	res, err := cli.client.Flush(context.Background(), req.GetFlush(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_Flush{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.Flush(ctx, req.GetFlush(), grpc.FailFast(true))
+		return &types.Response{&types.Response_Flush{res}}, err
+	})
 }
 
 func (cli *grpcClient) InfoAsync(params types.RequestInfo) *ReqRes {
 	req := types.ToRequestInfo(params)
-	res, err := cli.client.Info(context.Background(), req.GetInfo(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_Info{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.Info(ctx, req.GetInfo(), grpc.FailFast(true))
+		return &types.Response{&types.Response_Info{res}}, err
+	})
 }
 
 func (cli *grpcClient) SetOptionAsync(params types.RequestSetOption) *ReqRes {
 	req := types.ToRequestSetOption(params)
-	res, err := cli.client.SetOption(context.Background(), req.GetSetOption(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_SetOption{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.SetOption(ctx, req.GetSetOption(), grpc.FailFast(true))
+		return &types.Response{&types.Response_SetOption{res}}, err
+	})
 }
 
 func (cli *grpcClient) DeliverTxAsync(tx []byte) *ReqRes {
 	req := types.ToRequestDeliverTx(tx)
-	res, err := cli.client.DeliverTx(context.Background(), req.GetDeliverTx(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_DeliverTx{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.DeliverTx(ctx, req.GetDeliverTx(), grpc.FailFast(true))
+		return &types.Response{&types.Response_DeliverTx{res}}, err
+	})
 }
 
-func (cli *grpcClient) CheckTxAsync(tx []byte) *ReqRes {
-	req := types.ToRequestCheckTx(tx)
-	res, err := cli.client.CheckTx(context.Background(), req.GetCheckTx(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_CheckTx{res}})
+func (cli *grpcClient) CheckTxAsync(params types.RequestCheckTx) *ReqRes {
+	req := types.ToRequestCheckTx(params)
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.CheckTx(ctx, req.GetCheckTx(), grpc.FailFast(true))
+		return &types.Response{&types.Response_CheckTx{res}}, err
+	})
 }
 
 func (cli *grpcClient) QueryAsync(params types.RequestQuery) *ReqRes {
 	req := types.ToRequestQuery(params)
-	res, err := cli.client.Query(context.Background(), req.GetQuery(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_Query{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.Query(ctx, req.GetQuery(), grpc.FailFast(true))
+		return &types.Response{&types.Response_Query{res}}, err
+	})
 }
 
 func (cli *grpcClient) CommitAsync() *ReqRes {
 	req := types.ToRequestCommit()
-	res, err := cli.client.Commit(context.Background(), req.GetCommit(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_Commit{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.Commit(ctx, req.GetCommit(), grpc.FailFast(true))
+		return &types.Response{&types.Response_Commit{res}}, err
+	})
 }
 
 func (cli *grpcClient) InitChainAsync(params types.RequestInitChain) *ReqRes {
 	req := types.ToRequestInitChain(params)
-	res, err := cli.client.InitChain(context.Background(), req.GetInitChain(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_InitChain{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.InitChain(ctx, req.GetInitChain(), grpc.FailFast(true))
+		return &types.Response{&types.Response_InitChain{res}}, err
+	})
 }
 
 func (cli *grpcClient) BeginBlockAsync(params types.RequestBeginBlock) *ReqRes {
 	req := types.ToRequestBeginBlock(params)
-	res, err := cli.client.BeginBlock(context.Background(), req.GetBeginBlock(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_BeginBlock{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.BeginBlock(ctx, req.GetBeginBlock(), grpc.FailFast(true))
+		return &types.Response{&types.Response_BeginBlock{res}}, err
+	})
 }
 
 func (cli *grpcClient) EndBlockAsync(params types.RequestEndBlock) *ReqRes {
 	req := types.ToRequestEndBlock(params)
-	res, err := cli.client.EndBlock(context.Background(), req.GetEndBlock(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{&types.Response_EndBlock{res}})
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.EndBlock(ctx, req.GetEndBlock(), grpc.FailFast(true))
+		return &types.Response{&types.Response_EndBlock{res}}, err
+	})
+}
+
+func (cli *grpcClient) DeliverTxBatchAsync(params types.RequestDeliverTxBatch) *ReqRes {
+	req := types.ToRequestDeliverTxBatch(params)
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.DeliverTxBatch(ctx, req.GetDeliverTxBatch(), grpc.FailFast(true))
+		return &types.Response{&types.Response_DeliverTxBatch{res}}, err
+	})
+}
+
+func (cli *grpcClient) CheckTxBatchAsync(params types.RequestCheckTxBatch) *ReqRes {
+	req := types.ToRequestCheckTxBatch(params)
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.CheckTxBatch(ctx, req.GetCheckTxBatch(), grpc.FailFast(true))
+		return &types.Response{&types.Response_CheckTxBatch{res}}, err
+	})
+}
+
+func (cli *grpcClient) ListSnapshotsAsync(params types.RequestListSnapshots) *ReqRes {
+	req := types.ToRequestListSnapshots(params)
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.ListSnapshots(ctx, req.GetListSnapshots(), grpc.FailFast(true))
+		return &types.Response{&types.Response_ListSnapshots{res}}, err
+	})
+}
+
+func (cli *grpcClient) OfferSnapshotAsync(params types.RequestOfferSnapshot) *ReqRes {
+	req := types.ToRequestOfferSnapshot(params)
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.OfferSnapshot(ctx, req.GetOfferSnapshot(), grpc.FailFast(true))
+		return &types.Response{&types.Response_OfferSnapshot{res}}, err
+	})
+}
+
+func (cli *grpcClient) LoadSnapshotChunkAsync(params types.RequestLoadSnapshotChunk) *ReqRes {
+	req := types.ToRequestLoadSnapshotChunk(params)
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.LoadSnapshotChunk(ctx, req.GetLoadSnapshotChunk(), grpc.FailFast(true))
+		return &types.Response{&types.Response_LoadSnapshotChunk{res}}, err
+	})
+}
+
+func (cli *grpcClient) ApplySnapshotChunkAsync(params types.RequestApplySnapshotChunk) *ReqRes {
+	req := types.ToRequestApplySnapshotChunk(params)
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.ApplySnapshotChunk(ctx, req.GetApplySnapshotChunk(), grpc.FailFast(true))
+		return &types.Response{&types.Response_ApplySnapshotChunk{res}}, err
+	})
+}
+
+func (cli *grpcClient) PrepareProposalAsync(params types.RequestPrepareProposal) *ReqRes {
+	req := types.ToRequestPrepareProposal(params)
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.PrepareProposal(ctx, req.GetPrepareProposal(), grpc.FailFast(true))
+		return &types.Response{&types.Response_PrepareProposal{res}}, err
+	})
+}
+
+func (cli *grpcClient) ProcessProposalAsync(params types.RequestProcessProposal) *ReqRes {
+	req := types.ToRequestProcessProposal(params)
+	return cli.queueCall(req, func(ctx context.Context, client types.ABCIApplicationClient) (*types.Response, error) {
+		res, err := client.ProcessProposal(ctx, req.GetProcessProposal(), grpc.FailFast(true))
+		return &types.Response{&types.Response_ProcessProposal{res}}, err
+	})
 }
 
-func (cli *grpcClient) finishAsyncCall(req *types.Request, res *types.Response) *ReqRes {
+// queueCall registers the request, so its callbacks run in submission order,
+// and runs the call in its own go-routine.
+// It blocks while maxPendingCalls calls are outstanding.
+func (cli *grpcClient) queueCall(req *types.Request, call grpcCall) *ReqRes {
 	reqres := NewReqRes(req)
-	reqres.Response = res // Set response
-	reqres.Done()         // Release waiters
-	reqres.SetDone()      // so reqRes.SetCallback will run the callback
+	pending := &pendingCall{reqres: reqres}
+
+	cli.mtx.Lock()
+	ctx, client := cli.ctx, cli.client
+	cli.reqSent.PushBack(pending)
+	// consensus calls wait for the previous one to finish
+	var prev <-chan struct{}
+	var done chan struct{}
+	if isOrderedRequest(req) {
+		prev = cli.lastOrdered
+		done = make(chan struct{})
+		cli.lastOrdered = done
+	}
+	cli.mtx.Unlock()
+
+	if ctx == nil {
+		cli.finishCall(pending, nil, ErrConnectionLost{errors.New("abci.grpcClient is not started")})
+		closeIfNotNil(done)
+		return reqres
+	}
+
+	select {
+	case cli.pendingSem <- struct{}{}:
+	case <-ctx.Done():
+		cli.finishCall(pending, nil, ctx.Err())
+		closeIfNotNil(done)
+		return reqres
+	}
 
-	// go routine for callbacks
 	go func() {
-		// Notify reqRes listener if set
-		if cb := reqres.GetCallback(); cb != nil {
-			cb(res)
+		res, err := cli.runCall(ctx, client, call, prev)
+		closeIfNotNil(done)
+		<-cli.pendingSem // callbacks may queue new calls
+		cli.finishCall(pending, res, err)
+	}()
+	return reqres
+}
+
+// runCall waits for the previous ordered call, if any, and for a free slot,
+// then makes the call.
+func (cli *grpcClient) runCall(ctx context.Context, client types.ABCIApplicationClient,
+	call grpcCall, prev <-chan struct{}) (*types.Response, error) {
+	if prev != nil {
+		select {
+		case <-prev:
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		}
+	}
+
+	select {
+	case cli.callSem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-cli.callSem }()
+
+	return call(ctx, client)
+}
+
+// finishCall records the response of a call, then releases waiters for all
+// calls that are finished, and invokes their callbacks, in submission order.
+// The waiters of a Flush are only released once the callbacks of the calls
+// before it were invoked.
+// Failed calls stop the client and have their Err set instead of a response,
+// and no callbacks.
+func (cli *grpcClient) finishCall(pending *pendingCall, res *types.Response, err error) {
+	if err != nil {
+		err = grpcError(err)
+		cli.StopForError(err)
+		pending.reqres.Err = err
+	} else {
+		pending.reqres.Response = res // Set response
+	}
 
-		// Notify client listener if set
-		if cli.resCb != nil {
-			cli.resCb(reqres.Request, res)
+	cli.mtx.Lock()
+	pending.finished = true
+	for next := cli.reqSent.Front(); next != nil; next = cli.reqSent.Front() {
+		call := next.Value.(*pendingCall)
+		if !call.finished {
+			break
 		}
-	}()
-	return reqres
+		if !isFlush(call.reqres.Request) {
+			call.reqres.Done() // Release waiters
+		}
+		cli.released = append(cli.released, call.reqres)
+		cli.reqSent.Remove(next)
+	}
+	// only one go-routine invokes callbacks at a time, so the order is preserved
+	if cli.delivering {
+		cli.mtx.Unlock()
+		return
+	}
+	cli.delivering = true
+	cli.mtx.Unlock()
+
+	cli.deliverReleased()
+}
+
+// deliverReleased invokes the callbacks of the released calls until there
+// are none left. Callbacks run without locks held, so they may make calls,
+// even Sync ones but FlushSync, whose callbacks are invoked once they return.
+func (cli *grpcClient) deliverReleased() {
+	for {
+		cli.mtx.Lock()
+		ready := cli.released
+		cli.released = nil
+		if len(ready) == 0 {
+			cli.delivering = false
+			cli.mtx.Unlock()
+			return
+		}
+		resCb := cli.resCb
+		cli.mtx.Unlock()
+
+		for _, reqres := range ready {
+			if isFlush(reqres.Request) {
+				reqres.Done() // Release waiters
+			}
+			cb := reqres.setDoneAndGetCallback()
+			if reqres.Response == nil {
+				continue
+			}
+
+			// Notify reqRes listener if set
+			if cb != nil {
+				cb(reqres.Response)
+			}
+
+			// Notify client listener if set
+			if resCb != nil {
+				resCb(reqres.Request, reqres.Response)
+			}
+		}
+	}
+}
+
+func isFlush(req *types.Request) bool {
+	_, ok := req.Value.(*types.Request_Flush)
+	return ok
+}
+
+// Consensus calls must be executed in order.
+func isOrderedRequest(req *types.Request) bool {
+	switch req.Value.(type) {
+	case *types.Request_InitChain, *types.Request_BeginBlock, *types.Request_DeliverTx,
+		*types.Request_DeliverTxBatch, *types.Request_EndBlock, *types.Request_Commit,
+		*types.Request_PrepareProposal, *types.Request_ProcessProposal:
+		return true
+	}
+	return false
+}
+
+// grpcError converts the error of a call to a client error type.
+// Errors returned by the app's handlers, or panics caught by the
+// recovery interceptor, are app exceptions.
+func grpcError(err error) error {
+	switch err {
+	case context.Canceled:
+		return ErrConnectionLost{errClientStopped}
+	case context.DeadlineExceeded:
+		return ErrTimeout{err}
+	}
+	switch err.(type) {
+	case ErrConnectionLost, ErrTimeout:
+		return err
+	}
+	if grpc.ErrorDesc(err) == grpc.ErrClientConnClosing.Error() {
+		return ErrConnectionLost{err}
+	}
+	switch grpc.Code(err) {
+	case codes.Canceled, codes.Unavailable:
+		return ErrConnectionLost{err}
+	case codes.DeadlineExceeded:
+		return ErrTimeout{err}
+	case codes.Unknown, codes.Internal:
+		return ErrAppException{grpc.ErrorDesc(err)}
+	}
+	return err
+}
+
+func closeIfNotNil(ch chan struct{}) {
+	if ch != nil {
+		close(ch)
+	}
 }
 
 //----------------------------------------
 
+// FlushSync waits for all previous calls to complete.
 func (cli *grpcClient) FlushSync() error {
+	return cli.wait(cli.FlushAsync())
+}
+
+// wait waits for the call to complete.
+// The response is never nil if there is no error.
+func (cli *grpcClient) wait(reqres *ReqRes) error {
+	reqres.Wait()
+	if reqres.Response == nil {
+		if reqres.Err != nil {
+			return reqres.Err
+		}
+		if err := cli.Error(); err != nil {
+			return err
+		}
+		return ErrConnectionLost{errClientStopped}
+	}
 	return nil
 }
 
 func (cli *grpcClient) EchoSync(msg string) (*types.ResponseEcho, error) {
 	reqres := cli.EchoAsync(msg)
 	// StopForError should already have been called if error is set
-	return reqres.Response.GetEcho(), cli.Error()
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetEcho(), nil
 }
 
 func (cli *grpcClient) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
 	reqres := cli.InfoAsync(req)
-	return reqres.Response.GetInfo(), cli.Error()
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetInfo(), nil
 }
 
 func (cli *grpcClient) SetOptionSync(req types.RequestSetOption) (*types.ResponseSetOption, error) {
 	reqres := cli.SetOptionAsync(req)
-	return reqres.Response.GetSetOption(), cli.Error()
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetSetOption(), nil
 }
 
 func (cli *grpcClient) DeliverTxSync(tx []byte) (*types.ResponseDeliverTx, error) {
 	reqres := cli.DeliverTxAsync(tx)
-	return reqres.Response.GetDeliverTx(), cli.Error()
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetDeliverTx(), nil
 }
 
-func (cli *grpcClient) CheckTxSync(tx []byte) (*types.ResponseCheckTx, error) {
-	reqres := cli.CheckTxAsync(tx)
-	return reqres.Response.GetCheckTx(), cli.Error()
+func (cli *grpcClient) CheckTxSync(params types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	reqres := cli.CheckTxAsync(params)
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCheckTx(), nil
 }
 
 func (cli *grpcClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
 	reqres := cli.QueryAsync(req)
-	return reqres.Response.GetQuery(), cli.Error()
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetQuery(), nil
 }
 
 func (cli *grpcClient) CommitSync() (*types.ResponseCommit, error) {
 	reqres := cli.CommitAsync()
-	return reqres.Response.GetCommit(), cli.Error()
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCommit(), nil
 }
 
 func (cli *grpcClient) InitChainSync(params types.RequestInitChain) (*types.ResponseInitChain, error) {
 	reqres := cli.InitChainAsync(params)
-	return reqres.Response.GetInitChain(), cli.Error()
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetInitChain(), nil
 }
 
 func (cli *grpcClient) BeginBlockSync(params types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
 	reqres := cli.BeginBlockAsync(params)
-	return reqres.Response.GetBeginBlock(), cli.Error()
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetBeginBlock(), nil
 }
 
 func (cli *grpcClient) EndBlockSync(params types.RequestEndBlock) (*types.ResponseEndBlock, error) {
 	reqres := cli.EndBlockAsync(params)
-	return reqres.Response.GetEndBlock(), cli.Error()
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetEndBlock(), nil
+}
+
+func (cli *grpcClient) DeliverTxBatchSync(req types.RequestDeliverTxBatch) (*types.ResponseDeliverTxBatch, error) {
+	reqres := cli.DeliverTxBatchAsync(req)
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetDeliverTxBatch(), nil
+}
+
+func (cli *grpcClient) CheckTxBatchSync(params types.RequestCheckTxBatch) (*types.ResponseCheckTxBatch, error) {
+	reqres := cli.CheckTxBatchAsync(params)
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCheckTxBatch(), nil
+}
+
+func (cli *grpcClient) ListSnapshotsSync(params types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	reqres := cli.ListSnapshotsAsync(params)
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetListSnapshots(), nil
+}
+
+func (cli *grpcClient) OfferSnapshotSync(params types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	reqres := cli.OfferSnapshotAsync(params)
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetOfferSnapshot(), nil
+}
+
+func (cli *grpcClient) LoadSnapshotChunkSync(params types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	reqres := cli.LoadSnapshotChunkAsync(params)
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetLoadSnapshotChunk(), nil
+}
+
+func (cli *grpcClient) ApplySnapshotChunkSync(params types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	reqres := cli.ApplySnapshotChunkAsync(params)
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetApplySnapshotChunk(), nil
+}
+
+func (cli *grpcClient) PrepareProposalSync(params types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	reqres := cli.PrepareProposalAsync(params)
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetPrepareProposal(), nil
+}
+
+func (cli *grpcClient) ProcessProposalSync(params types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	reqres := cli.ProcessProposalAsync(params)
+	if err := cli.wait(reqres); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetProcessProposal(), nil
 }
@@ -0,0 +1,160 @@
+package abcicli_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	context "golang.org/x/net/context"
+
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/server"
+	"github.com/tendermint/abci/types"
+)
+
+func TestGRPCClientStopAndRestart(t *testing.T) {
+	socket := "unix://test-grpc-restart.sock"
+	srv := server.NewGRPCServer(socket, types.NewGRPCApplication(types.NewBaseApplication()))
+	srv.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	c := abcicli.NewGRPCClient(socket, true)
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c.Start())
+
+	res, err := c.EchoSync("hello")
+	require.NoError(t, err)
+	require.Equal(t, "hello", res.Message)
+
+	// calls after stop fail instead of hanging or returning empty responses
+	require.NoError(t, c.Stop())
+	_, err = c.EchoSync("hello")
+	require.IsType(t, abcicli.ErrConnectionLost{}, err)
+
+	// the client can be restarted on a new connection
+	require.NoError(t, c.Reset())
+	require.NoError(t, c.Error())
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	res, err = c.EchoSync("again")
+	require.NoError(t, err)
+	require.Equal(t, "again", res.Message)
+}
+
+// slowCheckTxApp makes earlier txs slower, so concurrent calls finish out of order.
+type slowCheckTxApp struct {
+	types.BaseApplication
+}
+
+func (slowCheckTxApp) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	tx := req.Tx
+	var i int
+	fmt.Sscanf(string(tx), "%d", &i)
+	time.Sleep(time.Duration(10-i%10) * time.Millisecond)
+	return types.ResponseCheckTx{Code: types.CodeTypeOK, Data: tx}
+}
+
+func TestGRPCClientAsyncOrder(t *testing.T) {
+	socket := "unix://test-grpc-async.sock"
+	srv := server.NewGRPCServer(socket, types.NewGRPCApplication(slowCheckTxApp{}))
+	srv.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	c := abcicli.NewGRPCClient(socket, true, abcicli.GRPCMaxConcurrentCalls(4))
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	var mtx sync.Mutex
+	var got []string
+	c.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if r := res.GetCheckTx(); r != nil {
+			mtx.Lock()
+			got = append(got, string(r.Data))
+			mtx.Unlock()
+		}
+	})
+
+	const numTxs = 40
+	var want []string
+	start := time.Now()
+	for i := 0; i < numTxs; i++ {
+		tx := fmt.Sprintf("%d", i)
+		want = append(want, tx)
+		c.CheckTxAsync(types.RequestCheckTx{Tx: []byte(tx)})
+	}
+	// calls don't wait for the responses
+	assert.True(t, time.Since(start) < 100*time.Millisecond, "CheckTxAsync blocked")
+
+	require.NoError(t, c.FlushSync())
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, want, got, "responses not delivered in submission order")
+}
+
+func TestGRPCClientSyncCallFromCallback(t *testing.T) {
+	socket := "unix://test-grpc-callback.sock"
+	srv := server.NewGRPCServer(socket, types.NewGRPCApplication(types.NewBaseApplication()))
+	srv.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	c := abcicli.NewGRPCClient(socket, true)
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c.Start())
+
+	done := make(chan string, 1)
+	c.EchoAsync("first").SetCallback(func(res *types.Response) {
+		res2, err := c.EchoSync("second")
+		require.NoError(t, err)
+		done <- res2.Message
+	})
+	select {
+	case msg := <-done:
+		assert.Equal(t, "second", msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sync call from a callback deadlocked")
+	}
+
+	// a failed call records its error, and its callback is never called
+	require.NoError(t, c.Stop())
+	reqres := c.EchoAsync("stopped")
+	reqres.Wait()
+	assert.Nil(t, reqres.Response)
+	assert.IsType(t, abcicli.ErrConnectionLost{}, reqres.Err)
+	reqres.SetCallback(func(*types.Response) {
+		t.Error("callback called without a response")
+	})
+}
+
+// notReadyApp never answers Info, so the server never reports serving
+type notReadyApp struct {
+	types.ABCIApplicationServer
+}
+
+func (notReadyApp) Info(ctx context.Context, req *types.RequestInfo) (*types.ResponseInfo, error) {
+	return nil, errors.New("not ready")
+}
+
+func TestGRPCClientNotServingTimeout(t *testing.T) {
+	socket := "unix://test-grpc-not-serving.sock"
+	srv := server.NewGRPCServer(socket, notReadyApp{types.NewGRPCApplication(types.NewBaseApplication())})
+	srv.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	c := abcicli.NewGRPCClient(socket, true, abcicli.GRPCConnectTimeout(100*time.Millisecond))
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	err := c.Start()
+	require.IsType(t, abcicli.ErrTimeout{}, err)
+	assert.Contains(t, err.Error(), "server not serving")
+}
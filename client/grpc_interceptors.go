@@ -0,0 +1,67 @@
+package abcicli
+
+import (
+	"time"
+
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+
+	"github.com/tendermint/tmlibs/log"
+)
+
+// LoggingUnaryClientInterceptor logs every unary call, with its duration,
+// at debug level, or at error level if it fails.
+func LoggingUnaryClientInterceptor(logger log.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			logger.Error("gRPC call failed", "method", method, "took", time.Since(start), "err", err)
+		} else {
+			logger.Debug("gRPC call", "method", method, "took", time.Since(start))
+		}
+		return err
+	}
+}
+
+// LoggingStreamClientInterceptor logs the opening of every stream.
+func LoggingStreamClientInterceptor(logger log.Logger) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			logger.Error("gRPC stream failed", "method", method, "err", err)
+		} else {
+			logger.Debug("gRPC stream", "method", method)
+		}
+		return cs, err
+	}
+}
+
+//----------------------------------------
+// grpc takes a single interceptor of each kind, so we chain them ourselves.
+
+func chainUnaryClientInterceptors(interceptors []grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		chained := invoker
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, next, opts...)
+			}
+		}
+		return chained(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func chainStreamClientInterceptors(interceptors []grpc.StreamClientInterceptor) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		chained := streamer
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return interceptor(ctx, desc, cc, method, next, opts...)
+			}
+		}
+		return chained(ctx, desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,95 @@
+package abcicli
+
+import (
+	"time"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// GRPCClientOption sets an optional parameter on the grpcClient.
+type GRPCClientOption func(*grpcClient)
+
+// GRPCUnaryInterceptors adds unary interceptors to the client.
+// Interceptors are chained in the order they are given,
+// the first one being the outermost.
+func GRPCUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.unaryInterceptors = append(cli.unaryInterceptors, interceptors...)
+	}
+}
+
+// GRPCStreamInterceptors adds stream interceptors to the client.
+// Interceptors are chained in the order they are given,
+// the first one being the outermost.
+func GRPCStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.streamInterceptors = append(cli.streamInterceptors, interceptors...)
+	}
+}
+
+// GRPCKeepalive sets the keepalive parameters of the client.
+func GRPCKeepalive(params keepalive.ClientParameters) GRPCClientOption {
+	return GRPCDialOptions(grpc.WithKeepaliveParams(params))
+}
+
+// GRPCMaxMsgSize sets the maximum size in bytes of messages
+// the client can receive and send.
+func GRPCMaxMsgSize(recv, send int) GRPCClientOption {
+	return GRPCDialOptions(grpc.WithDefaultCallOptions(
+		grpc.MaxCallRecvMsgSize(recv),
+		grpc.MaxCallSendMsgSize(send),
+	))
+}
+
+// GRPCCreds sets the transport credentials of the client.
+// Without it, the client dials insecurely.
+func GRPCCreds(creds credentials.TransportCredentials) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.creds = creds
+	}
+}
+
+// GRPCDialOptions passes raw grpc.DialOptions through to grpc.Dial.
+// Use GRPCUnaryInterceptors and GRPCStreamInterceptors for interceptors,
+// since grpc only accepts one of each.
+func GRPCDialOptions(opts ...grpc.DialOption) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.dialOpts = append(cli.dialOpts, opts...)
+	}
+}
+
+// GRPCMaxPendingCalls sets how many XxxAsync calls may be outstanding
+// before further calls block. Defaults to 256.
+func GRPCMaxPendingCalls(n int) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.maxPendingCalls = n
+	}
+}
+
+// GRPCMaxConcurrentCalls sets how many calls may be in flight at once.
+// Consensus calls are always executed one at a time. Defaults to 16.
+func GRPCMaxConcurrentCalls(n int) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.maxConcurrentCalls = n
+	}
+}
+
+// GRPCConnectTimeout makes Start fail with ErrTimeout if the server isn't
+// healthy within the timeout. By default, Start waits until it is,
+// or the client is stopped.
+func GRPCConnectTimeout(timeout time.Duration) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.connectTimeout = timeout
+	}
+}
+
+// GRPCCheckVersion makes the client check on start that the server speaks
+// a compatible ABCI version, see CheckVersion. Start fails and the client
+// is stopped if it doesn't.
+func GRPCCheckVersion() GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.checkVersion = true
+	}
+}
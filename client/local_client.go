@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	types "github.com/tendermint/abci/types"
+	"github.com/tendermint/abci/version"
 	cmn "github.com/tendermint/tmlibs/common"
 )
 
@@ -55,6 +56,7 @@ func (app *localClient) InfoAsync(req types.RequestInfo) *ReqRes {
 	app.mtx.Lock()
 	res := app.Application.Info(req)
 	app.mtx.Unlock()
+	res.AbciVersion = version.ABCIVersion
 	return app.callback(
 		types.ToRequestInfo(req),
 		types.ToResponseInfo(res),
@@ -81,12 +83,12 @@ func (app *localClient) DeliverTxAsync(tx []byte) *ReqRes {
 	)
 }
 
-func (app *localClient) CheckTxAsync(tx []byte) *ReqRes {
+func (app *localClient) CheckTxAsync(req types.RequestCheckTx) *ReqRes {
 	app.mtx.Lock()
-	res := app.Application.CheckTx(tx)
+	res := app.Application.CheckTx(req)
 	app.mtx.Unlock()
 	return app.callback(
-		types.ToRequestCheckTx(tx),
+		types.ToRequestCheckTx(req),
 		types.ToResponseCheckTx(res),
 	)
 }
@@ -142,6 +144,92 @@ func (app *localClient) EndBlockAsync(req types.RequestEndBlock) *ReqRes {
 	)
 }
 
+func (app *localClient) DeliverTxBatchAsync(req types.RequestDeliverTxBatch) *ReqRes {
+	app.mtx.Lock()
+	res, err := types.DeliverTxBatch(app.Application, req)
+	app.mtx.Unlock()
+	if err != nil {
+		return app.callback(types.ToRequestDeliverTxBatch(req), types.ToResponseException(err.Error()))
+	}
+	return app.callback(
+		types.ToRequestDeliverTxBatch(req),
+		types.ToResponseDeliverTxBatch(res),
+	)
+}
+
+func (app *localClient) CheckTxBatchAsync(req types.RequestCheckTxBatch) *ReqRes {
+	app.mtx.Lock()
+	res, err := types.CheckTxBatch(app.Application, req)
+	app.mtx.Unlock()
+	if err != nil {
+		return app.callback(types.ToRequestCheckTxBatch(req), types.ToResponseException(err.Error()))
+	}
+	return app.callback(
+		types.ToRequestCheckTxBatch(req),
+		types.ToResponseCheckTxBatch(res),
+	)
+}
+
+func (app *localClient) ListSnapshotsAsync(req types.RequestListSnapshots) *ReqRes {
+	app.mtx.Lock()
+	res := app.Application.ListSnapshots(req)
+	app.mtx.Unlock()
+	return app.callback(
+		types.ToRequestListSnapshots(req),
+		types.ToResponseListSnapshots(res),
+	)
+}
+
+func (app *localClient) OfferSnapshotAsync(req types.RequestOfferSnapshot) *ReqRes {
+	app.mtx.Lock()
+	res := app.Application.OfferSnapshot(req)
+	app.mtx.Unlock()
+	return app.callback(
+		types.ToRequestOfferSnapshot(req),
+		types.ToResponseOfferSnapshot(res),
+	)
+}
+
+func (app *localClient) LoadSnapshotChunkAsync(req types.RequestLoadSnapshotChunk) *ReqRes {
+	app.mtx.Lock()
+	res := app.Application.LoadSnapshotChunk(req)
+	app.mtx.Unlock()
+	return app.callback(
+		types.ToRequestLoadSnapshotChunk(req),
+		types.ToResponseLoadSnapshotChunk(res),
+	)
+}
+
+func (app *localClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotChunk) *ReqRes {
+	app.mtx.Lock()
+	res := app.Application.ApplySnapshotChunk(req)
+	app.mtx.Unlock()
+	return app.callback(
+		types.ToRequestApplySnapshotChunk(req),
+		types.ToResponseApplySnapshotChunk(res),
+	)
+}
+
+func (app *localClient) PrepareProposalAsync(req types.RequestPrepareProposal) *ReqRes {
+	app.mtx.Lock()
+	res := app.Application.PrepareProposal(req)
+	app.mtx.Unlock()
+	return app.callback(
+		types.ToRequestPrepareProposal(req),
+		types.ToResponsePrepareProposal(res),
+	)
+}
+
+func (app *localClient) ProcessProposalAsync(req types.RequestProcessProposal) *ReqRes {
+	app.mtx.Lock()
+	res := app.Application.ProcessProposal(req)
+	app.mtx.Unlock()
+	return app.callback(
+		types.ToRequestProcessProposal(req),
+		types.ToResponseProcessProposal(res),
+	)
+}
+
 //-------------------------------------------------------
 
 func (app *localClient) FlushSync() error {
@@ -156,6 +244,7 @@ func (app *localClient) InfoSync(req types.RequestInfo) (*types.ResponseInfo, er
 	app.mtx.Lock()
 	res := app.Application.Info(req)
 	app.mtx.Unlock()
+	res.AbciVersion = version.ABCIVersion
 	return &res, nil
 }
 
@@ -173,9 +262,9 @@ func (app *localClient) DeliverTxSync(tx []byte) (*types.ResponseDeliverTx, erro
 	return &res, nil
 }
 
-func (app *localClient) CheckTxSync(tx []byte) (*types.ResponseCheckTx, error) {
+func (app *localClient) CheckTxSync(req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
 	app.mtx.Lock()
-	res := app.Application.CheckTx(tx)
+	res := app.Application.CheckTx(req)
 	app.mtx.Unlock()
 	return &res, nil
 }
@@ -215,6 +304,68 @@ func (app *localClient) EndBlockSync(req types.RequestEndBlock) (*types.Response
 	return &res, nil
 }
 
+func (app *localClient) DeliverTxBatchSync(req types.RequestDeliverTxBatch) (*types.ResponseDeliverTxBatch, error) {
+	app.mtx.Lock()
+	res, err := types.DeliverTxBatch(app.Application, req)
+	app.mtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (app *localClient) CheckTxBatchSync(req types.RequestCheckTxBatch) (*types.ResponseCheckTxBatch, error) {
+	app.mtx.Lock()
+	res, err := types.CheckTxBatch(app.Application, req)
+	app.mtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (app *localClient) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	app.mtx.Lock()
+	res := app.Application.ListSnapshots(req)
+	app.mtx.Unlock()
+	return &res, nil
+}
+
+func (app *localClient) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	app.mtx.Lock()
+	res := app.Application.OfferSnapshot(req)
+	app.mtx.Unlock()
+	return &res, nil
+}
+
+func (app *localClient) LoadSnapshotChunkSync(req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	app.mtx.Lock()
+	res := app.Application.LoadSnapshotChunk(req)
+	app.mtx.Unlock()
+	return &res, nil
+}
+
+func (app *localClient) ApplySnapshotChunkSync(req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	app.mtx.Lock()
+	res := app.Application.ApplySnapshotChunk(req)
+	app.mtx.Unlock()
+	return &res, nil
+}
+
+func (app *localClient) PrepareProposalSync(req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	app.mtx.Lock()
+	res := app.Application.PrepareProposal(req)
+	app.mtx.Unlock()
+	return &res, nil
+}
+
+func (app *localClient) ProcessProposalSync(req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	app.mtx.Lock()
+	res := app.Application.ProcessProposal(req)
+	app.mtx.Unlock()
+	return &res, nil
+}
+
 //-------------------------------------------------------
 
 func (app *localClient) callback(req *types.Request, res *types.Response) *ReqRes {
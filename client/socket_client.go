@@ -3,7 +3,6 @@ package abcicli
 import (
 	"bufio"
 	"container/list"
-	"errors"
 	"fmt"
 	"net"
 	"reflect"
@@ -14,9 +13,9 @@ import (
 	cmn "github.com/tendermint/tmlibs/common"
 )
 
-const reqQueueSize = 256 // TODO make configurable
+const reqQueueSize = 256 // default, see SocketQueueSize
 // const maxResponseSize = 1048576 // 1MB TODO make configurable
-const flushThrottleMS = 20 // Don't wait longer than...
+const flushThrottleMS = 20 // Don't wait longer than... (default, see SocketFlushInterval)
 
 var _ Client = (*socketClient)(nil)
 
@@ -26,30 +25,45 @@ var _ Client = (*socketClient)(nil)
 type socketClient struct {
 	cmn.BaseService
 
-	reqQueue    chan *ReqRes
-	flushTimer  *cmn.ThrottleTimer
-	mustConnect bool
-
-	mtx     sync.Mutex
-	addr    string
-	conn    net.Conn
-	err     error
-	reqSent *list.List
-	resCb   func(*types.Request, *types.Response) // listens to all callbacks
-
-}
-
-func NewSocketClient(addr string, mustConnect bool) *socketClient {
+	reqQueue       chan *ReqRes
+	queueSize      int
+	enqueueTimeout time.Duration
+	flushTimer     *cmn.ThrottleTimer
+	flushInterval  time.Duration
+	flushEvery     int  // flush after this many requests, if > 0
+	coalesce       bool // merge queued flushes into one
+	unflushed      int  // requests written since the last flush, only used by sendRequestsRoutine
+	mustConnect    bool
+	checkVersion   bool // check the ABCI version of the server on start
+
+	mtx       sync.Mutex
+	addr      string
+	conn      net.Conn
+	err       error
+	stopped   bool // requests queued once it is set are failed
+	reqSent   *list.List
+	coalesced map[*ReqRes][]*ReqRes                 // flushes answered by the response to a sent flush
+	resCb     func(*types.Request, *types.Response) // listens to all callbacks
+
+}
+
+func NewSocketClient(addr string, mustConnect bool, options ...SocketClientOption) *socketClient {
 	cli := &socketClient{
-		reqQueue:    make(chan *ReqRes, reqQueueSize),
-		flushTimer:  cmn.NewThrottleTimer("socketClient", flushThrottleMS),
-		mustConnect: mustConnect,
-
-		addr:    addr,
-		reqSent: list.New(),
-		resCb:   nil,
+		queueSize:     reqQueueSize,
+		flushInterval: flushThrottleMS * time.Millisecond,
+		mustConnect:   mustConnect,
+
+		addr:      addr,
+		reqSent:   list.New(),
+		coalesced: make(map[*ReqRes][]*ReqRes),
+		resCb:     nil,
 	}
 	cli.BaseService = *cmn.NewBaseService(nil, "socketClient", cli)
+	for _, option := range options {
+		option(cli)
+	}
+	cli.reqQueue = make(chan *ReqRes, cli.queueSize)
+	cli.flushTimer = cmn.NewThrottleTimer("socketClient", cli.flushInterval)
 	return cli
 }
 
@@ -75,21 +89,38 @@ RETRY_LOOP:
 
 		go cli.sendRequestsRoutine(conn)
 		go cli.recvResponseRoutine(conn)
-
 		return nil
 	}
 }
 
+// Start starts the client, then checks the ABCI version of the server
+// if required, stopping the client if it isn't compatible.
+// The check needs the client running, so it can't be done in OnStart.
+func (cli *socketClient) Start() error {
+	if err := cli.BaseService.Start(); err != nil {
+		return err
+	}
+	if cli.checkVersion {
+		if _, err := CheckVersion(cli); err != nil {
+			cli.StopForError(err)
+			return err
+		}
+	}
+	return nil
+}
+
 func (cli *socketClient) OnStop() {
 	cli.BaseService.OnStop()
 
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
+	cli.stopped = true
 	if cli.conn != nil {
 		cli.conn.Close()
 	}
 
 	cli.flushQueue()
+	cli.releaseSent()
 }
 
 // Stop the client and set the error
@@ -108,7 +139,19 @@ func (cli *socketClient) StopForError(err error) {
 	cli.Stop()
 }
 
+// Error returns the error the client was stopped for, if any,
+// or ErrQueueFull while the request queue is saturated.
 func (cli *socketClient) Error() error {
+	if err := cli.stopError(); err != nil {
+		return err
+	}
+	if len(cli.reqQueue) == cap(cli.reqQueue) {
+		return ErrQueueFull{Size: cap(cli.reqQueue)}
+	}
+	return nil
+}
+
+func (cli *socketClient) stopError() error {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
 	return cli.err
@@ -138,22 +181,73 @@ func (cli *socketClient) sendRequestsRoutine(conn net.Conn) {
 		case <-cli.Quit():
 			return
 		case reqres := <-cli.reqQueue:
-			cli.willSendReq(reqres)
-			err := types.WriteMessage(reqres.Request, w)
-			if err != nil {
-				cli.StopForError(fmt.Errorf("Error writing msg: %v", err))
+			if err := cli.sendRequest(w, reqres); err != nil {
+				cli.StopForError(err)
 				return
 			}
-			// cli.Logger.Debug("Sent request", "requestType", reflect.TypeOf(reqres.Request), "request", reqres.Request)
-			if _, ok := reqres.Request.Value.(*types.Request_Flush); ok {
-				err = w.Flush()
-				if err != nil {
-					cli.StopForError(fmt.Errorf("Error flushing writer: %v", err))
-					return
-				}
+		}
+	}
+}
+
+// sendRequest writes the request, and flushes the writer if it is a Flush.
+// It sends a Flush of its own once flushEvery requests were written.
+func (cli *socketClient) sendRequest(w *bufio.Writer, reqres *ReqRes) error {
+	if _, ok := reqres.Request.Value.(*types.Request_Flush); ok {
+		if cli.coalesce {
+			if err := cli.coalesceFlush(w, reqres); err != nil {
+				return err
 			}
 		}
+		if err := cli.writeRequest(w, reqres); err != nil {
+			return err
+		}
+		cli.unflushed = 0
+		if err := w.Flush(); err != nil {
+			return ErrConnectionLost{fmt.Errorf("Error flushing writer: %v", err)}
+		}
+		return nil
+	}
+
+	if err := cli.writeRequest(w, reqres); err != nil {
+		return err
+	}
+	cli.unflushed++
+	if cli.flushEvery > 0 && cli.unflushed >= cli.flushEvery {
+		return cli.sendRequest(w, NewReqRes(types.ToRequestFlush()))
+	}
+	return nil
+}
+
+// coalesceFlush writes the requests already queued behind the flush,
+// so it covers them too, and answers the other queued flushes with it.
+func (cli *socketClient) coalesceFlush(w *bufio.Writer, flush *ReqRes) error {
+	var merged []*ReqRes
+	for n := len(cli.reqQueue); n > 0; n-- {
+		reqres := <-cli.reqQueue
+		if _, ok := reqres.Request.Value.(*types.Request_Flush); ok {
+			merged = append(merged, reqres)
+			continue
+		}
+		if err := cli.writeRequest(w, reqres); err != nil {
+			return err
+		}
+		cli.unflushed++
+	}
+	if len(merged) > 0 {
+		cli.mtx.Lock()
+		cli.coalesced[flush] = merged
+		cli.mtx.Unlock()
 	}
+	return nil
+}
+
+func (cli *socketClient) writeRequest(w *bufio.Writer, reqres *ReqRes) error {
+	cli.willSendReq(reqres)
+	if err := types.WriteMessage(reqres.Request, w); err != nil {
+		return ErrConnectionLost{fmt.Errorf("Error writing msg: %v", err)}
+	}
+	// cli.Logger.Debug("Sent request", "requestType", reflect.TypeOf(reqres.Request), "request", reqres.Request)
+	return nil
 }
 
 func (cli *socketClient) recvResponseRoutine(conn net.Conn) {
@@ -163,13 +257,13 @@ func (cli *socketClient) recvResponseRoutine(conn net.Conn) {
 		var res = &types.Response{}
 		err := types.ReadMessage(r, res)
 		if err != nil {
-			cli.StopForError(err)
+			cli.StopForError(ErrConnectionLost{err})
 			return
 		}
 		switch r := res.Value.(type) {
 		case *types.Response_Exception:
 			// XXX After setting cli.err, release waiters (e.g. reqres.Done())
-			cli.StopForError(errors.New(r.Exception.Error))
+			cli.StopForError(ErrAppException{r.Exception.Error})
 			return
 		default:
 			// cli.Logger.Debug("Received response", "responseType", reflect.TypeOf(res), "response", res)
@@ -195,12 +289,13 @@ func (cli *socketClient) didRecvResponse(res *types.Response) error {
 	// Get the first ReqRes
 	next := cli.reqSent.Front()
 	if next == nil {
-		return fmt.Errorf("Unexpected result type %v when nothing expected", reflect.TypeOf(res.Value))
+		return ErrProtocolViolation{fmt.Sprintf("Unexpected result type %v when nothing expected",
+			reflect.TypeOf(res.Value))}
 	}
 	reqres := next.Value.(*ReqRes)
 	if !resMatchesReq(reqres.Request, res) {
-		return fmt.Errorf("Unexpected result type %v when response to %v expected",
-			reflect.TypeOf(res.Value), reflect.TypeOf(reqres.Request.Value))
+		return ErrProtocolViolation{fmt.Sprintf("Unexpected result type %v when response to %v expected",
+			reflect.TypeOf(res.Value), reflect.TypeOf(reqres.Request.Value))}
 	}
 
 	reqres.Response = res    // Set response
@@ -217,6 +312,21 @@ func (cli *socketClient) didRecvResponse(res *types.Response) error {
 		cli.resCb(reqres.Request, res)
 	}
 
+	// Answer the flushes that were merged into this one
+	if merged, ok := cli.coalesced[reqres]; ok {
+		delete(cli.coalesced, reqres)
+		for _, flush := range merged {
+			flush.Response = res
+			flush.Done()
+			if cb := flush.GetCallback(); cb != nil {
+				cb(res)
+			}
+			if cli.resCb != nil {
+				cli.resCb(flush.Request, res)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -242,8 +352,8 @@ func (cli *socketClient) DeliverTxAsync(tx []byte) *ReqRes {
 	return cli.queueRequest(types.ToRequestDeliverTx(tx))
 }
 
-func (cli *socketClient) CheckTxAsync(tx []byte) *ReqRes {
-	return cli.queueRequest(types.ToRequestCheckTx(tx))
+func (cli *socketClient) CheckTxAsync(req types.RequestCheckTx) *ReqRes {
+	return cli.queueRequest(types.ToRequestCheckTx(req))
 }
 
 func (cli *socketClient) QueryAsync(req types.RequestQuery) *ReqRes {
@@ -266,84 +376,325 @@ func (cli *socketClient) EndBlockAsync(req types.RequestEndBlock) *ReqRes {
 	return cli.queueRequest(types.ToRequestEndBlock(req))
 }
 
+func (cli *socketClient) DeliverTxBatchAsync(req types.RequestDeliverTxBatch) *ReqRes {
+	return cli.queueRequest(types.ToRequestDeliverTxBatch(req))
+}
+
+func (cli *socketClient) CheckTxBatchAsync(req types.RequestCheckTxBatch) *ReqRes {
+	return cli.queueRequest(types.ToRequestCheckTxBatch(req))
+}
+
+func (cli *socketClient) ListSnapshotsAsync(req types.RequestListSnapshots) *ReqRes {
+	return cli.queueRequest(types.ToRequestListSnapshots(req))
+}
+
+func (cli *socketClient) OfferSnapshotAsync(req types.RequestOfferSnapshot) *ReqRes {
+	return cli.queueRequest(types.ToRequestOfferSnapshot(req))
+}
+
+func (cli *socketClient) LoadSnapshotChunkAsync(req types.RequestLoadSnapshotChunk) *ReqRes {
+	return cli.queueRequest(types.ToRequestLoadSnapshotChunk(req))
+}
+
+func (cli *socketClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotChunk) *ReqRes {
+	return cli.queueRequest(types.ToRequestApplySnapshotChunk(req))
+}
+
+func (cli *socketClient) PrepareProposalAsync(req types.RequestPrepareProposal) *ReqRes {
+	return cli.queueRequest(types.ToRequestPrepareProposal(req))
+}
+
+func (cli *socketClient) ProcessProposalAsync(req types.RequestProcessProposal) *ReqRes {
+	return cli.queueRequest(types.ToRequestProcessProposal(req))
+}
+
+//----------------------------------------
+// TryXxxAsync calls don't wait for room in the request queue:
+// they return ErrQueueFull right away if it is full.
+
+func (cli *socketClient) TryEchoAsync(msg string) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestEcho(msg), false)
+}
+
+func (cli *socketClient) TryFlushAsync() (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestFlush(), false)
+}
+
+func (cli *socketClient) TryInfoAsync(req types.RequestInfo) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestInfo(req), false)
+}
+
+func (cli *socketClient) TrySetOptionAsync(req types.RequestSetOption) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestSetOption(req), false)
+}
+
+func (cli *socketClient) TryDeliverTxAsync(tx []byte) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestDeliverTx(tx), false)
+}
+
+func (cli *socketClient) TryCheckTxAsync(req types.RequestCheckTx) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestCheckTx(req), false)
+}
+
+func (cli *socketClient) TryQueryAsync(req types.RequestQuery) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestQuery(req), false)
+}
+
+func (cli *socketClient) TryCommitAsync() (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestCommit(), false)
+}
+
+func (cli *socketClient) TryInitChainAsync(req types.RequestInitChain) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestInitChain(req), false)
+}
+
+func (cli *socketClient) TryBeginBlockAsync(req types.RequestBeginBlock) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestBeginBlock(req), false)
+}
+
+func (cli *socketClient) TryEndBlockAsync(req types.RequestEndBlock) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestEndBlock(req), false)
+}
+
+func (cli *socketClient) TryDeliverTxBatchAsync(req types.RequestDeliverTxBatch) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestDeliverTxBatch(req), false)
+}
+
+func (cli *socketClient) TryCheckTxBatchAsync(req types.RequestCheckTxBatch) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestCheckTxBatch(req), false)
+}
+
+func (cli *socketClient) TryListSnapshotsAsync(req types.RequestListSnapshots) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestListSnapshots(req), false)
+}
+
+func (cli *socketClient) TryOfferSnapshotAsync(req types.RequestOfferSnapshot) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestOfferSnapshot(req), false)
+}
+
+func (cli *socketClient) TryLoadSnapshotChunkAsync(req types.RequestLoadSnapshotChunk) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestLoadSnapshotChunk(req), false)
+}
+
+func (cli *socketClient) TryApplySnapshotChunkAsync(req types.RequestApplySnapshotChunk) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestApplySnapshotChunk(req), false)
+}
+
+func (cli *socketClient) TryPrepareProposalAsync(req types.RequestPrepareProposal) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestPrepareProposal(req), false)
+}
+
+func (cli *socketClient) TryProcessProposalAsync(req types.RequestProcessProposal) (*ReqRes, error) {
+	return cli.tryQueueRequest(types.ToRequestProcessProposal(req), false)
+}
+
 //----------------------------------------
 
 func (cli *socketClient) FlushSync() error {
-	reqRes := cli.queueRequest(types.ToRequestFlush())
-	if err := cli.Error(); err != nil {
+	reqRes, err := cli.tryQueueRequest(types.ToRequestFlush(), true)
+	if err != nil {
+		return err
+	}
+	if err := cli.stopError(); err != nil {
 		return err
 	}
 	reqRes.Wait() // NOTE: if we don't flush the queue, its possible to get stuck here
-	return cli.Error()
+	if err := cli.stopError(); err != nil {
+		return err
+	}
+	if reqRes.Response == nil {
+		return ErrConnectionLost{errClientStopped}
+	}
+	return nil
 }
 
 func (cli *socketClient) EchoSync(msg string) (*types.ResponseEcho, error) {
-	reqres := cli.queueRequest(types.ToRequestEcho(msg))
-	cli.FlushSync()
-	return reqres.Response.GetEcho(), cli.Error()
+	reqres, err := cli.queueRequestSync(types.ToRequestEcho(msg))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetEcho(), nil
 }
 
 func (cli *socketClient) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
-	reqres := cli.queueRequest(types.ToRequestInfo(req))
-	cli.FlushSync()
-	return reqres.Response.GetInfo(), cli.Error()
+	reqres, err := cli.queueRequestSync(types.ToRequestInfo(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetInfo(), nil
 }
 
 func (cli *socketClient) SetOptionSync(req types.RequestSetOption) (*types.ResponseSetOption, error) {
-	reqres := cli.queueRequest(types.ToRequestSetOption(req))
-	cli.FlushSync()
-	return reqres.Response.GetSetOption(), cli.Error()
+	reqres, err := cli.queueRequestSync(types.ToRequestSetOption(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetSetOption(), nil
 }
 
 func (cli *socketClient) DeliverTxSync(tx []byte) (*types.ResponseDeliverTx, error) {
-	reqres := cli.queueRequest(types.ToRequestDeliverTx(tx))
-	cli.FlushSync()
-	return reqres.Response.GetDeliverTx(), cli.Error()
+	reqres, err := cli.queueRequestSync(types.ToRequestDeliverTx(tx))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetDeliverTx(), nil
 }
 
-func (cli *socketClient) CheckTxSync(tx []byte) (*types.ResponseCheckTx, error) {
-	reqres := cli.queueRequest(types.ToRequestCheckTx(tx))
-	cli.FlushSync()
-	return reqres.Response.GetCheckTx(), cli.Error()
+func (cli *socketClient) CheckTxSync(req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	reqres, err := cli.queueRequestSync(types.ToRequestCheckTx(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCheckTx(), nil
 }
 
 func (cli *socketClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
-	reqres := cli.queueRequest(types.ToRequestQuery(req))
-	cli.FlushSync()
-	return reqres.Response.GetQuery(), cli.Error()
+	reqres, err := cli.queueRequestSync(types.ToRequestQuery(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetQuery(), nil
 }
 
 func (cli *socketClient) CommitSync() (*types.ResponseCommit, error) {
-	reqres := cli.queueRequest(types.ToRequestCommit())
-	cli.FlushSync()
-	return reqres.Response.GetCommit(), cli.Error()
+	reqres, err := cli.queueRequestSync(types.ToRequestCommit())
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCommit(), nil
 }
 
 func (cli *socketClient) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
-	reqres := cli.queueRequest(types.ToRequestInitChain(req))
-	cli.FlushSync()
-	return reqres.Response.GetInitChain(), cli.Error()
+	reqres, err := cli.queueRequestSync(types.ToRequestInitChain(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetInitChain(), nil
 }
 
 func (cli *socketClient) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
-	reqres := cli.queueRequest(types.ToRequestBeginBlock(req))
-	cli.FlushSync()
-	return reqres.Response.GetBeginBlock(), cli.Error()
+	reqres, err := cli.queueRequestSync(types.ToRequestBeginBlock(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetBeginBlock(), nil
 }
 
 func (cli *socketClient) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
-	reqres := cli.queueRequest(types.ToRequestEndBlock(req))
-	cli.FlushSync()
-	return reqres.Response.GetEndBlock(), cli.Error()
+	reqres, err := cli.queueRequestSync(types.ToRequestEndBlock(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetEndBlock(), nil
+}
+
+func (cli *socketClient) DeliverTxBatchSync(req types.RequestDeliverTxBatch) (*types.ResponseDeliverTxBatch, error) {
+	reqres, err := cli.queueRequestSync(types.ToRequestDeliverTxBatch(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetDeliverTxBatch(), nil
+}
+
+func (cli *socketClient) CheckTxBatchSync(req types.RequestCheckTxBatch) (*types.ResponseCheckTxBatch, error) {
+	reqres, err := cli.queueRequestSync(types.ToRequestCheckTxBatch(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCheckTxBatch(), nil
+}
+
+func (cli *socketClient) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	reqres, err := cli.queueRequestSync(types.ToRequestListSnapshots(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetListSnapshots(), nil
+}
+
+func (cli *socketClient) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	reqres, err := cli.queueRequestSync(types.ToRequestOfferSnapshot(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetOfferSnapshot(), nil
+}
+
+func (cli *socketClient) LoadSnapshotChunkSync(req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	reqres, err := cli.queueRequestSync(types.ToRequestLoadSnapshotChunk(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetLoadSnapshotChunk(), nil
+}
+
+func (cli *socketClient) ApplySnapshotChunkSync(req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	reqres, err := cli.queueRequestSync(types.ToRequestApplySnapshotChunk(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetApplySnapshotChunk(), nil
+}
+
+func (cli *socketClient) PrepareProposalSync(req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	reqres, err := cli.queueRequestSync(types.ToRequestPrepareProposal(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetPrepareProposal(), nil
+}
+
+func (cli *socketClient) ProcessProposalSync(req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	reqres, err := cli.queueRequestSync(types.ToRequestProcessProposal(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetProcessProposal(), nil
 }
 
 //----------------------------------------
 
+// queueRequest queues the request, waiting for room in the queue
+// up to the enqueue timeout. If it times out, or the client stops,
+// the returned ReqRes failed with its Err set.
 func (cli *socketClient) queueRequest(req *types.Request) *ReqRes {
+	reqres, err := cli.tryQueueRequest(req, true)
+	if err != nil {
+		cli.Logger.Error("Dropping request", "err", err)
+	}
+	return reqres
+}
+
+// queueRequestSync queues the request and flushes,
+// returning once the response is received.
+// The response is never nil if there is no error.
+func (cli *socketClient) queueRequestSync(req *types.Request) (*ReqRes, error) {
+	reqres, err := cli.tryQueueRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+	reqres.Wait()
+	if reqres.Response == nil {
+		if reqres.Err != nil {
+			return nil, reqres.Err
+		}
+		if err := cli.stopError(); err != nil {
+			return nil, err
+		}
+		return nil, ErrConnectionLost{errClientStopped}
+	}
+	return reqres, nil
+}
+
+func (cli *socketClient) tryQueueRequest(req *types.Request, wait bool) (*ReqRes, error) {
 	reqres := NewReqRes(req)
 
-	// TODO: set cli.err if reqQueue times out
-	cli.reqQueue <- reqres
+	if err := cli.enqueue(reqres, wait); err != nil {
+		return reqres, err
+	}
 
 	// Maybe auto-flush, or unset auto-flush
 	switch req.Value.(type) {
@@ -353,25 +704,110 @@ func (cli *socketClient) queueRequest(req *types.Request) *ReqRes {
 		cli.flushTimer.Set()
 	}
 
-	return reqres
+	return reqres, nil
 }
 
+// enqueue returns ErrQueueFull if the queue is full and either wait is false
+// or the enqueue timeout expires, and ErrConnectionLost if the client is
+// stopped, or stops while it waits. The request fails with the error.
+func (cli *socketClient) enqueue(reqres *ReqRes, wait bool) error {
+	cli.mtx.Lock()
+	if cli.stopped {
+		err := cli.lostError()
+		cli.mtx.Unlock()
+		reqres.fail(err)
+		return err
+	}
+	cli.mtx.Unlock()
+
+	select {
+	case cli.reqQueue <- reqres:
+		return cli.checkStopped()
+	default:
+	}
+	if !wait {
+		err := ErrQueueFull{Size: cap(cli.reqQueue)}
+		reqres.fail(err)
+		return err
+	}
+
+	var timeout <-chan time.Time
+	if cli.enqueueTimeout > 0 {
+		timer := time.NewTimer(cli.enqueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case cli.reqQueue <- reqres:
+		return cli.checkStopped()
+	case <-timeout:
+		err := ErrQueueFull{Size: cap(cli.reqQueue)}
+		reqres.fail(err)
+		return err
+	case <-cli.Quit():
+		cli.mtx.Lock()
+		err := cli.lostError()
+		cli.mtx.Unlock()
+		reqres.fail(err)
+		return err
+	}
+}
+
+// checkStopped fails the queued requests if the client was stopped
+// while a request was queued, as no routine sends them anymore.
+func (cli *socketClient) checkStopped() error {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if !cli.stopped {
+		return nil
+	}
+	cli.flushQueue()
+	return cli.lostError()
+}
+
+// releaseSent fails the requests that were sent but not answered,
+// releasing their waiters. The caller must hold cli.mtx.
+func (cli *socketClient) releaseSent() {
+	err := cli.lostError()
+	for next := cli.reqSent.Front(); next != nil; next = cli.reqSent.Front() {
+		reqres := next.Value.(*ReqRes)
+		reqres.fail(err)
+		for _, flush := range cli.coalesced[reqres] {
+			flush.fail(err)
+		}
+		delete(cli.coalesced, reqres)
+		cli.reqSent.Remove(next)
+	}
+}
+
+// flushQueue fails the requests that were queued but not sent.
+// The caller must hold cli.mtx.
 func (cli *socketClient) flushQueue() {
+	err := cli.lostError()
 LOOP:
 	for {
 		select {
 		case reqres := <-cli.reqQueue:
-			reqres.Done()
+			reqres.fail(err)
 		default:
 			break LOOP
 		}
 	}
 }
 
+// lostError is the error of the requests pending when the client stops.
+// The caller must hold cli.mtx.
+func (cli *socketClient) lostError() error {
+	if cli.err != nil {
+		return cli.err
+	}
+	return ErrConnectionLost{errClientStopped}
+}
+
 //----------------------------------------
 
 func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
-	switch req.Value.(type) {
+	switch r := req.Value.(type) {
 	case *types.Request_Echo:
 		_, ok = res.Value.(*types.Response_Echo)
 	case *types.Request_Flush:
@@ -394,6 +830,27 @@ func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
 		_, ok = res.Value.(*types.Response_BeginBlock)
 	case *types.Request_EndBlock:
 		_, ok = res.Value.(*types.Response_EndBlock)
+	case *types.Request_DeliverTxBatch:
+		// one response per tx
+		var batch *types.Response_DeliverTxBatch
+		batch, ok = res.Value.(*types.Response_DeliverTxBatch)
+		ok = ok && len(batch.DeliverTxBatch.Responses) == len(r.DeliverTxBatch.Txs)
+	case *types.Request_CheckTxBatch:
+		var batch *types.Response_CheckTxBatch
+		batch, ok = res.Value.(*types.Response_CheckTxBatch)
+		ok = ok && len(batch.CheckTxBatch.Responses) == len(r.CheckTxBatch.Txs)
+	case *types.Request_ListSnapshots:
+		_, ok = res.Value.(*types.Response_ListSnapshots)
+	case *types.Request_OfferSnapshot:
+		_, ok = res.Value.(*types.Response_OfferSnapshot)
+	case *types.Request_LoadSnapshotChunk:
+		_, ok = res.Value.(*types.Response_LoadSnapshotChunk)
+	case *types.Request_ApplySnapshotChunk:
+		_, ok = res.Value.(*types.Response_ApplySnapshotChunk)
+	case *types.Request_PrepareProposal:
+		_, ok = res.Value.(*types.Response_PrepareProposal)
+	case *types.Request_ProcessProposal:
+		_, ok = res.Value.(*types.Response_ProcessProposal)
 	}
 	return ok
 }
@@ -1,11 +1,23 @@
 package abcicli_test
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
+	"net"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmn "github.com/tendermint/tmlibs/common"
+	"github.com/tendermint/tmlibs/log"
+
 	"github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/server"
+	"github.com/tendermint/abci/types"
 )
 
 func TestSocketClientStopForErrorDeadlock(t *testing.T) {
@@ -26,3 +38,195 @@ func TestSocketClientStopForErrorDeadlock(t *testing.T) {
 		t.Fatalf("Test took too long, potential deadlock still exists")
 	}
 }
+
+func TestSocketClientQueueFull(t *testing.T) {
+	// not started, so nothing drains the queue
+	c := abcicli.NewSocketClient(":80", false,
+		abcicli.SocketQueueSize(1), abcicli.SocketEnqueueTimeout(10*time.Millisecond))
+
+	_, err := c.TryEchoAsync("one")
+	require.NoError(t, err)
+	require.Equal(t, abcicli.ErrQueueFull{Size: 1}, c.Error(), "saturation should be reported")
+
+	reqres, err := c.TryEchoAsync("two")
+	require.IsType(t, abcicli.ErrQueueFull{}, err)
+	reqres.Wait()
+	require.Nil(t, reqres.Response)
+	require.Equal(t, err, reqres.Err)
+
+	// Async calls that time out fail the same way, without calling back
+	reqres = c.EchoAsync("three")
+	reqres.Wait()
+	require.IsType(t, abcicli.ErrQueueFull{}, reqres.Err)
+	reqres.SetCallback(func(*types.Response) {
+		t.Error("callback called without a response")
+	})
+
+	_, err = c.EchoSync("four")
+	require.IsType(t, abcicli.ErrQueueFull{}, err)
+}
+
+func TestSocketClientEnqueueOnStop(t *testing.T) {
+	// not started, and no enqueue timeout
+	c := abcicli.NewSocketClient(":80", false, abcicli.SocketQueueSize(1))
+	_, err := c.TryEchoAsync("one")
+	require.NoError(t, err)
+
+	done := make(chan *abcicli.ReqRes)
+	go func() {
+		done <- c.EchoAsync("two")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, c.Stop())
+
+	select {
+	case reqres := <-done:
+		reqres.Wait()
+		assert.IsType(t, abcicli.ErrConnectionLost{}, reqres.Err)
+	case <-time.After(time.Second):
+		t.Fatal("EchoAsync blocked on a stopped client")
+	}
+}
+
+func TestSocketClientSyncAfterStop(t *testing.T) {
+	socket := "unix://test-socket-stop.sock"
+	srv, err := server.NewServer(socket, "socket", types.NewBaseApplication())
+	require.NoError(t, err)
+	srv.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	c := abcicli.NewSocketClient(socket, true)
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c.Start())
+	require.NoError(t, c.Stop())
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := c.EchoSync("hello")
+		done <- err
+		done <- c.FlushSync()
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			assert.IsType(t, abcicli.ErrConnectionLost{}, err)
+		case <-time.After(time.Second):
+			t.Fatal("sync call blocked on a stopped client")
+		}
+	}
+}
+
+// shortBatchApp drops the last response of a batch
+type shortBatchApp struct {
+	types.BaseApplication
+}
+
+func (shortBatchApp) DeliverTxBatch(req types.RequestDeliverTxBatch) []*types.ResponseDeliverTx {
+	return make([]*types.ResponseDeliverTx, len(req.Txs)-1)
+}
+
+func (shortBatchApp) CheckTxBatch(req types.RequestCheckTxBatch) []*types.ResponseCheckTx {
+	return make([]*types.ResponseCheckTx, len(req.Txs)-1)
+}
+
+func TestSocketClientBrokenBatch(t *testing.T) {
+	socket := "unix://test-socket-batch.sock"
+	srv, err := server.NewServer(socket, "socket", shortBatchApp{})
+	require.NoError(t, err)
+	srv.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	c := abcicli.NewSocketClient(socket, true)
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c.Start())
+	defer c.Stop()
+	_, err = c.DeliverTxBatchSync(types.RequestDeliverTxBatch{Txs: [][]byte{[]byte("a"), []byte("b")}})
+	assert.IsType(t, abcicli.ErrAppException{}, err)
+
+	// the server still serves the other connections
+	c2 := abcicli.NewSocketClient(socket, true)
+	c2.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c2.Start())
+	defer c2.Stop()
+	_, err = c2.EchoSync("hello")
+	assert.NoError(t, err)
+}
+
+func TestSocketClientCoalescedFlushes(t *testing.T) {
+	socket := "unix://test-socket-coalesce.sock"
+	srv, err := server.NewServer(socket, "socket", types.NewBaseApplication())
+	require.NoError(t, err)
+	srv.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	c := abcicli.NewSocketClient(socket, true, abcicli.SocketCoalesceFlushes(), abcicli.SocketFlushEvery(3))
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				msg := fmt.Sprintf("%d-%d", i, j)
+				res, err := c.EchoSync(msg)
+				if assert.NoError(t, err) {
+					assert.Equal(t, msg, res.Message)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSocketClientTypedErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		res     *types.Response
+		errType error
+	}{
+		{"exception", types.ToResponseException("boom"), abcicli.ErrAppException{}},
+		{"mismatch", types.ToResponseCommit(types.ResponseCommit{}), abcicli.ErrProtocolViolation{}},
+		{"hangup", nil, abcicli.ErrConnectionLost{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			socket := fmt.Sprintf("unix://test-socket-%s.sock", tc.name)
+			ln, err := net.Listen(cmn.ProtocolAndAddress(socket))
+			require.NoError(t, err)
+			defer ln.Close()
+
+			// answer the first request with tc.res, or hang up if nil
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+				req := &types.Request{}
+				if err := types.ReadMessage(r, req); err != nil || tc.res == nil {
+					return
+				}
+				types.WriteMessage(tc.res, w)
+				w.Flush()
+				types.ReadMessage(r, req) // wait for the client to close
+			}()
+
+			c := abcicli.NewSocketClient(socket, true)
+			c.SetLogger(log.TestingLogger())
+			require.NoError(t, c.Start())
+			defer c.Stop()
+
+			res, err := c.EchoSync("hello")
+			assert.Nil(t, res)
+			assert.IsType(t, tc.errType, err)
+			assert.IsType(t, tc.errType, c.Error())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package abcicli
+
+import (
+	"time"
+)
+
+// SocketClientOption sets an optional parameter on the socketClient.
+type SocketClientOption func(*socketClient)
+
+// SocketQueueSize sets the size of the request queue. Defaults to 256.
+func SocketQueueSize(size int) SocketClientOption {
+	return func(cli *socketClient) {
+		cli.queueSize = size
+	}
+}
+
+// SocketEnqueueTimeout sets how long XxxAsync and XxxSync calls wait for
+// room in a full request queue. Calls that time out fail with ErrQueueFull:
+// Sync calls return it, and Async calls return a ReqRes that failed with it
+// as its Err. A timeout of 0, the default, waits until the client stops.
+func SocketEnqueueTimeout(timeout time.Duration) SocketClientOption {
+	return func(cli *socketClient) {
+		cli.enqueueTimeout = timeout
+	}
+}
+
+// SocketFlushInterval sets the longest time a request waits in the buffer
+// before the client sends a Flush on its own. Defaults to 20ms.
+func SocketFlushInterval(interval time.Duration) SocketClientOption {
+	return func(cli *socketClient) {
+		cli.flushInterval = interval
+	}
+}
+
+// SocketFlushEvery makes the client send a Flush after every n requests,
+// on top of the flush interval.
+func SocketFlushEvery(n int) SocketClientOption {
+	return func(cli *socketClient) {
+		cli.flushEvery = n
+	}
+}
+
+// SocketFlushImmediate makes the client send a Flush after every request.
+func SocketFlushImmediate() SocketClientOption {
+	return SocketFlushEvery(1)
+}
+
+// SocketCoalesceFlushes makes the client merge the flushes waiting in the
+// request queue into one, so concurrent XxxSync callers share a round trip.
+func SocketCoalesceFlushes() SocketClientOption {
+	return func(cli *socketClient) {
+		cli.coalesce = true
+	}
+}
+
+// SocketCheckVersion makes the client check on start that the server speaks
+// a compatible ABCI version, see CheckVersion. Start fails and the client
+// is stopped if it doesn't.
+func SocketCheckVersion() SocketClientOption {
+	return func(cli *socketClient) {
+		cli.checkVersion = true
+	}
+}
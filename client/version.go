@@ -0,0 +1,24 @@
+package abcicli
+
+import (
+	"github.com/tendermint/abci/types"
+	"github.com/tendermint/abci/version"
+)
+
+// CheckVersion sends an Info request with the ABCI version of the client,
+// and returns an ErrIncompatibleVersion if the server reports an ABCI
+// version that isn't compatible with it, or none at all.
+// The response is returned either way, eg. to print the versions.
+func CheckVersion(cli Client) (*types.ResponseInfo, error) {
+	res, err := cli.InfoSync(types.RequestInfo{AbciVersion: version.ABCIVersion})
+	if err != nil {
+		return nil, err
+	}
+	if version.Compatible(version.ABCIVersion, res.AbciVersion) != nil {
+		return res, ErrIncompatibleVersion{
+			Client: version.ABCIVersion,
+			Server: res.AbciVersion,
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,83 @@
+package abcicli_test
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmn "github.com/tendermint/tmlibs/common"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/types"
+	"github.com/tendermint/abci/version"
+)
+
+func TestCheckVersion(t *testing.T) {
+	c := abcicli.NewLocalClient(nil, types.NewBaseApplication())
+	res, err := abcicli.CheckVersion(c)
+	require.NoError(t, err)
+	assert.Equal(t, version.ABCIVersion, res.AbciVersion)
+}
+
+func TestSocketCheckVersion(t *testing.T) {
+	cases := []struct {
+		name   string
+		server string
+		ok     bool
+	}{
+		{"compatible", version.Maj + "." + version.Min + ".99", true},
+		{"incompatible", "99.0.0", false},
+		{"unreported", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			socket := fmt.Sprintf("unix://test-socket-version-%s.sock", tc.name)
+			ln, err := net.Listen(cmn.ProtocolAndAddress(socket))
+			require.NoError(t, err)
+			defer ln.Close()
+
+			// answer Info with the server version, until the client closes
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+				for {
+					req := &types.Request{}
+					if err := types.ReadMessage(r, req); err != nil {
+						return
+					}
+					switch req.Value.(type) {
+					case *types.Request_Info:
+						types.WriteMessage(types.ToResponseInfo(types.ResponseInfo{AbciVersion: tc.server}), w)
+					case *types.Request_Flush:
+						types.WriteMessage(types.ToResponseFlush(), w)
+						w.Flush()
+					default:
+						types.WriteMessage(types.ToResponseException("unexpected request"), w)
+					}
+				}
+			}()
+
+			c := abcicli.NewSocketClient(socket, true, abcicli.SocketCheckVersion())
+			c.SetLogger(log.TestingLogger())
+			err = c.Start()
+			defer c.Stop()
+			if tc.ok {
+				assert.NoError(t, err)
+				assert.True(t, c.IsRunning())
+				return
+			}
+			assert.Equal(t, abcicli.ErrIncompatibleVersion{Client: version.ABCIVersion, Server: tc.server}, err)
+			assert.False(t, c.IsRunning())
+			assert.Equal(t, err, c.Error(), "stopped for the error")
+		})
+	}
+}
@@ -2,34 +2,52 @@ package main
 
 import (
 	"bufio"
-	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	cmn "github.com/tendermint/tmlibs/common"
+	dbm "github.com/tendermint/tmlibs/db"
 	"github.com/tendermint/tmlibs/log"
+	"github.com/tendermint/tmlibs/pubsub/query"
 
 	abcicli "github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/determinism"
 	"github.com/tendermint/abci/example/code"
 	"github.com/tendermint/abci/example/counter"
 	"github.com/tendermint/abci/example/kvstore"
+	"github.com/tendermint/abci/indexer"
 	"github.com/tendermint/abci/server"
 	servertest "github.com/tendermint/abci/tests/server"
 	"github.com/tendermint/abci/types"
 	"github.com/tendermint/abci/version"
 )
 
-// client is a global variable so it can be reused by the console
+// client is a global variable so it can be reused by the console,
+// and so is the indexer of its DeliverTx results
 var (
-	client abcicli.Client
-	logger log.Logger
+	client    abcicli.Client
+	txIndexer *indexer.Indexer
+	indexDB   dbm.DB
+	logger    log.Logger
 )
 
+// the commands delivering txs, committing or searching them use the indexer
+var indexCommands = map[string]bool{
+	"deliver_tx": true,
+	"commit":     true,
+	"search":     true,
+	"batch":      true,
+	"console":    true,
+}
+
 // flags
 var (
 	// global
@@ -37,17 +55,29 @@ var (
 	flagAbci     string
 	flagVerbose  bool   // for the println output
 	flagLogLevel string // for the logger
+	flagIndex    string // for the indexer
+
+	flagCheckVersion bool
 
 	// query
 	flagPath   string
 	flagHeight int
 	flagProve  bool
 
+	// check_tx
+	flagRecheck bool
+
+	// examples
+	flagCheckDeterminism bool
+
 	// counter
 	flagSerial bool
 
 	// kvstore
-	flagPersist string
+	flagPersist          string
+	flagSnapshotInterval int64
+	flagLivenessWindow   int64
+	flagMaxMissedBlocks  int64
 )
 
 var RootCmd = &cobra.Command{
@@ -80,9 +110,31 @@ var RootCmd = &cobra.Command{
 			if err := client.Start(); err != nil {
 				return err
 			}
+			if flagCheckVersion {
+				if _, err := abcicli.CheckVersion(client); err != nil {
+					return err
+				}
+			}
+		}
+		if txIndexer == nil && indexCommands[cmd.Use] {
+			indexDB = dbm.NewMemDB()
+			if flagIndex != "" {
+				db, err := dbm.NewGoLevelDB("index", flagIndex)
+				if err != nil {
+					return err
+				}
+				indexDB = db
+			}
+			txIndexer = indexer.NewIndexer(indexDB)
+			txIndexer.Subscribe(client, nil)
 		}
 		return nil
 	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if indexDB != nil {
+			indexDB.Close()
+		}
+	},
 }
 
 // Structure for data passed to print response.
@@ -93,7 +145,51 @@ type response struct {
 	Info string
 	Log  string
 
-	Query *queryResponse
+	GasWanted int64
+	GasUsed   int64
+
+	CheckTx  *checkTxResponse
+	Query    *queryResponse
+	Paths    []string
+	Search   []*indexer.TxResult
+	Versions *versionsResponse
+	Snapshot *snapshotResponse
+	Proposal *proposalResponse
+	EndBlock *endBlockResponse
+}
+
+type checkTxResponse struct {
+	Priority int64
+	Sender   string
+	Nonce    uint64
+	Eviction types.EvictionHint
+}
+
+type snapshotResponse struct {
+	Snapshots     []*types.Snapshot
+	Chunk         []byte
+	Result        string
+	RefetchChunks []uint32
+	RejectSenders []string
+}
+
+type proposalResponse struct {
+	Txs    [][]byte
+	Status string
+}
+
+type versionsResponse struct {
+	Version           string
+	AppVersion        uint64
+	ClientAbciVersion string
+	ServerAbciVersion string
+}
+
+type endBlockResponse struct {
+	ValidatorUpdates      []types.Validator
+	ConsensusParamUpdates *types.ConsensusParams
+	// the error of the updates applied to the default params, if invalid
+	ConsensusParamsError error
 }
 
 type queryResponse struct {
@@ -111,9 +207,11 @@ func Execute() error {
 
 func addGlobalFlags() {
 	RootCmd.PersistentFlags().StringVarP(&flagAddress, "address", "", "tcp://0.0.0.0:26658", "address of application socket")
-	RootCmd.PersistentFlags().StringVarP(&flagAbci, "abci", "", "socket", "either socket or grpc")
+	RootCmd.PersistentFlags().StringVarP(&flagAbci, "abci", "", "socket", "either socket or grpc, or failover for a client with comma-separated addresses")
 	RootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false, "print the command and results as if it were a console session")
 	RootCmd.PersistentFlags().StringVarP(&flagLogLevel, "log_level", "", "debug", "set the logger level")
+	RootCmd.PersistentFlags().StringVarP(&flagIndex, "index", "", "", "directory to use for a database indexing the delivered txs (in memory if empty)")
+	RootCmd.PersistentFlags().BoolVarP(&flagCheckVersion, "check_version", "", false, "refuse to talk to an app whose ABCI version is incompatible")
 }
 
 func addQueryFlags() {
@@ -122,8 +220,13 @@ func addQueryFlags() {
 	queryCmd.PersistentFlags().BoolVarP(&flagProve, "prove", "", false, "whether or not to return a merkle proof of the query result")
 }
 
+func addCheckTxFlags() {
+	checkTxCmd.PersistentFlags().BoolVarP(&flagRecheck, "recheck", "", false, "check the transaction again, as after a commit")
+}
+
 func addCounterFlags() {
 	counterCmd.PersistentFlags().BoolVarP(&flagSerial, "serial", "", false, "enforce incrementing (serial) transactions")
+	counterCmd.PersistentFlags().BoolVarP(&flagCheckDeterminism, "check_determinism", "", false, "run two instances of the app, exiting when their responses differ")
 }
 
 func addDummyFlags() {
@@ -132,6 +235,10 @@ func addDummyFlags() {
 
 func addKVStoreFlags() {
 	kvstoreCmd.PersistentFlags().StringVarP(&flagPersist, "persist", "", "", "directory to use for a database")
+	kvstoreCmd.PersistentFlags().Int64VarP(&flagSnapshotInterval, "snapshot_interval", "", 0, "take a snapshot every this many heights, with --persist (0 to disable)")
+	kvstoreCmd.PersistentFlags().Int64VarP(&flagLivenessWindow, "liveness_window", "", kvstore.DefaultLivenessWindow, "number of recent blocks tracked for validator liveness, with --persist (0 to disable jailing)")
+	kvstoreCmd.PersistentFlags().BoolVarP(&flagCheckDeterminism, "check_determinism", "", false, "run two instances of the app, exiting when their responses differ (the second persisted in the --persist directory suffixed with -determinism)")
+	kvstoreCmd.PersistentFlags().Int64VarP(&flagMaxMissedBlocks, "max_missed_blocks", "", kvstore.DefaultMaxMissedBlocks, "jail validators missing more than this many blocks of the liveness window, with --persist")
 }
 
 func addCommands() {
@@ -141,12 +248,22 @@ func addCommands() {
 	RootCmd.AddCommand(infoCmd)
 	RootCmd.AddCommand(setOptionCmd)
 	RootCmd.AddCommand(deliverTxCmd)
+	addCheckTxFlags()
 	RootCmd.AddCommand(checkTxCmd)
+	RootCmd.AddCommand(endBlockCmd)
 	RootCmd.AddCommand(commitCmd)
 	RootCmd.AddCommand(versionCmd)
 	RootCmd.AddCommand(testCmd)
 	addQueryFlags()
 	RootCmd.AddCommand(queryCmd)
+	RootCmd.AddCommand(pathsCmd)
+	RootCmd.AddCommand(searchCmd)
+	RootCmd.AddCommand(listSnapshotsCmd)
+	RootCmd.AddCommand(offerSnapshotCmd)
+	RootCmd.AddCommand(loadSnapshotChunkCmd)
+	RootCmd.AddCommand(applySnapshotChunkCmd)
+	RootCmd.AddCommand(prepareProposalCmd)
+	RootCmd.AddCommand(processProposalCmd)
 
 	// examples
 	addCounterFlags()
@@ -194,8 +311,10 @@ var consoleCmd = &cobra.Command{
 This command opens an interactive console for running any of the other commands
 without opening a new connection each time
 `,
-	Args:      cobra.ExactArgs(0),
-	ValidArgs: []string{"echo", "info", "set_option", "deliver_tx", "check_tx", "commit", "query"},
+	Args: cobra.ExactArgs(0),
+	ValidArgs: []string{"echo", "info", "set_option", "deliver_tx", "check_tx", "end_block", "commit", "query", "paths", "search",
+		"list_snapshots", "offer_snapshot", "load_snapshot_chunk", "apply_snapshot_chunk",
+		"prepare_proposal", "process_proposal"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return cmdConsole(cmd, args)
 	},
@@ -249,6 +368,20 @@ var checkTxCmd = &cobra.Command{
 	},
 }
 
+var endBlockCmd = &cobra.Command{
+	Use:   "end_block",
+	Short: "end the block and print the validator and consensus params updates: [height]",
+	Long: `end the block and print the validator and consensus params updates: [height]
+
+The consensus params updates are applied to the default params,
+and flagged if the result is invalid.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmdEndBlock(cmd, args)
+	},
+}
+
 var commitCmd = &cobra.Command{
 	Use:   "commit",
 	Short: "commit the application state and return the Merkle root hash",
@@ -280,6 +413,94 @@ var queryCmd = &cobra.Command{
 	},
 }
 
+var pathsCmd = &cobra.Command{
+	Use:   "paths",
+	Short: "list the query paths of the application",
+	Long:  "list the query paths of the application",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmdPaths(cmd, args)
+	},
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "search the txs delivered by the client, by tag",
+	Long: `search the txs delivered by the client, by tag
+
+The query is a conjunction of conditions on the tags of the txs,
+and on their tx.height and tx.hash:
+
+    abci-cli search "app.key='foo' AND tx.height>5"
+
+The txs are indexed in the --index directory, across runs,
+or in memory, as in a console session.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmdSearch(cmd, args)
+	},
+}
+
+var listSnapshotsCmd = &cobra.Command{
+	Use:   "list_snapshots",
+	Short: "list the snapshots of the application state",
+	Long:  "list the snapshots of the application state",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmdListSnapshots(cmd, args)
+	},
+}
+
+var offerSnapshotCmd = &cobra.Command{
+	Use:   "offer_snapshot",
+	Short: "offer a snapshot to restore: height format chunks hash metadata app_hash",
+	Long:  "offer a snapshot to restore: height format chunks hash metadata app_hash",
+	Args:  cobra.ExactArgs(6),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmdOfferSnapshot(cmd, args)
+	},
+}
+
+var loadSnapshotChunkCmd = &cobra.Command{
+	Use:   "load_snapshot_chunk",
+	Short: "load a snapshot chunk: height format chunk",
+	Long:  "load a snapshot chunk: height format chunk",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmdLoadSnapshotChunk(cmd, args)
+	},
+}
+
+var applySnapshotChunkCmd = &cobra.Command{
+	Use:   "apply_snapshot_chunk",
+	Short: "apply a chunk of the offered snapshot: index chunk [sender]",
+	Long:  "apply a chunk of the offered snapshot: index chunk [sender]",
+	Args:  cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmdApplySnapshotChunk(cmd, args)
+	},
+}
+
+var prepareProposalCmd = &cobra.Command{
+	Use:   "prepare_proposal",
+	Short: "prepare the txs of a block to propose: max_tx_bytes [tx...]",
+	Long:  "prepare the txs of a block to propose: max_tx_bytes [tx...]",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmdPrepareProposal(cmd, args)
+	},
+}
+
+var processProposalCmd = &cobra.Command{
+	Use:   "process_proposal",
+	Short: "accept or reject a proposed block: [tx...]",
+	Long:  "accept or reject a proposed block: [tx...]",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmdProcessProposal(cmd, args)
+	},
+}
+
 var counterCmd = &cobra.Command{
 	Use:   "counter",
 	Short: "ABCI demo example",
@@ -463,14 +684,32 @@ func muxOnCommands(cmd *cobra.Command, pArgs []string) error {
 		return cmdCommit(cmd, actualArgs)
 	case "deliver_tx":
 		return cmdDeliverTx(cmd, actualArgs)
+	case "end_block":
+		return cmdEndBlock(cmd, actualArgs)
 	case "echo":
 		return cmdEcho(cmd, actualArgs)
 	case "info":
 		return cmdInfo(cmd, actualArgs)
 	case "query":
 		return cmdQuery(cmd, actualArgs)
+	case "paths":
+		return cmdPaths(cmd, actualArgs)
+	case "search":
+		return cmdSearch(cmd, actualArgs)
 	case "set_option":
 		return cmdSetOption(cmd, actualArgs)
+	case "list_snapshots":
+		return cmdListSnapshots(cmd, actualArgs)
+	case "offer_snapshot":
+		return cmdOfferSnapshot(cmd, actualArgs)
+	case "load_snapshot_chunk":
+		return cmdLoadSnapshotChunk(cmd, actualArgs)
+	case "apply_snapshot_chunk":
+		return cmdApplySnapshotChunk(cmd, actualArgs)
+	case "prepare_proposal":
+		return cmdPrepareProposal(cmd, actualArgs)
+	case "process_proposal":
+		return cmdProcessProposal(cmd, actualArgs)
 	default:
 		return cmdUnimplemented(cmd, pArgs)
 	}
@@ -510,16 +749,25 @@ func cmdEcho(cmd *cobra.Command, args []string) error {
 
 // Get some info from the application
 func cmdInfo(cmd *cobra.Command, args []string) error {
-	var version string
+	var nodeVersion string
 	if len(args) == 1 {
-		version = args[0]
+		nodeVersion = args[0]
 	}
-	res, err := client.InfoSync(types.RequestInfo{version})
+	res, err := client.InfoSync(types.RequestInfo{
+		Version:     nodeVersion,
+		AbciVersion: version.ABCIVersion,
+	})
 	if err != nil {
 		return err
 	}
 	printResponse(cmd, args, response{
 		Data: []byte(res.Data),
+		Versions: &versionsResponse{
+			Version:           res.Version,
+			AppVersion:        res.AppVersion,
+			ClientAbciVersion: version.ABCIVersion,
+			ServerAbciVersion: res.AbciVersion,
+		},
 	})
 	return nil
 }
@@ -554,7 +802,7 @@ func cmdDeliverTx(cmd *cobra.Command, args []string) error {
 		})
 		return nil
 	}
-	txBytes, err := stringOrHexToBytes(args[0])
+	txBytes, err := types.StringOrHexToBytes(args[0])
 	if err != nil {
 		return err
 	}
@@ -563,10 +811,12 @@ func cmdDeliverTx(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	printResponse(cmd, args, response{
-		Code: res.Code,
-		Data: res.Data,
-		Info: res.Info,
-		Log:  res.Log,
+		Code:      res.Code,
+		Data:      res.Data,
+		Info:      res.Info,
+		Log:       res.Log,
+		GasWanted: res.GasWanted,
+		GasUsed:   res.GasUsed,
 	})
 	return nil
 }
@@ -580,19 +830,57 @@ func cmdCheckTx(cmd *cobra.Command, args []string) error {
 		})
 		return nil
 	}
-	txBytes, err := stringOrHexToBytes(args[0])
+	txBytes, err := types.StringOrHexToBytes(args[0])
 	if err != nil {
 		return err
 	}
-	res, err := client.CheckTxSync(txBytes)
+	req := types.RequestCheckTx{Tx: txBytes}
+	if flagRecheck {
+		req.Type = types.CheckTxType_Recheck
+	}
+	res, err := client.CheckTxSync(req)
 	if err != nil {
 		return err
 	}
 	printResponse(cmd, args, response{
-		Code: res.Code,
-		Data: res.Data,
-		Info: res.Info,
-		Log:  res.Log,
+		Code:      res.Code,
+		Data:      res.Data,
+		Info:      res.Info,
+		Log:       res.Log,
+		GasWanted: res.GasWanted,
+		GasUsed:   res.GasUsed,
+		CheckTx: &checkTxResponse{
+			Priority: res.Priority,
+			Sender:   res.Sender,
+			Nonce:    res.Nonce,
+			Eviction: res.Eviction,
+		},
+	})
+	return nil
+}
+
+// End the block
+func cmdEndBlock(cmd *cobra.Command, args []string) error {
+	var height int64
+	if len(args) > 0 {
+		var err error
+		if height, err = strconv.ParseInt(args[0], 10, 64); err != nil {
+			return err
+		}
+	}
+	res, err := client.EndBlockSync(types.RequestEndBlock{Height: height})
+	if err != nil {
+		return err
+	}
+	endBlock := &endBlockResponse{
+		ValidatorUpdates:      res.ValidatorUpdates,
+		ConsensusParamUpdates: res.ConsensusParamUpdates,
+	}
+	if res.ConsensusParamUpdates != nil {
+		endBlock.ConsensusParamsError = types.DefaultConsensusParams().Update(res.ConsensusParamUpdates).Validate()
+	}
+	printResponse(cmd, args, response{
+		EndBlock: endBlock,
 	})
 	return nil
 }
@@ -619,7 +907,7 @@ func cmdQuery(cmd *cobra.Command, args []string) error {
 		})
 		return nil
 	}
-	queryBytes, err := stringOrHexToBytes(args[0])
+	queryBytes, err := types.StringOrHexToBytes(args[0])
 	if err != nil {
 		return err
 	}
@@ -647,12 +935,258 @@ func cmdQuery(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func cmdCounter(cmd *cobra.Command, args []string) error {
+// List the query paths of the application
+func cmdPaths(cmd *cobra.Command, args []string) error {
+	resQuery, err := client.QuerySync(types.RequestQuery{
+		Path: types.QueryPathsPath,
+	})
+	if err != nil {
+		return err
+	}
+	var paths []string
+	if resQuery.IsOK() {
+		if err := json.Unmarshal(resQuery.Value, &paths); err != nil {
+			return fmt.Errorf("Error decoding the query paths: %v", err)
+		}
+	}
+	printResponse(cmd, args, response{
+		Code:  resQuery.Code,
+		Info:  resQuery.Info,
+		Log:   resQuery.Log,
+		Paths: paths,
+	})
+	return nil
+}
+
+// Search the delivered txs by tag
+func cmdSearch(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		printResponse(cmd, args, response{
+			Code: codeBad,
+			Log:  "want the query",
+		})
+		return nil
+	}
+	// the console splits the query on spaces
+	q, err := query.New(strings.Trim(strings.Join(args, " "), "\""))
+	if err != nil {
+		printResponse(cmd, args, response{
+			Code: codeBad,
+			Log:  err.Error(),
+		})
+		return nil
+	}
+	results, err := txIndexer.Search(q)
+	if err != nil {
+		printResponse(cmd, args, response{
+			Code: codeBad,
+			Log:  err.Error(),
+		})
+		return nil
+	}
+	printResponse(cmd, args, response{
+		Search: results,
+	})
+	return nil
+}
+
+// List the snapshots of the application state
+func cmdListSnapshots(cmd *cobra.Command, args []string) error {
+	res, err := client.ListSnapshotsSync(types.RequestListSnapshots{})
+	if err != nil {
+		return err
+	}
+	printResponse(cmd, args, response{
+		Snapshot: &snapshotResponse{
+			Snapshots: res.Snapshots,
+		},
+	})
+	return nil
+}
+
+// Offer a snapshot to restore
+func cmdOfferSnapshot(cmd *cobra.Command, args []string) error {
+	if len(args) != 6 {
+		printResponse(cmd, args, response{
+			Code: codeBad,
+			Info: "want height, format, chunks, hash, metadata and app hash",
+		})
+		return nil
+	}
+	height, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	format, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		return err
+	}
+	chunks, err := strconv.ParseUint(args[2], 10, 32)
+	if err != nil {
+		return err
+	}
+	var hashes [3][]byte
+	for i, arg := range args[3:] {
+		if hashes[i], err = types.StringOrHexToBytes(arg); err != nil {
+			return err
+		}
+	}
+	res, err := client.OfferSnapshotSync(types.RequestOfferSnapshot{
+		Snapshot: &types.Snapshot{
+			Height:   height,
+			Format:   uint32(format),
+			Chunks:   uint32(chunks),
+			Hash:     hashes[0],
+			Metadata: hashes[1],
+		},
+		AppHash: hashes[2],
+	})
+	if err != nil {
+		return err
+	}
+	printResponse(cmd, args, response{
+		Snapshot: &snapshotResponse{
+			Result: res.Result.String(),
+		},
+	})
+	return nil
+}
+
+// Load a snapshot chunk
+func cmdLoadSnapshotChunk(cmd *cobra.Command, args []string) error {
+	if len(args) != 3 {
+		printResponse(cmd, args, response{
+			Code: codeBad,
+			Info: "want height, format and chunk",
+		})
+		return nil
+	}
+	height, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	format, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		return err
+	}
+	chunk, err := strconv.ParseUint(args[2], 10, 32)
+	if err != nil {
+		return err
+	}
+	res, err := client.LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk{
+		Height: height,
+		Format: uint32(format),
+		Chunk:  uint32(chunk),
+	})
+	if err != nil {
+		return err
+	}
+	printResponse(cmd, args, response{
+		Snapshot: &snapshotResponse{
+			Chunk: res.Chunk,
+		},
+	})
+	return nil
+}
+
+// Apply a chunk of the offered snapshot
+func cmdApplySnapshotChunk(cmd *cobra.Command, args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		printResponse(cmd, args, response{
+			Code: codeBad,
+			Info: "want index, chunk and optionally sender",
+		})
+		return nil
+	}
+	index, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return err
+	}
+	chunk, err := types.StringOrHexToBytes(args[1])
+	if err != nil {
+		return err
+	}
+	var sender string
+	if len(args) == 3 {
+		sender = args[2]
+	}
+	res, err := client.ApplySnapshotChunkSync(types.RequestApplySnapshotChunk{
+		Index:  uint32(index),
+		Chunk:  chunk,
+		Sender: sender,
+	})
+	if err != nil {
+		return err
+	}
+	printResponse(cmd, args, response{
+		Snapshot: &snapshotResponse{
+			Result:        res.Result.String(),
+			RefetchChunks: res.RefetchChunks,
+			RejectSenders: res.RejectSenders,
+		},
+	})
+	return nil
+}
+
+// Prepare the txs of a block to propose
+func cmdPrepareProposal(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		printResponse(cmd, args, response{
+			Code: codeBad,
+			Info: "want the max tx bytes and the txs",
+		})
+		return nil
+	}
+	maxTxBytes, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	txs, err := stringsOrHexToBytes(args[1:])
+	if err != nil {
+		return err
+	}
+	res, err := client.PrepareProposalSync(types.RequestPrepareProposal{
+		MaxTxBytes: maxTxBytes,
+		Txs:        txs,
+	})
+	if err != nil {
+		return err
+	}
+	printResponse(cmd, args, response{
+		Proposal: &proposalResponse{
+			Txs: res.Txs,
+		},
+	})
+	return nil
+}
 
-	app := counter.NewCounterApplication(flagSerial)
+// Accept or reject a proposed block
+func cmdProcessProposal(cmd *cobra.Command, args []string) error {
+	txs, err := stringsOrHexToBytes(args)
+	if err != nil {
+		return err
+	}
+	res, err := client.ProcessProposalSync(types.RequestProcessProposal{
+		Txs: txs,
+	})
+	if err != nil {
+		return err
+	}
+	printResponse(cmd, args, response{
+		Proposal: &proposalResponse{
+			Status: res.Status.String(),
+		},
+	})
+	return nil
+}
 
+func cmdCounter(cmd *cobra.Command, args []string) error {
 	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
 
+	app := checkDeterminism(func(instance int) types.Application {
+		return counter.NewCounterApplication(flagSerial)
+	}, logger)
+
 	// Start the listener
 	srv, err := server.NewServer(flagAddress, flagAbci, app)
 	if err != nil {
@@ -675,13 +1209,20 @@ func cmdKVStore(cmd *cobra.Command, args []string) error {
 	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
 
 	// Create the application - in memory or persisted to disk
-	var app types.Application
-	if flagPersist == "" {
-		app = kvstore.NewKVStoreApplication()
-	} else {
-		app = kvstore.NewPersistentKVStoreApplication(flagPersist)
-		app.(*kvstore.PersistentKVStoreApplication).SetLogger(logger.With("module", "kvstore"))
-	}
+	app := checkDeterminism(func(instance int) types.Application {
+		if flagPersist == "" {
+			return kvstore.NewKVStoreApplication()
+		}
+		dir := flagPersist
+		if instance > 0 {
+			dir = filepath.Clean(flagPersist) + "-determinism"
+		}
+		persistentApp := kvstore.NewPersistentKVStoreApplication(dir)
+		persistentApp.SetLogger(logger.With("module", "kvstore"))
+		persistentApp.SetSnapshotInterval(flagSnapshotInterval)
+		persistentApp.SetLivenessParams(flagLivenessWindow, flagMaxMissedBlocks)
+		return persistentApp
+	}, logger)
 
 	// Start the listener
 	srv, err := server.NewServer(flagAddress, flagAbci, app)
@@ -701,6 +1242,20 @@ func cmdKVStore(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// checkDeterminism returns the app made by newApp, or with --check_determinism
+// a checker of two instances of it, exiting at the first mismatch.
+func checkDeterminism(newApp func(instance int) types.Application, logger log.Logger) types.Application {
+	if !flagCheckDeterminism {
+		return newApp(0)
+	}
+	checker := determinism.NewChecker(newApp(0), newApp(1))
+	checker.SetMismatchHandler(func(err determinism.ErrMismatch) {
+		logger.Error(err.Error())
+		os.Exit(1)
+	})
+	return checker
+}
+
 //--------------------------------------------------------------------------------
 
 func printResponse(cmd *cobra.Command, args []string, rsp response) {
@@ -728,6 +1283,87 @@ func printResponse(cmd *cobra.Command, args []string, rsp response) {
 	if rsp.Log != "" {
 		fmt.Printf("-> log: %s\n", rsp.Log)
 	}
+	if rsp.GasWanted != 0 || rsp.GasUsed != 0 {
+		fmt.Printf("-> gas_wanted: %d\n", rsp.GasWanted)
+		fmt.Printf("-> gas_used: %d\n", rsp.GasUsed)
+	}
+
+	if rsp.Versions != nil {
+		if rsp.Versions.Version != "" {
+			fmt.Printf("-> version: %s\n", rsp.Versions.Version)
+		}
+		if rsp.Versions.AppVersion != 0 {
+			fmt.Printf("-> app_version: %d\n", rsp.Versions.AppVersion)
+		}
+		serverVersion := rsp.Versions.ServerAbciVersion
+		if serverVersion == "" {
+			serverVersion = "unknown"
+		}
+		fmt.Printf("-> abci_version: client=%s server=%s\n", rsp.Versions.ClientAbciVersion, serverVersion)
+	}
+
+	if rsp.CheckTx != nil {
+		if rsp.CheckTx.Priority != 0 {
+			fmt.Printf("-> priority: %d\n", rsp.CheckTx.Priority)
+		}
+		// the nonce is only meaningful with a sender
+		if rsp.CheckTx.Sender != "" {
+			fmt.Printf("-> sender: %s\n", rsp.CheckTx.Sender)
+			fmt.Printf("-> nonce: %d\n", rsp.CheckTx.Nonce)
+		}
+		if rsp.CheckTx.Eviction != types.EvictionHint_Normal {
+			fmt.Printf("-> eviction: %s\n", rsp.CheckTx.Eviction)
+		}
+	}
+
+	if rsp.Snapshot != nil {
+		for _, snapshot := range rsp.Snapshot.Snapshots {
+			fmt.Printf("-> snapshot: height=%d format=%d chunks=%d hash=0x%X metadata=0x%X\n",
+				snapshot.Height, snapshot.Format, snapshot.Chunks, snapshot.Hash, snapshot.Metadata)
+		}
+		if len(rsp.Snapshot.Chunk) != 0 {
+			fmt.Printf("-> chunk: 0x%X\n", rsp.Snapshot.Chunk)
+		}
+		if rsp.Snapshot.Result != "" {
+			fmt.Printf("-> result: %s\n", rsp.Snapshot.Result)
+		}
+		if len(rsp.Snapshot.RefetchChunks) != 0 {
+			fmt.Printf("-> refetch_chunks: %v\n", rsp.Snapshot.RefetchChunks)
+		}
+		if len(rsp.Snapshot.RejectSenders) != 0 {
+			fmt.Printf("-> reject_senders: %v\n", rsp.Snapshot.RejectSenders)
+		}
+	}
+
+	if rsp.Proposal != nil {
+		for _, tx := range rsp.Proposal.Txs {
+			fmt.Printf("-> tx: 0x%X\n", tx)
+		}
+		if rsp.Proposal.Status != "" {
+			fmt.Printf("-> status: %s\n", rsp.Proposal.Status)
+		}
+	}
+
+	if rsp.EndBlock != nil {
+		for _, v := range rsp.EndBlock.ValidatorUpdates {
+			fmt.Printf("-> validator_update: pubkey=0x%X power=%d\n", v.PubKey.Data, v.Power)
+		}
+		if params := rsp.EndBlock.ConsensusParamUpdates; params != nil {
+			fmt.Printf("-> consensus_param_updates: %v\n", params)
+			if rsp.EndBlock.ConsensusParamsError != nil {
+				fmt.Printf("-> invalid consensus_param_updates: %v\n", rsp.EndBlock.ConsensusParamsError)
+			}
+		}
+	}
+
+	for _, path := range rsp.Paths {
+		fmt.Printf("-> path: %s\n", path)
+	}
+
+	for _, result := range rsp.Search {
+		fmt.Printf("-> tx: height=%d index=%d hash=0x%X code=%d tx=0x%X\n",
+			result.Height, result.Index, types.TxHash(result.Tx), result.Result.Code, result.Tx)
+	}
 
 	if rsp.Query != nil {
 		fmt.Printf("-> height: %d\n", rsp.Query.Height)
@@ -745,21 +1381,15 @@ func printResponse(cmd *cobra.Command, args []string, rsp response) {
 	}
 }
 
-// NOTE: s is interpreted as a string unless prefixed with 0x
-func stringOrHexToBytes(s string) ([]byte, error) {
-	if len(s) > 2 && strings.ToLower(s[:2]) == "0x" {
-		b, err := hex.DecodeString(s[2:])
+// stringsOrHexToBytes parses each arg with types.StringOrHexToBytes
+func stringsOrHexToBytes(args []string) ([][]byte, error) {
+	bzs := make([][]byte, len(args))
+	for i, arg := range args {
+		bz, err := types.StringOrHexToBytes(arg)
 		if err != nil {
-			err = fmt.Errorf("Error decoding hex argument: %s", err.Error())
 			return nil, err
 		}
-		return b, nil
-	}
-
-	if !strings.HasPrefix(s, "\"") || !strings.HasSuffix(s, "\"") {
-		err := fmt.Errorf("Invalid string arg: \"%s\". Must be quoted or a \"0x\"-prefixed hex string", s)
-		return nil, err
+		bzs[i] = bz
 	}
-
-	return []byte(s[1 : len(s)-1]), nil
+	return bzs, nil
 }
@@ -0,0 +1,282 @@
+package determinism
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/tendermint/abci/types"
+	cmn "github.com/tendermint/tmlibs/common"
+)
+
+// ErrMismatch is a difference between the responses of the two instances
+// to the same call.
+type ErrMismatch struct {
+	Method string   // eg. "DeliverTx", or "DeliverTxBatch[2]" for a tx of a batch
+	Diff   []string // "Field: <first> != <second>" for each differing field
+}
+
+func (err ErrMismatch) Error() string {
+	return fmt.Sprintf("Non-deterministic %s:\n  %s", err.Method, strings.Join(err.Diff, "\n  "))
+}
+
+// ignoredFields may differ between the instances:
+// they are for humans, not for consensus.
+var ignoredFields = map[string]bool{
+	"Log":  true,
+	"Info": true,
+}
+
+// Checker is an Application forwarding every call to two independent
+// instances of an app, and comparing their responses, but for the
+// Log and Info fields. Info responses only have their last block height and
+// app hash compared, their other fields being about the instance.
+// It returns the responses of the first instance.
+//
+// At each mismatch, it calls the mismatch handler, which panics with the
+// ErrMismatch by default, failing at the first mismatch. Set Record as the
+// handler to collect every mismatch for Mismatches instead.
+type Checker struct {
+	first, second types.Application
+	onMismatch    func(ErrMismatch)
+
+	mtx        sync.Mutex
+	mismatches []ErrMismatch
+}
+
+var _ types.BatchApplication = (*Checker)(nil)
+
+func NewChecker(first, second types.Application) *Checker {
+	return &Checker{
+		first:  first,
+		second: second,
+		onMismatch: func(err ErrMismatch) {
+			panic(err)
+		},
+	}
+}
+
+// SetMismatchHandler sets the function called with each mismatch,
+// instead of panicking.
+func (c *Checker) SetMismatchHandler(onMismatch func(ErrMismatch)) {
+	c.onMismatch = onMismatch
+}
+
+// Mismatches returns the mismatches recorded by Record.
+func (c *Checker) Mismatches() []ErrMismatch {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return append([]ErrMismatch(nil), c.mismatches...)
+}
+
+// Record is a mismatch handler recording the mismatches for Mismatches,
+// so the checker keeps going after them.
+func (c *Checker) Record(err ErrMismatch) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.mismatches = append(c.mismatches, err)
+}
+
+// check compares the responses of the instances to a call of the method
+func (c *Checker) check(method string, first, second interface{}) {
+	if diff := Diff(first, second); len(diff) != 0 {
+		c.onMismatch(ErrMismatch{Method: method, Diff: diff})
+	}
+}
+
+// Diff returns the differences between the exported fields of two responses
+// of the same type, ignoring the Log and Info fields.
+// Nil and empty slices are equal.
+func Diff(first, second interface{}) []string {
+	v1, v2 := reflect.Indirect(reflect.ValueOf(first)), reflect.Indirect(reflect.ValueOf(second))
+	if !v1.IsValid() || !v2.IsValid() {
+		if v1.IsValid() != v2.IsValid() {
+			return []string{fmt.Sprintf("%v != %v", first, second)}
+		}
+		return nil
+	}
+	if v1.Type() != v2.Type() {
+		return []string{fmt.Sprintf("type: %v != %v", v1.Type(), v2.Type())}
+	}
+	if v1.Kind() != reflect.Struct {
+		if !equal(v1, v2) {
+			return []string{fmt.Sprintf("%v != %v", v1, v2)}
+		}
+		return nil
+	}
+
+	var diff []string
+	for i := 0; i < v1.NumField(); i++ {
+		field := v1.Type().Field(i)
+		if field.PkgPath != "" || ignoredFields[field.Name] || strings.HasPrefix(field.Name, "XXX_") {
+			continue
+		}
+		f1, f2 := v1.Field(i), v2.Field(i)
+		if !equal(f1, f2) {
+			diff = append(diff, fmt.Sprintf("%s: %s != %s", field.Name, format(f1), format(f2)))
+		}
+	}
+	return diff
+}
+
+func equal(v1, v2 reflect.Value) bool {
+	if v1.Kind() == reflect.Slice && v1.Len() == 0 && v2.Len() == 0 {
+		return true
+	}
+	if b1, ok := v1.Interface().([]byte); ok {
+		return bytes.Equal(b1, v2.Interface().([]byte))
+	}
+	return reflect.DeepEqual(v1.Interface(), v2.Interface())
+}
+
+func format(v reflect.Value) string {
+	if b, ok := v.Interface().([]byte); ok {
+		return fmt.Sprintf("0x%X", b)
+	}
+	if tags, ok := v.Interface().([]cmn.KVPair); ok {
+		strs := make([]string, len(tags))
+		for i, tag := range tags {
+			strs[i] = fmt.Sprintf("%s=%s", tag.Key, tag.Value)
+		}
+		return "[" + strings.Join(strs, " ") + "]"
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+//-----------------------------------------
+
+func (c *Checker) Info(req types.RequestInfo) types.ResponseInfo {
+	res := c.first.Info(req)
+	c.check("Info", lastBlock(res), lastBlock(c.second.Info(req)))
+	return res
+}
+
+// lastBlock returns the fields of the Info response the instances agree on
+func lastBlock(res types.ResponseInfo) types.ResponseInfo {
+	return types.ResponseInfo{
+		LastBlockHeight:  res.LastBlockHeight,
+		LastBlockAppHash: res.LastBlockAppHash,
+	}
+}
+
+func (c *Checker) SetOption(req types.RequestSetOption) types.ResponseSetOption {
+	res := c.first.SetOption(req)
+	c.check("SetOption", res, c.second.SetOption(req))
+	return res
+}
+
+func (c *Checker) Query(req types.RequestQuery) types.ResponseQuery {
+	res := c.first.Query(req)
+	c.check("Query", res, c.second.Query(req))
+	return res
+}
+
+func (c *Checker) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	res := c.first.CheckTx(req)
+	c.check("CheckTx", res, c.second.CheckTx(req))
+	return res
+}
+
+// CheckTxBatch returns the responses of a first instance breaking the
+// batch, for the caller to report, and reports a second one as a mismatch.
+func (c *Checker) CheckTxBatch(req types.RequestCheckTxBatch) []*types.ResponseCheckTx {
+	res, err := types.CheckTxBatch(c.first, req)
+	if err != nil {
+		return res.Responses
+	}
+	res2, err := types.CheckTxBatch(c.second, req)
+	if err != nil {
+		c.onMismatch(ErrMismatch{Method: "CheckTxBatch", Diff: []string{err.Error()}})
+		return res.Responses
+	}
+	for i := range res.Responses {
+		c.check(fmt.Sprintf("CheckTxBatch[%d]", i), res.Responses[i], res2.Responses[i])
+	}
+	return res.Responses
+}
+
+func (c *Checker) InitChain(req types.RequestInitChain) types.ResponseInitChain {
+	res := c.first.InitChain(req)
+	c.check("InitChain", res, c.second.InitChain(req))
+	return res
+}
+
+func (c *Checker) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
+	res := c.first.BeginBlock(req)
+	c.check("BeginBlock", res, c.second.BeginBlock(req))
+	return res
+}
+
+func (c *Checker) DeliverTx(tx []byte) types.ResponseDeliverTx {
+	res := c.first.DeliverTx(tx)
+	c.check("DeliverTx", res, c.second.DeliverTx(tx))
+	return res
+}
+
+// DeliverTxBatch returns the responses of a first instance breaking the
+// batch, for the caller to report, and reports a second one as a mismatch.
+func (c *Checker) DeliverTxBatch(req types.RequestDeliverTxBatch) []*types.ResponseDeliverTx {
+	res, err := types.DeliverTxBatch(c.first, req)
+	if err != nil {
+		return res.Responses
+	}
+	res2, err := types.DeliverTxBatch(c.second, req)
+	if err != nil {
+		c.onMismatch(ErrMismatch{Method: "DeliverTxBatch", Diff: []string{err.Error()}})
+		return res.Responses
+	}
+	for i := range res.Responses {
+		c.check(fmt.Sprintf("DeliverTxBatch[%d]", i), res.Responses[i], res2.Responses[i])
+	}
+	return res.Responses
+}
+
+func (c *Checker) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
+	res := c.first.EndBlock(req)
+	c.check("EndBlock", res, c.second.EndBlock(req))
+	return res
+}
+
+func (c *Checker) Commit() types.ResponseCommit {
+	res := c.first.Commit()
+	c.check("Commit", res, c.second.Commit())
+	return res
+}
+
+func (c *Checker) PrepareProposal(req types.RequestPrepareProposal) types.ResponsePrepareProposal {
+	res := c.first.PrepareProposal(req)
+	c.check("PrepareProposal", res, c.second.PrepareProposal(req))
+	return res
+}
+
+func (c *Checker) ProcessProposal(req types.RequestProcessProposal) types.ResponseProcessProposal {
+	res := c.first.ProcessProposal(req)
+	c.check("ProcessProposal", res, c.second.ProcessProposal(req))
+	return res
+}
+
+func (c *Checker) ListSnapshots(req types.RequestListSnapshots) types.ResponseListSnapshots {
+	res := c.first.ListSnapshots(req)
+	c.check("ListSnapshots", res, c.second.ListSnapshots(req))
+	return res
+}
+
+func (c *Checker) OfferSnapshot(req types.RequestOfferSnapshot) types.ResponseOfferSnapshot {
+	res := c.first.OfferSnapshot(req)
+	c.check("OfferSnapshot", res, c.second.OfferSnapshot(req))
+	return res
+}
+
+func (c *Checker) LoadSnapshotChunk(req types.RequestLoadSnapshotChunk) types.ResponseLoadSnapshotChunk {
+	res := c.first.LoadSnapshotChunk(req)
+	c.check("LoadSnapshotChunk", res, c.second.LoadSnapshotChunk(req))
+	return res
+}
+
+func (c *Checker) ApplySnapshotChunk(req types.RequestApplySnapshotChunk) types.ResponseApplySnapshotChunk {
+	res := c.first.ApplySnapshotChunk(req)
+	c.check("ApplySnapshotChunk", res, c.second.ApplySnapshotChunk(req))
+	return res
+}
@@ -0,0 +1,130 @@
+package determinism
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmn "github.com/tendermint/tmlibs/common"
+
+	"github.com/tendermint/abci/example/kvstore"
+	"github.com/tendermint/abci/types"
+)
+
+// randomApp is a kvstore whose DeliverTx data and Commit hash
+// depend on its instance
+type randomApp struct {
+	*kvstore.KVStoreApplication
+	seed string
+}
+
+func (app *randomApp) DeliverTx(tx []byte) types.ResponseDeliverTx {
+	res := app.KVStoreApplication.DeliverTx(tx)
+	res.Log = app.seed
+	if string(tx) == "random" {
+		res.Data = []byte(app.seed)
+	}
+	return res
+}
+
+func (app *randomApp) Commit() types.ResponseCommit {
+	res := app.KVStoreApplication.Commit()
+	res.Data = append(res.Data, app.seed...)
+	return res
+}
+
+func TestDiff(t *testing.T) {
+	assert.Empty(t, Diff(types.ResponseDeliverTx{Log: "a", Info: "b"}, types.ResponseDeliverTx{Data: []byte{}}))
+	assert.Equal(t, []string{"Code: 1 != 2", "Data: 0xAB != 0x"},
+		Diff(&types.ResponseDeliverTx{Code: 1, Data: []byte{0xAB}}, &types.ResponseDeliverTx{Code: 2}))
+	assert.Equal(t, []string{"Tags: [k=v] != []"},
+		Diff(types.ResponseDeliverTx{Tags: []cmn.KVPair{{[]byte("k"), []byte("v")}}}, types.ResponseDeliverTx{}))
+	assert.Equal(t, []string{"ConsensusParamUpdates: block_size:<max_txs:1 >  != <nil>"},
+		Diff(types.ResponseEndBlock{ConsensusParamUpdates: &types.ConsensusParams{BlockSize: &types.BlockSize{MaxTxs: 1}}},
+			types.ResponseEndBlock{}))
+}
+
+func TestCheckerPersistentKVStore(t *testing.T) {
+	dir1, err := ioutil.TempDir("/tmp", "abci-determinism-test")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir1)
+	dir2, err := ioutil.TempDir("/tmp", "abci-determinism-test")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir2)
+
+	checker := NewChecker(kvstore.NewPersistentKVStoreApplication(dir1), kvstore.NewPersistentKVStoreApplication(dir2))
+	checker.InitChain(types.RequestInitChain{})
+	for height := int64(1); height <= 3; height++ {
+		checker.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: height}})
+		checker.DeliverTx([]byte("key=value"))
+		checker.DeliverTxBatch(types.RequestDeliverTxBatch{Txs: [][]byte{[]byte("a=b"), []byte("c")}})
+		checker.EndBlock(types.RequestEndBlock{Height: height})
+		checker.Commit()
+	}
+	checker.Info(types.RequestInfo{})
+	checker.Query(types.RequestQuery{Path: "/store", Data: []byte("key"), Prove: true})
+}
+
+func TestCheckerMismatch(t *testing.T) {
+	checker := NewChecker(&randomApp{kvstore.NewKVStoreApplication(), "a"},
+		&randomApp{kvstore.NewKVStoreApplication(), "b"})
+
+	// the logs are ignored
+	res := checker.DeliverTx([]byte("tx"))
+	assert.Equal(t, "a", res.Log)
+
+	// the first diverging DeliverTx panics by default
+	func() {
+		defer func() {
+			assert.Equal(t, ErrMismatch{Method: "DeliverTx", Diff: []string{"Data: 0x61 != 0x62"}}, recover())
+		}()
+		checker.DeliverTx([]byte("random"))
+		t.Error("no panic")
+	}()
+
+	// or the mismatches are recorded
+	checker.SetMismatchHandler(checker.Record)
+	checker.DeliverTx([]byte("random"))
+	require.Len(t, checker.Mismatches(), 1)
+	assert.Equal(t, "DeliverTx", checker.Mismatches()[0].Method)
+
+	var mismatches []ErrMismatch
+	checker.SetMismatchHandler(func(err ErrMismatch) { mismatches = append(mismatches, err) })
+	checker.DeliverTxBatch(types.RequestDeliverTxBatch{Txs: [][]byte{[]byte("x"), []byte("random")}})
+	checker.Commit()
+	require.Len(t, mismatches, 2, "every mismatch")
+	assert.Equal(t, "DeliverTxBatch[1]", mismatches[0].Method)
+	assert.Equal(t, "Non-deterministic DeliverTxBatch[1]:\n  Data: 0x61 != 0x62", mismatches[0].Error())
+	assert.Equal(t, "Commit", mismatches[1].Method)
+	assert.Len(t, checker.Mismatches(), 1, "not recorded by another handler")
+}
+
+// infoApp reports the instance in the Info data, and its last block height
+type infoApp struct {
+	*kvstore.KVStoreApplication
+	seed   string
+	height int64
+}
+
+func (app *infoApp) Info(req types.RequestInfo) types.ResponseInfo {
+	res := app.KVStoreApplication.Info(req)
+	res.Data = app.seed
+	res.Version = app.seed
+	res.LastBlockHeight = app.height
+	return res
+}
+
+func TestCheckerInfo(t *testing.T) {
+	second := &infoApp{kvstore.NewKVStoreApplication(), "b", 0}
+	checker := NewChecker(&infoApp{kvstore.NewKVStoreApplication(), "a", 0}, second)
+	checker.SetMismatchHandler(checker.Record)
+	assert.Equal(t, "a", checker.Info(types.RequestInfo{}).Data)
+	assert.Empty(t, checker.Mismatches())
+
+	second.height = 1
+	checker.Info(types.RequestInfo{})
+	require.Len(t, checker.Mismatches(), 1)
+	assert.Equal(t, "Info", checker.Mismatches()[0].Method)
+}
@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"github.com/tendermint/abci/types"
+)
+
+// application publishes the consensus responses of the app it wraps.
+type application struct {
+	types.Application
+	bus *EventBus
+}
+
+// batchApplication is an application wrapping a types.BatchApplication,
+// so the servers keep delivering batches in one call.
+type batchApplication struct {
+	application
+	batchApp types.BatchApplication
+}
+
+// NewApplication returns a middleware publishing the BeginBlock, DeliverTx,
+// EndBlock and Commit responses of the app to the bus, for a server.
+// It is a types.BatchApplication if the app is.
+func NewApplication(app types.Application, bus *EventBus) types.Application {
+	wrapped := application{Application: app, bus: bus}
+	if batchApp, ok := app.(types.BatchApplication); ok {
+		return &batchApplication{wrapped, batchApp}
+	}
+	return &wrapped
+}
+
+func (app *application) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
+	res := app.Application.BeginBlock(req)
+	app.bus.Callback(types.ToRequestBeginBlock(req), types.ToResponseBeginBlock(res))
+	return res
+}
+
+func (app *application) DeliverTx(tx []byte) types.ResponseDeliverTx {
+	res := app.Application.DeliverTx(tx)
+	app.bus.Callback(types.ToRequestDeliverTx(tx), types.ToResponseDeliverTx(res))
+	return res
+}
+
+func (app *application) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
+	res := app.Application.EndBlock(req)
+	app.bus.Callback(types.ToRequestEndBlock(req), types.ToResponseEndBlock(res))
+	return res
+}
+
+func (app *application) Commit() types.ResponseCommit {
+	res := app.Application.Commit()
+	app.bus.Callback(types.ToRequestCommit(), types.ToResponseCommit(res))
+	return res
+}
+
+func (app *batchApplication) CheckTxBatch(req types.RequestCheckTxBatch) []*types.ResponseCheckTx {
+	return app.batchApp.CheckTxBatch(req)
+}
+
+func (app *batchApplication) DeliverTxBatch(req types.RequestDeliverTxBatch) []*types.ResponseDeliverTx {
+	responses := app.batchApp.DeliverTxBatch(req)
+	app.bus.Callback(types.ToRequestDeliverTxBatch(req),
+		types.ToResponseDeliverTxBatch(types.ResponseDeliverTxBatch{Responses: responses}))
+	return responses
+}
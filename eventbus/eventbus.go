@@ -0,0 +1,281 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	abcicli "github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/types"
+	cmn "github.com/tendermint/tmlibs/common"
+	"github.com/tendermint/tmlibs/pubsub/query"
+)
+
+// Types of the events
+const (
+	EventBeginBlock = "BeginBlock"
+	EventDeliverTx  = "DeliverTx"
+	EventEndBlock   = "EndBlock"
+	EventCommit     = "Commit"
+)
+
+// Tags of the events, besides those of their response.
+// DeliverTx events also have the types.TxHashKey tag, with the hex hash,
+// and EndBlock events the number of validator updates.
+const (
+	EventTypeKey        = "abci.event"
+	HeightKey           = "block.height"
+	TxCodeKey           = "tx.code"
+	ValidatorUpdatesKey = "block.validator_updates"
+)
+
+var (
+	// ErrSlowSubscriber is the error of a subscription disconnected
+	// for not keeping up with the events.
+	ErrSlowSubscriber = errors.New("subscriber too slow")
+	// ErrUnsubscribed is the error of a subscription that was unsubscribed.
+	ErrUnsubscribed = errors.New("unsubscribed")
+)
+
+// Event is a response to a request of the consensus connection.
+// Response is a ResponseDeliverTx for each tx of a DeliverTxBatch.
+type Event struct {
+	Type             string
+	Height           int64
+	Tags             map[string]interface{}
+	Tx               []byte            // for DeliverTx events
+	ValidatorUpdates []types.Validator // for EndBlock events
+	Response         *types.Response
+}
+
+// SlowPolicy is what to do when the buffer of a subscription is full.
+type SlowPolicy int
+
+const (
+	// DropEvents drops the events the subscription has no room for.
+	DropEvents SlowPolicy = iota
+	// Disconnect closes the subscription, with ErrSlowSubscriber.
+	Disconnect
+)
+
+//-----------------------------------------
+
+// Subscription receives the events matching its query on Out,
+// until it is closed.
+type Subscription struct {
+	bus    *EventBus
+	query  *query.Query
+	policy SlowPolicy
+	out    chan Event
+
+	// protected by the mutex of the bus
+	dropped int
+	err     error
+}
+
+// Out returns the channel of the events,
+// closed when the subscription is unsubscribed or disconnected.
+func (s *Subscription) Out() <-chan Event {
+	return s.out
+}
+
+// Query returns the query of the subscription, nil for all the events.
+func (s *Subscription) Query() *query.Query {
+	return s.query
+}
+
+// Err returns why the subscription was closed, or nil if it is open.
+func (s *Subscription) Err() error {
+	s.bus.mtx.Lock()
+	defer s.bus.mtx.Unlock()
+	return s.err
+}
+
+// Dropped returns the number of events dropped for the subscription.
+func (s *Subscription) Dropped() int {
+	s.bus.mtx.Lock()
+	defer s.bus.mtx.Unlock()
+	return s.dropped
+}
+
+//-----------------------------------------
+
+// EventBus publishes the consensus responses of a client or an application
+// to its subscriptions.
+//
+// It tracks the height with the BeginBlock and Commit it sees,
+// starting from 1, like the indexer.
+type EventBus struct {
+	mtx    sync.Mutex
+	subs   map[*Subscription]struct{}
+	height int64
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subs:   make(map[*Subscription]struct{}),
+		height: 1,
+	}
+}
+
+// Subscribe returns a subscription to the events matching the query,
+// or to all the events if it is nil, buffering up to capacity events.
+// The policy says what to do when the buffer is full.
+func (b *EventBus) Subscribe(q *query.Query, capacity int, policy SlowPolicy) *Subscription {
+	if capacity < 1 {
+		cmn.PanicSanity(cmn.Fmt("Subscription capacity must be positive, got %d", capacity))
+	}
+	sub := &Subscription{
+		bus:    b,
+		query:  q,
+		policy: policy,
+		out:    make(chan Event, capacity),
+	}
+	b.mtx.Lock()
+	b.subs[sub] = struct{}{}
+	b.mtx.Unlock()
+	return sub
+}
+
+// Unsubscribe closes the subscription, if it is not already.
+func (b *EventBus) Unsubscribe(sub *Subscription) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.close(sub, ErrUnsubscribed)
+}
+
+// NumSubscriptions returns the number of open subscriptions.
+func (b *EventBus) NumSubscriptions() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return len(b.subs)
+}
+
+func (b *EventBus) close(sub *Subscription, err error) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+	delete(b.subs, sub)
+	sub.err = err
+	close(sub.out)
+}
+
+// Publish sends the event to the subscriptions matching its tags,
+// without blocking. Each subscription gets its own copy of the tags.
+func (b *EventBus) Publish(event Event) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.publish(event)
+}
+
+func (b *EventBus) publish(event Event) {
+	for sub := range b.subs {
+		if sub.query != nil && !matches(sub.query, event.Tags) {
+			continue
+		}
+		select {
+		case sub.out <- event.copyTags():
+		default:
+			switch sub.policy {
+			case Disconnect:
+				b.close(sub, ErrSlowSubscriber)
+			default:
+				sub.dropped++
+			}
+		}
+	}
+}
+
+// copyTags returns the event with its own copy of the tags,
+// so that a subscriber changing them doesn't change those of the others.
+func (e Event) copyTags() Event {
+	tags := make(map[string]interface{}, len(e.Tags))
+	for k, v := range e.Tags {
+		tags[k] = v
+	}
+	e.Tags = tags
+	return e
+}
+
+// matches is false for tags that can't be compared to the query,
+// eg. a string tag with a number, on which Matches panics.
+func matches(q *query.Query, tags map[string]interface{}) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	return q.Matches(tags)
+}
+
+//-----------------------------------------
+
+// Tap makes the bus publish the consensus responses of the client.
+// It sets the response callback of the client, to one calling next after
+// publishing, if not nil: pass the callback the client had to keep it.
+func (b *EventBus) Tap(cli abcicli.Client, next abcicli.Callback) {
+	cli.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		b.Callback(req, res)
+		if next != nil {
+			next(req, res)
+		}
+	})
+}
+
+// Callback publishes the BeginBlock, DeliverTx, EndBlock and Commit
+// responses, and tracks the height with the BeginBlock requests and Commit
+// responses. It is an abcicli.Callback, to call from another response callback.
+func (b *EventBus) Callback(req *types.Request, res *types.Response) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	switch r := res.Value.(type) {
+	case *types.Response_BeginBlock:
+		if height := req.GetBeginBlock().Header.Height; height > 0 {
+			b.height = height
+		}
+		b.publish(b.event(EventBeginBlock, res, r.BeginBlock.Tags))
+	case *types.Response_DeliverTx:
+		b.deliverTx(req.GetDeliverTx().Tx, *r.DeliverTx)
+	case *types.Response_DeliverTxBatch:
+		txs := req.GetDeliverTxBatch().Txs
+		for i, res := range r.DeliverTxBatch.Responses {
+			if i < len(txs) && res != nil {
+				b.deliverTx(txs[i], *res)
+			}
+		}
+	case *types.Response_EndBlock:
+		event := b.event(EventEndBlock, res, r.EndBlock.Tags)
+		event.ValidatorUpdates = r.EndBlock.ValidatorUpdates
+		event.Tags[ValidatorUpdatesKey] = int64(len(r.EndBlock.ValidatorUpdates))
+		b.publish(event)
+	case *types.Response_Commit:
+		b.publish(b.event(EventCommit, res, nil))
+		b.height++
+	}
+}
+
+func (b *EventBus) deliverTx(tx []byte, res types.ResponseDeliverTx) {
+	event := b.event(EventDeliverTx, types.ToResponseDeliverTx(res), res.Tags)
+	event.Tx = tx
+	event.Tags[types.TxHashKey] = fmt.Sprintf("%X", types.TxHash(tx))
+	event.Tags[TxCodeKey] = int64(res.Code)
+	b.publish(event)
+}
+
+// event returns an event with the tags of the response,
+// and the type and height tags.
+func (b *EventBus) event(typ string, res *types.Response, tags []cmn.KVPair) Event {
+	eventTags := make(map[string]interface{}, len(tags)+2)
+	for _, tag := range tags {
+		eventTags[string(tag.Key)] = string(tag.Value)
+	}
+	eventTags[EventTypeKey] = typ
+	eventTags[HeightKey] = b.height
+	return Event{
+		Type:     typ,
+		Height:   b.height,
+		Tags:     eventTags,
+		Response: res,
+	}
+}
@@ -0,0 +1,156 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tmlibs/pubsub/query"
+
+	abcicli "github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/example/kvstore"
+	"github.com/tendermint/abci/types"
+)
+
+// drain returns the events buffered for the subscription
+func drain(sub *Subscription) []Event {
+	var events []Event
+	for {
+		select {
+		case event, ok := <-sub.Out():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func eventTypes(events []Event) []string {
+	typs := make([]string, len(events))
+	for i, event := range events {
+		typs[i] = event.Type
+	}
+	return typs
+}
+
+func TestEventBusTap(t *testing.T) {
+	bus := NewEventBus()
+	cli := abcicli.NewLocalClient(nil, kvstore.NewKVStoreApplication())
+	bus.Tap(cli, nil)
+
+	all := bus.Subscribe(nil, 100, DropEvents)
+	foo := bus.Subscribe(query.MustParse("app.key='foo' AND block.height>1"), 100, DropEvents)
+	ends := bus.Subscribe(query.MustParse("abci.event='EndBlock'"), 100, DropEvents)
+	// a string tag can't be compared to a number
+	bad := bus.Subscribe(query.MustParse("app.key>1"), 100, DropEvents)
+
+	for height, txs := range [][]string{{"foo=1", "bar=2"}, {"foo=3"}} {
+		cli.BeginBlockAsync(types.RequestBeginBlock{Header: types.Header{Height: int64(height) + 1}})
+		for _, tx := range txs {
+			cli.DeliverTxAsync([]byte(tx))
+		}
+		cli.EndBlockAsync(types.RequestEndBlock{Height: int64(height) + 1})
+		cli.CommitAsync()
+	}
+
+	assert.Equal(t, []string{
+		EventBeginBlock, EventDeliverTx, EventDeliverTx, EventEndBlock, EventCommit,
+		EventBeginBlock, EventDeliverTx, EventEndBlock, EventCommit,
+	}, eventTypes(drain(all)))
+
+	events := drain(foo)
+	require.Len(t, events, 1)
+	assert.Equal(t, "foo=3", string(events[0].Tx))
+	assert.EqualValues(t, 2, events[0].Height)
+	assert.True(t, events[0].Response.GetDeliverTx().IsOK())
+	assert.EqualValues(t, 0, events[0].Tags[TxCodeKey])
+
+	events = drain(ends)
+	require.Len(t, events, 2)
+	assert.EqualValues(t, 0, events[1].Tags[ValidatorUpdatesKey])
+	assert.Empty(t, events[1].ValidatorUpdates)
+
+	assert.Empty(t, drain(bad))
+
+	// the EndBlock events have the validator updates
+	vals := []types.Validator{types.Ed25519Validator([]byte("pubkey"), 10)}
+	bus.Callback(types.ToRequestEndBlock(types.RequestEndBlock{Height: 3}),
+		types.ToResponseEndBlock(types.ResponseEndBlock{ValidatorUpdates: vals}))
+	events = drain(ends)
+	require.Len(t, events, 1)
+	assert.EqualValues(t, 1, events[0].Tags[ValidatorUpdatesKey])
+	assert.Equal(t, vals, events[0].ValidatorUpdates)
+}
+
+func TestEventBusSlowSubscribers(t *testing.T) {
+	bus := NewEventBus()
+	drop := bus.Subscribe(nil, 2, DropEvents)
+	disconnect := bus.Subscribe(nil, 2, Disconnect)
+	assert.Equal(t, 2, bus.NumSubscriptions())
+
+	for i := 0; i < 3; i++ {
+		bus.Callback(types.ToRequestDeliverTx([]byte("tx")),
+			types.ToResponseDeliverTx(types.ResponseDeliverTx{}))
+	}
+
+	assert.Len(t, drain(drop), 2)
+	assert.Equal(t, 1, drop.Dropped())
+	assert.Nil(t, drop.Err())
+
+	assert.Len(t, drain(disconnect), 2)
+	_, ok := <-disconnect.Out()
+	assert.False(t, ok, "disconnected")
+	assert.Equal(t, ErrSlowSubscriber, disconnect.Err())
+	assert.Equal(t, 1, bus.NumSubscriptions())
+
+	bus.Unsubscribe(drop)
+	bus.Unsubscribe(drop)
+	assert.Equal(t, ErrUnsubscribed, drop.Err())
+	assert.Equal(t, 0, bus.NumSubscriptions())
+
+	assert.Panics(t, func() { bus.Subscribe(nil, 0, DropEvents) })
+}
+
+func TestEventBusTagsCopied(t *testing.T) {
+	bus := NewEventBus()
+	first := bus.Subscribe(nil, 1, DropEvents)
+	second := bus.Subscribe(nil, 1, DropEvents)
+
+	tags := map[string]interface{}{"app.key": "foo"}
+	bus.Publish(Event{Type: EventDeliverTx, Tags: tags})
+
+	events := drain(first)
+	require.Len(t, events, 1)
+	events[0].Tags["app.key"] = "bar"
+	events[0].Tags["app.other"] = "baz"
+
+	events = drain(second)
+	require.Len(t, events, 1)
+	assert.Equal(t, map[string]interface{}{"app.key": "foo"}, events[0].Tags)
+	assert.Equal(t, map[string]interface{}{"app.key": "foo"}, tags)
+}
+
+func TestApplication(t *testing.T) {
+	bus := NewEventBus()
+	sub := bus.Subscribe(nil, 100, DropEvents)
+	app := NewApplication(kvstore.NewKVStoreApplication(), bus)
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 7}})
+	_, err := types.DeliverTxBatch(app, types.RequestDeliverTxBatch{Txs: [][]byte{[]byte("a=1"), []byte("b=2")}})
+	require.Nil(t, err)
+	app.EndBlock(types.RequestEndBlock{Height: 7})
+	app.CheckTx(types.RequestCheckTx{Tx: []byte("c=3")})
+	app.Commit()
+
+	events := drain(sub)
+	assert.Equal(t, []string{
+		EventBeginBlock, EventDeliverTx, EventDeliverTx, EventEndBlock, EventCommit,
+	}, eventTypes(events))
+	for _, event := range events {
+		assert.EqualValues(t, 7, event.Height)
+	}
+	assert.Equal(t, "b=2", string(events[2].Tx))
+}
@@ -9,16 +9,25 @@ import (
 	cmn "github.com/tendermint/tmlibs/common"
 )
 
+// Sender of all the txs in serial mode.
+const Sender = "counter"
+
 type CounterApplication struct {
 	types.BaseApplication
 
 	hashCount int
 	txCount   int
 	serial    bool
+
+	queryMux *types.QueryMux
 }
 
 func NewCounterApplication(serial bool) *CounterApplication {
-	return &CounterApplication{serial: serial}
+	app := &CounterApplication{serial: serial}
+	app.queryMux = types.NewQueryMux().
+		Handle("/hash", app.queryHash).
+		Handle("/tx", app.queryTx)
+	return app
 }
 
 func (app *CounterApplication) Info(req types.RequestInfo) types.ResponseInfo {
@@ -50,9 +59,7 @@ func (app *CounterApplication) DeliverTx(tx []byte) types.ResponseDeliverTx {
 				Code: code.CodeTypeEncodingError,
 				Log:  fmt.Sprintf("Max tx size is 8 bytes, got %d", len(tx))}
 		}
-		tx8 := make([]byte, 8)
-		copy(tx8[len(tx8)-len(tx):], tx)
-		txValue := binary.BigEndian.Uint64(tx8)
+		txValue := txNonce(tx)
 		if txValue != uint64(app.txCount) {
 			return types.ResponseDeliverTx{
 				Code: code.CodeTypeBadNonce,
@@ -63,21 +70,35 @@ func (app *CounterApplication) DeliverTx(tx []byte) types.ResponseDeliverTx {
 	return types.ResponseDeliverTx{Code: code.CodeTypeOK}
 }
 
-func (app *CounterApplication) CheckTx(tx []byte) types.ResponseCheckTx {
+// CheckTx checks the size and the nonce of the txs in serial mode.
+// A rechecked tx already passed the size check, so only its nonce is checked,
+// against the txs delivered since.
+func (app *CounterApplication) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
 	if app.serial {
-		if len(tx) > 8 {
+		tx := req.Tx
+		if req.Type != types.CheckTxType_Recheck && len(tx) > 8 {
 			return types.ResponseCheckTx{
 				Code: code.CodeTypeEncodingError,
 				Log:  fmt.Sprintf("Max tx size is 8 bytes, got %d", len(tx))}
 		}
-		tx8 := make([]byte, 8)
-		copy(tx8[len(tx8)-len(tx):], tx)
-		txValue := binary.BigEndian.Uint64(tx8)
+		txValue := txNonce(tx)
 		if txValue < uint64(app.txCount) {
 			return types.ResponseCheckTx{
 				Code: code.CodeTypeBadNonce,
 				Log:  fmt.Sprintf("Invalid nonce. Expected >= %v, got %v", app.txCount, txValue)}
 		}
+		// all txs are from the same sender, the next one to include
+		// is the one with the nonce of the tx count
+		eviction := types.EvictionHint_Pinned
+		if txValue > uint64(app.txCount) {
+			eviction = types.EvictionHint_Evictable
+		}
+		return types.ResponseCheckTx{
+			Code:     code.CodeTypeOK,
+			Sender:   Sender,
+			Nonce:    txValue,
+			Eviction: eviction,
+		}
 	}
 	return types.ResponseCheckTx{Code: code.CodeTypeOK}
 }
@@ -92,13 +113,22 @@ func (app *CounterApplication) Commit() (resp types.ResponseCommit) {
 	return types.ResponseCommit{Data: hash}
 }
 
+// The "/hash" path returns the number of commits, "/tx" the number of txs
 func (app *CounterApplication) Query(reqQuery types.RequestQuery) types.ResponseQuery {
-	switch reqQuery.Path {
-	case "hash":
-		return types.ResponseQuery{Value: []byte(cmn.Fmt("%v", app.hashCount))}
-	case "tx":
-		return types.ResponseQuery{Value: []byte(cmn.Fmt("%v", app.txCount))}
-	default:
-		return types.ResponseQuery{Log: cmn.Fmt("Invalid query path. Expected hash or tx, got %v", reqQuery.Path)}
-	}
+	return app.queryMux.Query(reqQuery)
+}
+
+func (app *CounterApplication) queryHash(reqQuery types.RequestQuery, params map[string]string) types.ResponseQuery {
+	return types.ResponseQuery{Value: []byte(cmn.Fmt("%v", app.hashCount))}
+}
+
+func (app *CounterApplication) queryTx(reqQuery types.RequestQuery, params map[string]string) types.ResponseQuery {
+	return types.ResponseQuery{Value: []byte(cmn.Fmt("%v", app.txCount))}
+}
+
+// txNonce decodes the big endian nonce in the last 8 bytes of the tx,
+// left padded with zeros.
+func txNonce(tx []byte) uint64 {
+	padded := append(make([]byte, 8), tx...)
+	return binary.BigEndian.Uint64(padded[len(tx):])
 }
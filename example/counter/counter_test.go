@@ -0,0 +1,71 @@
+package counter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/abci/example/code"
+	"github.com/tendermint/abci/types"
+)
+
+func TestCheckTxRecheck(t *testing.T) {
+	app := NewCounterApplication(true)
+
+	res := app.CheckTx(types.RequestCheckTx{Tx: []byte{0x00}})
+	require.Equal(t, code.CodeTypeOK, res.Code, res.Log)
+	require.Equal(t, types.EvictionHint_Pinned, res.Eviction)
+	res = app.CheckTx(types.RequestCheckTx{Tx: make([]byte, 9)})
+	require.Equal(t, code.CodeTypeEncodingError, res.Code, res.Log)
+
+	// once the nonce is used by a committed tx, the recheck fails
+	require.Equal(t, code.CodeTypeOK, app.DeliverTx([]byte{0x00}).Code)
+	app.Commit()
+	res = app.CheckTx(types.RequestCheckTx{Tx: []byte{0x00}, Type: types.CheckTxType_Recheck})
+	require.Equal(t, code.CodeTypeBadNonce, res.Code, res.Log)
+	res = app.CheckTx(types.RequestCheckTx{Tx: []byte{0x01}, Type: types.CheckTxType_Recheck})
+	require.Equal(t, code.CodeTypeOK, res.Code, res.Log)
+
+	// the size check is skipped on recheck, only the nonce is checked
+	tx := []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}
+	res = app.CheckTx(types.RequestCheckTx{Tx: tx})
+	require.Equal(t, code.CodeTypeEncodingError, res.Code, res.Log)
+	res = app.CheckTx(types.RequestCheckTx{Tx: tx, Type: types.CheckTxType_Recheck})
+	require.Equal(t, code.CodeTypeOK, res.Code, res.Log)
+	require.Equal(t, uint64(1), res.Nonce)
+}
+
+func TestCheckTxSenderAndNonce(t *testing.T) {
+	app := NewCounterApplication(true)
+
+	res := app.CheckTx(types.RequestCheckTx{Tx: []byte{0x00, 0x05}})
+	require.Equal(t, code.CodeTypeOK, res.Code, res.Log)
+	require.Equal(t, Sender, res.Sender)
+	require.Equal(t, uint64(5), res.Nonce)
+	// the txs before it must be included first
+	require.Equal(t, types.EvictionHint_Evictable, res.Eviction)
+
+	// nothing to order by when txs aren't serial
+	app = NewCounterApplication(false)
+	res = app.CheckTx(types.RequestCheckTx{Tx: []byte{0x05}})
+	require.Equal(t, code.CodeTypeOK, res.Code, res.Log)
+	require.Equal(t, types.ResponseCheckTx{Code: code.CodeTypeOK}, res)
+}
+
+func TestQuery(t *testing.T) {
+	app := NewCounterApplication(false)
+	app.DeliverTx([]byte{0x00})
+	app.Commit()
+
+	res := app.Query(types.RequestQuery{Path: "hash"})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "1", string(res.Value))
+	res = app.Query(types.RequestQuery{Path: "/tx"})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "1", string(res.Value))
+
+	res = app.Query(types.RequestQuery{Path: "/foo"})
+	require.Equal(t, types.CodeTypeUnknownPath, res.Code)
+	res = app.Query(types.RequestQuery{Path: types.QueryPathsPath})
+	require.Equal(t, `["/hash","/tx"]`, string(res.Value))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"golang.org/x/net/context"
 
@@ -36,6 +37,25 @@ func TestGRPC(t *testing.T) {
 	testGRPCSync(t, types.NewGRPCApplication(types.NewBaseApplication()))
 }
 
+func TestGRPCHealth(t *testing.T) {
+	fmt.Println("### Testing GRPC health")
+	testGRPCHealth(t, types.NewGRPCApplication(types.NewBaseApplication()))
+}
+
+func TestBatch(t *testing.T) {
+	fmt.Println("### Testing batches")
+	for _, transport := range []string{"socket", "grpc"} {
+		testBatch(t, transport, kvstore.NewKVStoreApplication())
+	}
+}
+
+func TestProposal(t *testing.T) {
+	fmt.Println("### Testing proposals")
+	for _, transport := range []string{"socket", "grpc"} {
+		testProposal(t, transport, reorderApp{types.NewBaseApplication()})
+	}
+}
+
 func testStream(t *testing.T, app types.Application) {
 	numDeliverTxs := 200000
 
@@ -152,3 +172,180 @@ func testGRPCSync(t *testing.T, app *types.GRPCApplication) {
 
 	}
 }
+
+func testGRPCHealth(t *testing.T, app *types.GRPCApplication) {
+	// Start the listener
+	server := abciserver.NewGRPCServer("unix://test-health.sock", app)
+	server.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	if err := server.Start(); err != nil {
+		t.Fatalf("Error starting GRPC server: %v", err.Error())
+	}
+	defer server.Stop()
+
+	// Connect to the socket
+	conn, err := grpc.Dial("unix://test-health.sock", grpc.WithInsecure(), grpc.WithDialer(dialerFunc))
+	if err != nil {
+		t.Fatalf("Error dialing GRPC server: %v", err.Error())
+	}
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+	req := &healthpb.HealthCheckRequest{Service: types.ABCIApplicationServiceName}
+
+	// The app answers Info right away, so the service should become ready shortly
+	var status healthpb.HealthCheckResponse_ServingStatus
+	for i := 0; i < 50; i++ {
+		res, err := client.Check(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Error in GRPC health check: %v", err.Error())
+		}
+		status = res.Status
+		if status == healthpb.HealthCheckResponse_SERVING {
+			break
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	if status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Expected service to be SERVING, got %v", status)
+	}
+}
+
+//-------------------------
+// test batches
+
+func testBatch(t *testing.T, transport string, app types.Application) {
+	socket := fmt.Sprintf("unix://test-batch-%s.sock", transport)
+
+	// Start the listener
+	server, err := abciserver.NewServer(socket, transport, app)
+	if err != nil {
+		t.Fatalf("Error creating %s server: %v", transport, err.Error())
+	}
+	server.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	if err := server.Start(); err != nil {
+		t.Fatalf("Error starting %s server: %v", transport, err.Error())
+	}
+	defer server.Stop()
+
+	// Connect to the socket
+	client, err := abcicli.NewClient(socket, transport, true)
+	if err != nil {
+		t.Fatalf("Error creating %s client: %v", transport, err.Error())
+	}
+	client.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	if err := client.Start(); err != nil {
+		t.Fatalf("Error starting %s client: %v", transport, err.Error())
+	}
+	defer client.Stop()
+
+	txs := [][]byte{[]byte("a=1"), []byte("b=2"), []byte("c=3")}
+	checkRes, err := client.CheckTxBatchSync(types.RequestCheckTxBatch{Txs: txs})
+	if err != nil {
+		t.Fatalf("Error in %s CheckTxBatch: %v", transport, err.Error())
+	}
+	if len(checkRes.Responses) != len(txs) {
+		t.Fatalf("Expected %d CheckTx responses, got %d", len(txs), len(checkRes.Responses))
+	}
+
+	deliverRes, err := client.DeliverTxBatchSync(types.RequestDeliverTxBatch{Txs: txs})
+	if err != nil {
+		t.Fatalf("Error in %s DeliverTxBatch: %v", transport, err.Error())
+	}
+	if len(deliverRes.Responses) != len(txs) {
+		t.Fatalf("Expected %d DeliverTx responses, got %d", len(txs), len(deliverRes.Responses))
+	}
+	for _, res := range deliverRes.Responses {
+		if res.Code != code.CodeTypeOK {
+			t.Error("DeliverTx failed with ret_code", res.Code)
+		}
+	}
+
+	// the txs were delivered in order
+	queryRes, err := client.QuerySync(types.RequestQuery{Path: "/store", Data: []byte("b")})
+	if err != nil {
+		t.Fatalf("Error in %s Query: %v", transport, err.Error())
+	}
+	if string(queryRes.Value) != "2" {
+		t.Errorf("Expected value 2 for key b, got %q", queryRes.Value)
+	}
+}
+
+//-------------------------
+// test proposals
+
+// reorderApp proposes the txs in reverse order,
+// and rejects the blocks with an empty tx.
+type reorderApp struct {
+	*types.BaseApplication
+}
+
+func (reorderApp) PrepareProposal(req types.RequestPrepareProposal) types.ResponsePrepareProposal {
+	txs := make([][]byte, len(req.Txs))
+	for i, tx := range req.Txs {
+		txs[len(txs)-1-i] = tx
+	}
+	return types.ResponsePrepareProposal{Txs: txs}
+}
+
+func (reorderApp) ProcessProposal(req types.RequestProcessProposal) types.ResponseProcessProposal {
+	for _, tx := range req.Txs {
+		if len(tx) == 0 {
+			return types.ResponseProcessProposal{Status: types.ResponseProcessProposal_Reject}
+		}
+	}
+	return types.ResponseProcessProposal{Status: types.ResponseProcessProposal_Accept}
+}
+
+func testProposal(t *testing.T, transport string, app types.Application) {
+	socket := fmt.Sprintf("unix://test-proposal-%s.sock", transport)
+
+	// Start the listener
+	server, err := abciserver.NewServer(socket, transport, app)
+	if err != nil {
+		t.Fatalf("Error creating %s server: %v", transport, err.Error())
+	}
+	server.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	if err := server.Start(); err != nil {
+		t.Fatalf("Error starting %s server: %v", transport, err.Error())
+	}
+	defer server.Stop()
+
+	// Connect to the socket
+	client, err := abcicli.NewClient(socket, transport, true)
+	if err != nil {
+		t.Fatalf("Error creating %s client: %v", transport, err.Error())
+	}
+	client.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	if err := client.Start(); err != nil {
+		t.Fatalf("Error starting %s client: %v", transport, err.Error())
+	}
+	defer client.Stop()
+
+	txs := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	prepareRes, err := client.PrepareProposalSync(types.RequestPrepareProposal{
+		MaxTxBytes: 1024,
+		Txs:        txs,
+		Header:     types.Header{Height: 1},
+	})
+	if err != nil {
+		t.Fatalf("Error in %s PrepareProposal: %v", transport, err.Error())
+	}
+	if !reflect.DeepEqual(prepareRes.Txs, [][]byte{[]byte("c"), []byte("b"), []byte("a")}) {
+		t.Errorf("Expected the txs in reverse order, got %q", prepareRes.Txs)
+	}
+
+	processRes, err := client.ProcessProposalSync(types.RequestProcessProposal{Txs: prepareRes.Txs})
+	if err != nil {
+		t.Fatalf("Error in %s ProcessProposal: %v", transport, err.Error())
+	}
+	if processRes.Status != types.ResponseProcessProposal_Accept {
+		t.Errorf("Expected the proposal to be accepted, got %v", processRes.Status)
+	}
+	processRes, err = client.ProcessProposalSync(types.RequestProcessProposal{Txs: [][]byte{[]byte("a"), {}}})
+	if err != nil {
+		t.Fatalf("Error in %s ProcessProposal: %v", transport, err.Error())
+	}
+	if processRes.Status != types.ResponseProcessProposal_Reject {
+		t.Errorf("Expected the proposal to be rejected, got %v", processRes.Status)
+	}
+}
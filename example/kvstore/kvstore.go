@@ -7,11 +7,16 @@ import (
 	"fmt"
 
 	"github.com/tendermint/abci/example/code"
+	"github.com/tendermint/abci/gas"
 	"github.com/tendermint/abci/types"
 	cmn "github.com/tendermint/tmlibs/common"
 	dbm "github.com/tendermint/tmlibs/db"
 )
 
+// ProtocolVersion is the version of the state machine of the kvstore,
+// reported in the AppVersion of Info.
+const ProtocolVersion uint64 = 0x1
+
 var (
 	stateKey        = []byte("stateKey")
 	kvPairPrefixKey = []byte("kvPairKey:")
@@ -56,39 +61,75 @@ var _ types.Application = (*KVStoreApplication)(nil)
 type KVStoreApplication struct {
 	types.BaseApplication
 
-	state State
+	state    State
+	queryMux *types.QueryMux
+	gasMeter *gas.BlockMeter
 }
 
 func NewKVStoreApplication() *KVStoreApplication {
-	state := loadState(dbm.NewMemDB())
-	return &KVStoreApplication{state: state}
+	return newKVStoreApplication(loadState(dbm.NewMemDB()))
+}
+
+func newKVStoreApplication(state State) *KVStoreApplication {
+	app := &KVStoreApplication{
+		state:    state,
+		gasMeter: gas.NewBlockMeter(gas.DefaultConfig()),
+	}
+	app.queryMux = types.NewQueryMux().
+		SetHeight(func() int64 { return app.state.Height }).
+		HandleProvable("/", app.queryStore).
+		HandleProvable("/store", app.queryStore).
+		HandleProvable("/store/{key}", app.queryStore)
+	return app
 }
 
 func (app *KVStoreApplication) Info(req types.RequestInfo) (resInfo types.ResponseInfo) {
-	return types.ResponseInfo{Data: fmt.Sprintf("{\"size\":%v}", app.state.Size)}
+	return types.ResponseInfo{
+		Data:       fmt.Sprintf("{\"size\":%v}", app.state.Size),
+		AppVersion: ProtocolVersion,
+	}
+}
+
+// BeginBlock resets the gas of the block
+func (app *KVStoreApplication) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
+	app.gasMeter.Reset()
+	return types.ResponseBeginBlock{}
 }
 
-// tx is either "key=value" or just arbitrary bytes
+// tx is either "key=value" or just arbitrary bytes.
+// The gas used is the cost of the write.
 func (app *KVStoreApplication) DeliverTx(tx []byte) types.ResponseDeliverTx {
-	var key, value []byte
+	return app.gasMeter.DeliverTx(app.state.db, func(store *gas.Store) types.ResponseDeliverTx {
+		key := execTx(store, tx)
+		app.state.Size += 1
+
+		tags := []cmn.KVPair{
+			{[]byte("app.creator"), []byte("jae")},
+			{[]byte("app.key"), key},
+		}
+		return types.ResponseDeliverTx{Code: code.CodeTypeOK, Tags: tags}
+	})
+}
+
+// The gas wanted is the cost of the write
+func (app *KVStoreApplication) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	return app.gasMeter.CheckTx(app.state.db, func(store *gas.Store) types.ResponseCheckTx {
+		execTx(store, req.Tx)
+		return types.ResponseCheckTx{Code: code.CodeTypeOK}
+	})
+}
+
+// execTx writes the tx to the store, and returns its key
+func execTx(store *gas.Store, tx []byte) (key []byte) {
+	var value []byte
 	parts := bytes.Split(tx, []byte("="))
 	if len(parts) == 2 {
 		key, value = parts[0], parts[1]
 	} else {
 		key, value = tx, tx
 	}
-	app.state.db.Set(prefixKey(key), value)
-	app.state.Size += 1
-
-	tags := []cmn.KVPair{
-		{[]byte("app.creator"), []byte("jae")},
-		{[]byte("app.key"), key},
-	}
-	return types.ResponseDeliverTx{Code: code.CodeTypeOK, Tags: tags}
-}
-
-func (app *KVStoreApplication) CheckTx(tx []byte) types.ResponseCheckTx {
-	return types.ResponseCheckTx{Code: code.CodeTypeOK}
+	store.Set(prefixKey(key), value)
+	return key
 }
 
 func (app *KVStoreApplication) Commit() types.ResponseCommit {
@@ -101,26 +142,27 @@ func (app *KVStoreApplication) Commit() types.ResponseCommit {
 	return types.ResponseCommit{Data: appHash}
 }
 
-func (app *KVStoreApplication) Query(reqQuery types.RequestQuery) (resQuery types.ResponseQuery) {
+// The "/store/{key}" path queries the key,
+// "/store" and "/" the key in the query data
+func (app *KVStoreApplication) Query(reqQuery types.RequestQuery) types.ResponseQuery {
+	return app.queryMux.Query(reqQuery)
+}
+
+func (app *KVStoreApplication) queryStore(reqQuery types.RequestQuery, params map[string]string) (resQuery types.ResponseQuery) {
+	key := reqQuery.Data
+	if k, ok := params["key"]; ok {
+		key = []byte(k)
+	}
+	value := app.state.db.Get(prefixKey(key))
 	if reqQuery.Prove {
-		value := app.state.db.Get(prefixKey(reqQuery.Data))
 		resQuery.Index = -1 // TODO make Proof return index
-		resQuery.Key = reqQuery.Data
-		resQuery.Value = value
-		if value != nil {
-			resQuery.Log = "exists"
-		} else {
-			resQuery.Log = "does not exist"
-		}
-		return
+		resQuery.Key = key
+	}
+	resQuery.Value = value
+	if value != nil {
+		resQuery.Log = "exists"
 	} else {
-		value := app.state.db.Get(prefixKey(reqQuery.Data))
-		resQuery.Value = value
-		if value != nil {
-			resQuery.Log = "exists"
-		} else {
-			resQuery.Log = "does not exist"
-		}
-		return
+		resQuery.Log = "does not exist"
 	}
+	return
 }
@@ -2,17 +2,20 @@ package kvstore
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
 
 	cmn "github.com/tendermint/tmlibs/common"
 	"github.com/tendermint/tmlibs/log"
 
 	abcicli "github.com/tendermint/abci/client"
 	"github.com/tendermint/abci/example/code"
+	"github.com/tendermint/abci/gas"
 	abciserver "github.com/tendermint/abci/server"
 	"github.com/tendermint/abci/types"
 )
@@ -40,6 +43,13 @@ func testKVStore(t *testing.T, app types.Application, tx []byte, key, value stri
 	})
 	require.EqualValues(t, code.CodeTypeOK, resQuery.Code)
 	require.Equal(t, value, string(resQuery.Value))
+
+	// the key can be in the path
+	resQuery = app.Query(types.RequestQuery{
+		Path: "/store/" + key,
+	})
+	require.Equal(t, code.CodeTypeOK, resQuery.Code)
+	require.Equal(t, value, string(resQuery.Value))
 }
 
 func TestKVStoreKV(t *testing.T) {
@@ -83,6 +93,9 @@ func TestPersistentKVStoreInfo(t *testing.T) {
 	if resInfo.LastBlockHeight != height {
 		t.Fatalf("expected height of %d, got %d", height, resInfo.LastBlockHeight)
 	}
+	if resInfo.AppVersion != ProtocolVersion {
+		t.Fatalf("expected app version %d, got %d", ProtocolVersion, resInfo.AppVersion)
+	}
 
 	// make and apply block
 	height = int64(1)
@@ -308,3 +321,417 @@ func testClient(t *testing.T, app abcicli.Client, tx []byte, key, value string)
 	require.Equal(t, code.CodeTypeOK, resQuery.Code)
 	require.Equal(t, value, string(resQuery.Value))
 }
+
+func TestSnapshotRestore(t *testing.T) {
+	// small chunks, so snapshots have several
+	defer func(size int) { snapshotChunkSize = size }(snapshotChunkSize)
+	snapshotChunkSize = 64
+
+	dir, err := ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	kvstore := NewPersistentKVStoreApplication(dir)
+	kvstore.SetSnapshotInterval(2)
+	for height := 1; height <= 6; height++ {
+		for i := 0; i < 5; i++ {
+			kvstore.DeliverTx([]byte(cmn.Fmt("key%d-%d=value%d", height, i, i)))
+		}
+		kvstore.Commit()
+	}
+	resInfo := kvstore.Info(types.RequestInfo{})
+
+	// snapshots of heights 4 and 6 are kept
+	snapshots := kvstore.ListSnapshots(types.RequestListSnapshots{}).Snapshots
+	require.Equal(t, 2, len(snapshots))
+	require.Equal(t, uint64(4), snapshots[0].Height)
+	snapshot := snapshots[1]
+	require.Equal(t, uint64(6), snapshot.Height)
+	require.True(t, snapshot.Chunks > 1, "expected several chunks")
+
+	// restore over the protocol, from a socket app to a grpc app
+	client, server, err := makeSocketClientServer(kvstore, "kvstore-snapshot-socket")
+	require.Nil(t, err)
+	defer server.Stop()
+	defer client.Stop()
+	dir, err = ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	restored := NewPersistentKVStoreApplication(dir)
+	gclient, gserver, err := makeGRPCClientServer(restored, "kvstore-snapshot-grpc")
+	require.Nil(t, err)
+	defer gserver.Stop()
+	defer gclient.Stop()
+
+	resList, err := client.ListSnapshotsSync(types.RequestListSnapshots{})
+	require.Nil(t, err)
+	require.Equal(t, snapshots, resList.Snapshots)
+	resOffer, err := gclient.OfferSnapshotSync(types.RequestOfferSnapshot{
+		Snapshot: snapshot,
+		AppHash:  resInfo.LastBlockAppHash,
+	})
+	require.Nil(t, err)
+	require.Equal(t, types.ResponseOfferSnapshot_Accept, resOffer.Result)
+
+	// in reverse order, with a corrupted chunk first
+	for i := int(snapshot.Chunks) - 1; i >= 0; i-- {
+		resChunk, err := client.LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  uint32(i),
+		})
+		require.Nil(t, err)
+		require.NotEmpty(t, resChunk.Chunk)
+
+		if i == 0 {
+			resApply, err := gclient.ApplySnapshotChunkSync(types.RequestApplySnapshotChunk{
+				Index:  uint32(i),
+				Chunk:  append([]byte{0x01}, resChunk.Chunk...),
+				Sender: "bad",
+			})
+			require.Nil(t, err)
+			require.Equal(t, types.ResponseApplySnapshotChunk_Retry, resApply.Result)
+			require.Equal(t, []uint32{0}, resApply.RefetchChunks)
+			require.Equal(t, []string{"bad"}, resApply.RejectSenders)
+		}
+		resApply, err := gclient.ApplySnapshotChunkSync(types.RequestApplySnapshotChunk{
+			Index:  uint32(i),
+			Chunk:  resChunk.Chunk,
+			Sender: "good",
+		})
+		require.Nil(t, err)
+		require.Equal(t, types.ResponseApplySnapshotChunk_Accept, resApply.Result)
+	}
+
+	require.Equal(t, resInfo, restored.Info(types.RequestInfo{}))
+	resQuery := restored.Query(types.RequestQuery{Path: "/store", Data: []byte("key6-4")})
+	require.Equal(t, "value4", string(resQuery.Value))
+	// the restored app has no snapshots of its own yet
+	require.Empty(t, restored.ListSnapshots(types.RequestListSnapshots{}).Snapshots)
+
+	// a non-empty app can't restore a snapshot
+	resAbort := restored.OfferSnapshot(types.RequestOfferSnapshot{Snapshot: snapshot})
+	require.Equal(t, types.ResponseOfferSnapshot_Abort, resAbort.Result)
+}
+
+func TestSnapshotRestoreAborted(t *testing.T) {
+	defer func(size int) { snapshotChunkSize = size }(snapshotChunkSize)
+	snapshotChunkSize = 64
+
+	dir, err := ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	kvstore := NewPersistentKVStoreApplication(dir)
+	kvstore.SetSnapshotInterval(2)
+	var resInfo types.ResponseInfo
+	for height := 1; height <= 6; height++ {
+		for i := 0; i < 5; i++ {
+			kvstore.DeliverTx([]byte(cmn.Fmt("key%d-%d=value%d", height, i, i)))
+		}
+		kvstore.Commit()
+		if height == 4 {
+			resInfo = kvstore.Info(types.RequestInfo{})
+		}
+	}
+	snapshots := kvstore.ListSnapshots(types.RequestListSnapshots{}).Snapshots
+	require.Equal(t, 2, len(snapshots))
+	old, last := snapshots[0], snapshots[1]
+
+	dir, err = ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	restored := NewPersistentKVStoreApplication(dir)
+	offer := func(snapshot *types.Snapshot) {
+		res := restored.OfferSnapshot(types.RequestOfferSnapshot{Snapshot: snapshot, AppHash: resInfo.LastBlockAppHash})
+		require.Equal(t, types.ResponseOfferSnapshot_Accept, res.Result)
+	}
+	apply := func(snapshot *types.Snapshot, from uint32) {
+		for i := from; i < snapshot.Chunks; i++ {
+			chunk := kvstore.LoadSnapshotChunk(types.RequestLoadSnapshotChunk{
+				Height: snapshot.Height,
+				Format: snapshot.Format,
+				Chunk:  i,
+			}).Chunk
+			res := restored.ApplySnapshotChunk(types.RequestApplySnapshotChunk{Index: i, Chunk: chunk})
+			require.Equal(t, types.ResponseApplySnapshotChunk_Accept, res.Result)
+		}
+	}
+
+	// abandoned for another snapshot
+	offer(last)
+	apply(last, 1)
+	offer(old)
+	apply(old, 1)
+	// aborted for a chunk out of range
+	res := restored.ApplySnapshotChunk(types.RequestApplySnapshotChunk{Index: old.Chunks})
+	require.Equal(t, types.ResponseApplySnapshotChunk_Abort, res.Result)
+
+	offer(old)
+	apply(old, 0)
+	require.Equal(t, resInfo, restored.Info(types.RequestInfo{}))
+	resQuery := restored.Query(types.RequestQuery{Path: "/store", Data: []byte("key4-4")})
+	require.Equal(t, "value4", string(resQuery.Value))
+	// nothing left from the aborted restores
+	resQuery = restored.Query(types.RequestQuery{Path: "/store", Data: []byte("key6-4")})
+	require.Empty(t, resQuery.Value)
+}
+
+func TestSnapshotRestoreAppHashMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	kvstore := NewPersistentKVStoreApplication(dir)
+	kvstore.SetSnapshotInterval(1)
+	kvstore.DeliverTx([]byte("key=value"))
+	kvstore.Commit()
+	snapshot := kvstore.ListSnapshots(types.RequestListSnapshots{}).Snapshots[0]
+
+	dir, err = ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	restored := NewPersistentKVStoreApplication(dir)
+	resOffer := restored.OfferSnapshot(types.RequestOfferSnapshot{
+		Snapshot: snapshot,
+		AppHash:  []byte("wrong"),
+	})
+	require.Equal(t, types.ResponseOfferSnapshot_Accept, resOffer.Result)
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk := kvstore.LoadSnapshotChunk(types.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  i,
+		}).Chunk
+		res := restored.ApplySnapshotChunk(types.RequestApplySnapshotChunk{Index: i, Chunk: chunk})
+		if i == snapshot.Chunks-1 {
+			require.Equal(t, types.ResponseApplySnapshotChunk_RejectSnapshot, res.Result)
+		}
+	}
+
+	// the restored state was dropped
+	require.Equal(t, int64(0), restored.Info(types.RequestInfo{}).LastBlockHeight)
+	resQuery := restored.Query(types.RequestQuery{Path: "/store", Data: []byte("key")})
+	require.Empty(t, resQuery.Value)
+}
+
+func TestSlashing(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	kvstore := NewPersistentKVStoreApplication(dir)
+
+	v1 := types.Ed25519Validator([]byte("pubkey1"), 10)
+	v2 := types.Ed25519Validator([]byte("pubkey2"), 10)
+	v3 := types.Ed25519Validator([]byte("pubkey3"), 10)
+	kvstore.InitChain(types.RequestInitChain{
+		Validators: []types.Validator{v1, v2, v3},
+	})
+	evidence := func(typ string, v types.Validator, height int64) types.Evidence {
+		return types.Evidence{
+			Type:             typ,
+			Validator:        v,
+			Height:           height,
+			Time:             height * 10,
+			TotalVotingPower: 30,
+		}
+	}
+	beginBlock := func(height int64, evidence ...types.Evidence) []types.Validator {
+		header := types.Header{Height: height, Time: height * 10}
+		kvstore.BeginBlock(types.RequestBeginBlock{Header: header, ByzantineValidators: evidence})
+		return kvstore.EndBlock(types.RequestEndBlock{Height: height}).ValidatorUpdates
+	}
+
+	// a duplicate vote halves the power, a lunatic header removes the validator
+	updates := beginBlock(5,
+		evidence(types.EvidenceTypeDuplicateVote, v1, 4),
+		evidence(types.EvidenceTypeLunaticHeader, v2, 3),
+		evidence("unknown/type", v3, 4),
+	)
+	v1.Power, v2.Power = 5, 0
+	valsEqual(t, []types.Validator{v1, v2}, updates)
+	valsEqual(t, []types.Validator{v1, v3}, kvstore.Validators())
+
+	// evidence from the future, too old or about removed validators is ignored
+	updates = beginBlock(maxEvidenceAgeBlocks+10,
+		evidence(types.EvidenceTypeDuplicateVote, v1, maxEvidenceAgeBlocks+11),
+		evidence(types.EvidenceTypeDuplicateVote, v3, 5),
+		evidence(types.EvidenceTypeDuplicateVote, v2, maxEvidenceAgeBlocks),
+	)
+	require.Empty(t, updates)
+
+	// invalid or inconsistent evidence is ignored, but not the valid evidence
+	// in the same block; slashing rounds up
+	malformed := evidence(types.EvidenceTypeDuplicateVote, v3, 6)
+	malformed.Validator.PubKey.Data = nil
+	inconsistent := evidence(types.EvidenceTypeDuplicateVote, v3, 6)
+	inconsistent.TotalVotingPower = 40
+	updates = beginBlock(7, malformed, evidence(types.EvidenceTypeDuplicateVote, v1, 6), inconsistent)
+	v1.Power = 2
+	valsEqual(t, []types.Validator{v1}, updates)
+	valsEqual(t, []types.Validator{v1, v3}, kvstore.Validators())
+
+	// a validator with power 1 still loses it
+	updates = beginBlock(8, evidence(types.EvidenceTypeDuplicateVote, v1, 7))
+	v1.Power = 1
+	valsEqual(t, []types.Validator{v1}, updates)
+	updates = beginBlock(9, evidence(types.EvidenceTypeDuplicateVote, v1, 8))
+	v1.Power = 0
+	valsEqual(t, []types.Validator{v1}, updates)
+}
+
+func TestLiveness(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	kvstore := NewPersistentKVStoreApplication(dir)
+	kvstore.SetLivenessParams(4, 2)
+
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	require.Nil(t, err)
+	v1 := types.Ed25519Validator(pubkey, 10)
+	v2 := types.Ed25519Validator([]byte("pubkey2"), 10)
+	kvstore.InitChain(types.RequestInitChain{
+		Validators: []types.Validator{v1, v2},
+	})
+	height := int64(0)
+	applyBlock := func(signed1, signed2 bool, txs ...[]byte) []types.Validator {
+		height++
+		kvstore.BeginBlock(types.RequestBeginBlock{
+			Header: types.Header{Height: height},
+			Validators: []types.SigningValidator{
+				{Validator: v1, SignedLastBlock: signed1},
+				{Validator: v2, SignedLastBlock: signed2},
+			},
+		})
+		for _, tx := range txs {
+			kvstore.DeliverTx(tx)
+		}
+		res := kvstore.EndBlock(types.RequestEndBlock{Height: height})
+		kvstore.Commit()
+		return res.ValidatorUpdates
+	}
+
+	// v2 misses 2 blocks out of 4, v1 ends up missing 3 and is jailed
+	require.Empty(t, applyBlock(false, false))
+	require.Empty(t, applyBlock(true, true))
+	require.Empty(t, applyBlock(true, false))
+	require.Empty(t, applyBlock(false, true))
+	require.Empty(t, applyBlock(false, false)) // the first block left the window
+	jailed := v1
+	jailed.Power = 0
+	require.Equal(t, []types.Validator{jailed}, applyBlock(false, true))
+	valsEqual(t, []types.Validator{v2}, kvstore.Validators())
+
+	resQuery := kvstore.Query(types.RequestQuery{Path: "/validators"})
+	var statuses []ValidatorStatus
+	require.Nil(t, json.Unmarshal(resQuery.Value, &statuses))
+	require.Equal(t, 2, len(statuses))
+	for _, status := range statuses {
+		if bytes.Equal(status.PubKey.Data, v1.PubKey.Data) {
+			require.Equal(t, ValidatorStatus{PubKey: v1.PubKey, Window: 4, Jailed: true, JailCount: 1}, status)
+		} else {
+			require.Equal(t, ValidatorStatus{PubKey: v2.PubKey, Power: 10, MissedBlocks: 2, Window: 4}, status)
+		}
+	}
+
+	// the unjail tx must be signed by the validator
+	res := kvstore.DeliverTx(MakeUnjailTx(v1.PubKey, ed25519.Sign(privkey, []byte("foo"))))
+	require.Equal(t, code.CodeTypeUnauthorized, res.Code, res.Log)
+	res = kvstore.DeliverTx(MakeUnjailTx(v2.PubKey, ed25519.Sign(privkey, []byte("foo"))))
+	require.Equal(t, code.CodeTypeEncodingError, res.Code, res.Log)
+
+	unjailTx := MakeUnjailTx(v1.PubKey, ed25519.Sign(privkey, UnjailSignBytes(v1.PubKey, 1)))
+	require.Equal(t, []types.Validator{v1}, applyBlock(true, true, unjailTx))
+	valsEqual(t, []types.Validator{v1, v2}, kvstore.Validators())
+	// and can't be replayed
+	res = kvstore.DeliverTx(unjailTx)
+	require.Equal(t, code.CodeTypeUnauthorized, res.Code, res.Log)
+
+	// the window starts over after unjailing
+	require.Empty(t, applyBlock(false, true))
+	require.Empty(t, applyBlock(false, true))
+	require.Equal(t, []types.Validator{jailed}, applyBlock(false, true))
+}
+
+func TestConsensusParamsUpdates(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	kvstore := NewPersistentKVStoreApplication(dir)
+
+	// invalid genesis params are ignored
+	kvstore.InitChain(types.RequestInitChain{
+		ConsensusParams: &types.ConsensusParams{TxSize: &types.TxSize{MaxBytes: -1}},
+	})
+	require.Equal(t, types.DefaultConsensusParams(), kvstore.ConsensusParams())
+
+	kvstore.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1}})
+	r := kvstore.DeliverTx(MakeConsensusParamsTx(&types.ConsensusParams{
+		BlockSize: &types.BlockSize{MaxGas: 1000},
+	}))
+	require.Equal(t, code.CodeTypeOK, r.Code, r.Log)
+	r = kvstore.DeliverTx(MakeConsensusParamsTx(&types.ConsensusParams{
+		TxSize: &types.TxSize{MaxGas: 2000},
+	}))
+	require.Equal(t, code.CodeTypeBadParams, r.Code, "tx gas above block gas")
+	r = kvstore.DeliverTx(MakeConsensusParamsTx(&types.ConsensusParams{
+		TxSize: &types.TxSize{MaxGas: 100},
+	}))
+	require.Equal(t, code.CodeTypeOK, r.Code, r.Log)
+	r = kvstore.DeliverTx([]byte(ConsensusParamsPrefix + "{"))
+	require.Equal(t, code.CodeTypeEncodingError, r.Code)
+
+	res := kvstore.EndBlock(types.RequestEndBlock{Height: 1})
+	kvstore.Commit()
+	require.Equal(t, &types.ConsensusParams{
+		BlockSize: &types.BlockSize{MaxGas: 1000},
+		TxSize:    &types.TxSize{MaxGas: 100},
+	}, res.ConsensusParamUpdates)
+
+	params := kvstore.ConsensusParams()
+	require.EqualValues(t, 1000, params.BlockSize.MaxGas)
+	require.EqualValues(t, 100, params.TxSize.MaxGas)
+	require.Nil(t, params.Validate())
+
+	resQuery := kvstore.Query(types.RequestQuery{Path: "/consensus_params"})
+	var queried types.ConsensusParams
+	require.Nil(t, json.Unmarshal(resQuery.Value, &queried))
+	require.Equal(t, params, &queried)
+
+	// no updates in the next block
+	kvstore.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2}})
+	res = kvstore.EndBlock(types.RequestEndBlock{Height: 2})
+	require.Nil(t, res.ConsensusParamUpdates)
+}
+
+func TestGas(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	require.Nil(t, err)
+	kvstore := NewPersistentKVStoreApplication(dir)
+	kvstore.InitChain(types.RequestInitChain{})
+
+	// the cost of writing "kvPairKey:abc" = "def"
+	config := gas.DefaultConfig()
+	cost := config.WriteCost + config.WriteCostPerByte*int64(len(prefixKey([]byte("abc")))+3)
+
+	resCheck := kvstore.CheckTx(types.RequestCheckTx{Tx: []byte("abc=def")})
+	require.Equal(t, code.CodeTypeOK, resCheck.Code, resCheck.Log)
+	require.Equal(t, cost, resCheck.GasWanted)
+
+	kvstore.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1}})
+	res := kvstore.DeliverTx([]byte("abc=def"))
+	require.Equal(t, code.CodeTypeOK, res.Code, res.Log)
+	require.Equal(t, cost, res.GasUsed)
+
+	// limit the block to two such txs, and a tx to less
+	res = kvstore.DeliverTx(MakeConsensusParamsTx(&types.ConsensusParams{
+		BlockSize: &types.BlockSize{MaxGas: 2 * cost},
+		TxSize:    &types.TxSize{MaxGas: cost + 5},
+	}))
+	require.Equal(t, code.CodeTypeOK, res.Code, res.Log)
+	kvstore.EndBlock(types.RequestEndBlock{Height: 1})
+	kvstore.Commit()
+
+	resCheck = kvstore.CheckTx(types.RequestCheckTx{Tx: []byte("abc=defghi")})
+	require.Equal(t, types.CodeTypeOutOfGas, resCheck.Code)
+
+	kvstore.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2}})
+	require.True(t, kvstore.DeliverTx([]byte("abc=123")).IsOK())
+	require.True(t, kvstore.DeliverTx([]byte("abc=456")).IsOK())
+	res = kvstore.DeliverTx([]byte("abc=789"))
+	require.Equal(t, types.CodeTypeOutOfGas, res.Code, "block is full")
+	kvstore.EndBlock(types.RequestEndBlock{Height: 2})
+	kvstore.Commit()
+
+	resQuery := kvstore.Query(types.RequestQuery{Path: "/store/abc"})
+	require.Equal(t, "456", string(resQuery.Value))
+}
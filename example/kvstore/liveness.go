@@ -0,0 +1,239 @@
+package kvstore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/tendermint/abci/example/code"
+	"github.com/tendermint/abci/types"
+	cmn "github.com/tendermint/tmlibs/common"
+	dbm "github.com/tendermint/tmlibs/db"
+)
+
+const (
+	UnjailPrefix string = "unjail:"
+
+	// a validator missing more than DefaultMaxMissedBlocks of the last
+	// DefaultLivenessWindow blocks is jailed
+	DefaultLivenessWindow  = 100
+	DefaultMaxMissedBlocks = 50
+
+	livenessPrefix = "liveness:"
+)
+
+// ValidatorLiveness is the persisted liveness of a validator.
+type ValidatorLiveness struct {
+	PubKey      types.PubKey `json:"pub_key"`
+	Missed      []bool       `json:"missed"`       // ring buffer of the blocks of the window
+	Blocks      int64        `json:"blocks"`       // blocks seen since it was last (un)jailed
+	MissedCount int64        `json:"missed_count"` // missed blocks in the window
+	Jailed      bool         `json:"jailed"`
+	JailCount   int64        `json:"jail_count"` // times jailed, signed in unjail txs against replays
+
+	// the validator before it was jailed, restored by an unjail tx
+	JailedValidator types.Validator `json:"jailed_validator"`
+}
+
+// ValidatorStatus is a validator with its liveness, for the /validators query.
+type ValidatorStatus struct {
+	PubKey       types.PubKey `json:"pub_key"`
+	Power        int64        `json:"power"`
+	MissedBlocks int64        `json:"missed_blocks"`
+	Window       int64        `json:"window"`
+	Jailed       bool         `json:"jailed"`
+	JailCount    int64        `json:"jail_count"`
+}
+
+func livenessKey(pubkey types.PubKey) []byte {
+	return []byte(livenessPrefix + string(pubkey.Data))
+}
+
+// SetLivenessParams makes the app jail the validators that miss more than
+// maxMissed of the last window blocks. A zero window disables jailing.
+func (app *PersistentKVStoreApplication) SetLivenessParams(window, maxMissed int64) {
+	app.livenessWindow = window
+	app.maxMissedBlocks = maxMissed
+}
+
+func (app *PersistentKVStoreApplication) loadLiveness(pubkey types.PubKey) ValidatorLiveness {
+	value := app.app.state.db.Get(livenessKey(pubkey))
+	liveness := ValidatorLiveness{PubKey: pubkey}
+	if len(value) != 0 {
+		if err := json.Unmarshal(value, &liveness); err != nil {
+			panic(err)
+		}
+	}
+	if int64(len(liveness.Missed)) != app.livenessWindow {
+		// new validator, or new window
+		liveness.resetWindow(app.livenessWindow)
+	}
+	return liveness
+}
+
+func (app *PersistentKVStoreApplication) saveLiveness(liveness ValidatorLiveness) {
+	value, err := json.Marshal(liveness)
+	if err != nil {
+		panic(err)
+	}
+	app.app.state.db.Set(livenessKey(liveness.PubKey), value)
+}
+
+func (liveness *ValidatorLiveness) resetWindow(window int64) {
+	liveness.Missed = make([]bool, window)
+	liveness.Blocks = 0
+	liveness.MissedCount = 0
+}
+
+// trackLiveness records which validators signed the last block,
+// and jails those that missed too many blocks.
+// The jailed validators are added to the ValUpdates, with power 0.
+func (app *PersistentKVStoreApplication) trackLiveness(validators []types.SigningValidator) {
+	if app.livenessWindow <= 0 {
+		return
+	}
+	for _, sv := range validators {
+		liveness := app.loadLiveness(sv.Validator.PubKey)
+		if liveness.Jailed {
+			continue
+		}
+
+		i := liveness.Blocks % app.livenessWindow
+		if liveness.Missed[i] {
+			liveness.MissedCount--
+		}
+		liveness.Missed[i] = !sv.SignedLastBlock
+		if liveness.Missed[i] {
+			liveness.MissedCount++
+		}
+		liveness.Blocks++
+
+		if liveness.MissedCount > app.maxMissedBlocks {
+			if validator, ok := app.validator(liveness.PubKey); ok {
+				app.jail(&liveness, validator)
+			}
+		}
+		app.saveLiveness(liveness)
+	}
+}
+
+func (app *PersistentKVStoreApplication) jail(liveness *ValidatorLiveness, validator types.Validator) {
+	jailed := validator
+	jailed.Power = 0
+	if r := app.updateValidator(jailed); r.IsErr() {
+		app.logger.Error("Error jailing validator", "r", r)
+		return
+	}
+	liveness.Jailed = true
+	liveness.JailCount++
+	liveness.JailedValidator = validator
+	liveness.resetWindow(app.livenessWindow)
+	app.logger.Info("Jailed validator", "pubkey", validator.PubKey.Data, "missed", liveness.MissedCount)
+}
+
+// UnjailSignBytes returns the bytes a jailed validator signs to be unjailed,
+// for the jailCount-th time.
+func UnjailSignBytes(pubkey types.PubKey, jailCount int64) []byte {
+	return []byte(cmn.Fmt("%s%X/%d", UnjailPrefix, pubkey.Data, jailCount))
+}
+
+// MakeUnjailTx returns the tx to unjail a validator,
+// with its signature of the UnjailSignBytes.
+func MakeUnjailTx(pubkey types.PubKey, signature []byte) []byte {
+	return []byte(cmn.Fmt("%s%X/%X", UnjailPrefix, pubkey.Data, signature))
+}
+
+func isUnjailTx(tx []byte) bool {
+	return strings.HasPrefix(string(tx), UnjailPrefix)
+}
+
+// format is "unjail:pubkey/signature"
+// pubkey is raw 32-byte ed25519 key
+func (app *PersistentKVStoreApplication) execUnjailTx(tx []byte) types.ResponseDeliverTx {
+	tx = tx[len(UnjailPrefix):]
+
+	pubKeyAndSig := strings.Split(string(tx), "/")
+	if len(pubKeyAndSig) != 2 {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeEncodingError,
+			Log:  fmt.Sprintf("Expected 'pubkey/signature'. Got %v", pubKeyAndSig)}
+	}
+	pubkeyS, sigS := pubKeyAndSig[0], pubKeyAndSig[1]
+
+	pubkey, err := hex.DecodeString(pubkeyS)
+	if err != nil || len(pubkey) != ed25519.PublicKeySize {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeEncodingError,
+			Log:  fmt.Sprintf("Pubkey (%s) is not a hex ed25519 pubkey", pubkeyS)}
+	}
+	sig, err := hex.DecodeString(sigS)
+	if err != nil {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeEncodingError,
+			Log:  fmt.Sprintf("Signature (%s) is invalid hex", sigS)}
+	}
+
+	liveness := app.loadLiveness(types.PubKey{Type: types.PubKeyEd25519, Data: pubkey})
+	if !liveness.Jailed {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeUnauthorized,
+			Log:  fmt.Sprintf("Validator %X is not jailed", pubkey)}
+	}
+	if !ed25519.Verify(pubkey, UnjailSignBytes(liveness.PubKey, liveness.JailCount), sig) {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeUnauthorized,
+			Log:  fmt.Sprintf("Invalid signature for validator %X", pubkey)}
+	}
+
+	res := app.updateValidator(liveness.JailedValidator)
+	if res.IsErr() {
+		return res
+	}
+	liveness.Jailed = false
+	liveness.JailedValidator = types.Validator{}
+	app.saveLiveness(liveness)
+	return res
+}
+
+// ValidatorStatuses returns the validators and the jailed validators,
+// with their liveness, sorted by pubkey.
+func (app *PersistentKVStoreApplication) ValidatorStatuses() []ValidatorStatus {
+	statuses := make(map[string]*ValidatorStatus)
+	for _, v := range app.Validators() {
+		statuses[string(v.PubKey.Data)] = &ValidatorStatus{
+			PubKey: v.PubKey,
+			Power:  v.Power,
+		}
+	}
+	itr := dbm.IteratePrefix(app.app.state.db, []byte(livenessPrefix))
+	for ; itr.Valid(); itr.Next() {
+		var liveness ValidatorLiveness
+		if err := json.Unmarshal(itr.Value(), &liveness); err != nil {
+			panic(err)
+		}
+		status, ok := statuses[string(liveness.PubKey.Data)]
+		if !ok {
+			status = &ValidatorStatus{PubKey: liveness.PubKey}
+			statuses[string(liveness.PubKey.Data)] = status
+		}
+		status.MissedBlocks = liveness.MissedCount
+		status.Window = int64(len(liveness.Missed))
+		status.Jailed = liveness.Jailed
+		status.JailCount = liveness.JailCount
+	}
+	itr.Close()
+
+	list := make([]ValidatorStatus, 0, len(statuses))
+	for _, status := range statuses {
+		list = append(list, *status)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i].PubKey.Data, list[j].PubKey.Data) < 0
+	})
+	return list
+}
@@ -0,0 +1,78 @@
+package kvstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/abci/example/code"
+	"github.com/tendermint/abci/types"
+)
+
+const (
+	ConsensusParamsPrefix string = "params:"
+
+	consensusParamsKey = "consensus_params"
+)
+
+// ConsensusParams returns the current consensus params,
+// the defaults if none were set.
+func (app *PersistentKVStoreApplication) ConsensusParams() *types.ConsensusParams {
+	value := app.app.state.db.Get([]byte(consensusParamsKey))
+	if value == nil {
+		return types.DefaultConsensusParams()
+	}
+	params := new(types.ConsensusParams)
+	if err := types.ReadMessage(bytes.NewBuffer(value), params); err != nil {
+		panic(err)
+	}
+	return params
+}
+
+func (app *PersistentKVStoreApplication) saveConsensusParams(params *types.ConsensusParams) {
+	value := new(bytes.Buffer)
+	if err := types.WriteMessage(params, value); err != nil {
+		panic(err)
+	}
+	app.app.state.db.Set([]byte(consensusParamsKey), value.Bytes())
+}
+
+// MakeConsensusParamsTx returns the tx to update the consensus params.
+// Only the non-zero fields of the updates are changed.
+func MakeConsensusParamsTx(updates *types.ConsensusParams) []byte {
+	bz, err := json.Marshal(updates)
+	if err != nil {
+		panic(err)
+	}
+	return append([]byte(ConsensusParamsPrefix), bz...)
+}
+
+func isConsensusParamsTx(tx []byte) bool {
+	return strings.HasPrefix(string(tx), ConsensusParamsPrefix)
+}
+
+// format is "params:{json}", with the JSON of the ConsensusParams updates.
+// Updates leaving the params invalid are rejected.
+func (app *PersistentKVStoreApplication) execConsensusParamsTx(tx []byte) types.ResponseDeliverTx {
+	tx = tx[len(ConsensusParamsPrefix):]
+
+	updates := new(types.ConsensusParams)
+	if err := json.Unmarshal(tx, updates); err != nil {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeEncodingError,
+			Log:  fmt.Sprintf("Consensus params (%s) are invalid JSON: %v", tx, err)}
+	}
+
+	params := app.ConsensusParams().Update(updates)
+	if err := params.Validate(); err != nil {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeBadParams,
+			Log:  fmt.Sprintf("Invalid consensus params update: %v", err)}
+	}
+	app.saveConsensusParams(params)
+
+	// accumulate the updates of the block
+	app.ParamUpdates = app.ParamUpdates.Update(updates)
+	return types.ResponseDeliverTx{Code: code.CodeTypeOK}
+}
@@ -3,6 +3,7 @@ package kvstore
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -28,6 +29,17 @@ type PersistentKVStoreApplication struct {
 	// validator set
 	ValUpdates []types.Validator
 
+	// consensus params changes of the block
+	ParamUpdates *types.ConsensusParams
+
+	// state sync
+	snapshotInterval int64
+	restore          *snapshotRestore
+
+	// liveness
+	livenessWindow  int64
+	maxMissedBlocks int64
+
 	logger log.Logger
 }
 
@@ -40,10 +52,17 @@ func NewPersistentKVStoreApplication(dbDir string) *PersistentKVStoreApplication
 
 	state := loadState(db)
 
-	return &PersistentKVStoreApplication{
-		app:    &KVStoreApplication{state: state},
-		logger: log.NewNopLogger(),
+	app := &PersistentKVStoreApplication{
+		app:             newKVStoreApplication(state),
+		livenessWindow:  DefaultLivenessWindow,
+		maxMissedBlocks: DefaultMaxMissedBlocks,
+		logger:          log.NewNopLogger(),
 	}
+	app.app.queryMux.
+		Handle("/validators", app.queryValidators).
+		Handle("/consensus_params", app.queryConsensusParams)
+	app.app.gasMeter.SetParams(app.ConsensusParams())
+	return app
 }
 
 func (app *PersistentKVStoreApplication) SetLogger(l log.Logger) {
@@ -61,7 +80,8 @@ func (app *PersistentKVStoreApplication) SetOption(req types.RequestSetOption) t
 	return app.app.SetOption(req)
 }
 
-// tx is either "val:pubkey/power" or "key=value" or just arbitrary bytes
+// tx is either "val:pubkey/power", "unjail:pubkey/signature", "params:{json}"
+// or "key=value" or just arbitrary bytes
 func (app *PersistentKVStoreApplication) DeliverTx(tx []byte) types.ResponseDeliverTx {
 	// if it starts with "val:", update the validator set
 	// format is "val:pubkey/power"
@@ -71,25 +91,72 @@ func (app *PersistentKVStoreApplication) DeliverTx(tx []byte) types.ResponseDeli
 		return app.execValidatorTx(tx)
 	}
 
+	// if it starts with "unjail:", unjail the validator
+	if isUnjailTx(tx) {
+		return app.execUnjailTx(tx)
+	}
+
+	// if it starts with "params:", update the consensus params
+	if isConsensusParamsTx(tx) {
+		return app.execConsensusParamsTx(tx)
+	}
+
 	// otherwise, update the key-value store
 	return app.app.DeliverTx(tx)
 }
 
-func (app *PersistentKVStoreApplication) CheckTx(tx []byte) types.ResponseCheckTx {
-	return app.app.CheckTx(tx)
+// Only the key-value txs are metered
+func (app *PersistentKVStoreApplication) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	if isValidatorTx(req.Tx) || isUnjailTx(req.Tx) || isConsensusParamsTx(req.Tx) {
+		return types.ResponseCheckTx{Code: code.CodeTypeOK}
+	}
+	return app.app.CheckTx(req)
 }
 
 // Commit will panic if InitChain was not called
 func (app *PersistentKVStoreApplication) Commit() types.ResponseCommit {
-	return app.app.Commit()
+	res := app.app.Commit()
+	// the updated params apply from the next block
+	app.app.gasMeter.SetParams(app.ConsensusParams())
+	if app.snapshotInterval > 0 && app.app.state.Height%app.snapshotInterval == 0 {
+		app.takeSnapshot()
+	}
+	return res
 }
 
+// The "/validators" path returns the JSON statuses of the validators,
+// "/consensus_params" the JSON of the consensus params,
+// other paths query the key-value store
 func (app *PersistentKVStoreApplication) Query(reqQuery types.RequestQuery) types.ResponseQuery {
 	return app.app.Query(reqQuery)
 }
 
-// Save the validators in the merkle tree
+func (app *PersistentKVStoreApplication) queryValidators(reqQuery types.RequestQuery, params map[string]string) types.ResponseQuery {
+	value, err := json.Marshal(app.ValidatorStatuses())
+	if err != nil {
+		panic(err)
+	}
+	return types.ResponseQuery{Value: value}
+}
+
+func (app *PersistentKVStoreApplication) queryConsensusParams(reqQuery types.RequestQuery, params map[string]string) types.ResponseQuery {
+	value, err := json.Marshal(app.ConsensusParams())
+	if err != nil {
+		panic(err)
+	}
+	return types.ResponseQuery{Value: value}
+}
+
+// Save the validators in the merkle tree, and the consensus params
 func (app *PersistentKVStoreApplication) InitChain(req types.RequestInitChain) types.ResponseInitChain {
+	if req.ConsensusParams != nil {
+		params := types.DefaultConsensusParams().Update(req.ConsensusParams)
+		if err := params.Validate(); err != nil {
+			app.logger.Error("Ignoring invalid consensus params", "err", err)
+		} else {
+			app.saveConsensusParams(params)
+		}
+	}
 	for _, v := range req.Validators {
 		r := app.updateValidator(v)
 		if r.IsErr() {
@@ -101,14 +168,28 @@ func (app *PersistentKVStoreApplication) InitChain(req types.RequestInitChain) t
 
 // Track the block hash and header information
 func (app *PersistentKVStoreApplication) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
-	// reset valset changes
+	// reset valset and params changes
 	app.ValUpdates = make([]types.Validator, 0)
+	app.ParamUpdates = nil
+	app.app.BeginBlock(req)
+
+	// punish the validators who misbehaved, or missed too many blocks
+	app.slash(req.ByzantineValidators, req.Header)
+	app.trackLiveness(req.Validators)
 	return types.ResponseBeginBlock{}
 }
 
-// Update the validator set
+// Update the validator set and the consensus params
 func (app *PersistentKVStoreApplication) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
-	return types.ResponseEndBlock{ValidatorUpdates: app.ValUpdates}
+	return types.ResponseEndBlock{ValidatorUpdates: app.ValUpdates, ConsensusParamUpdates: app.ParamUpdates}
+}
+
+func (app *PersistentKVStoreApplication) PrepareProposal(req types.RequestPrepareProposal) types.ResponsePrepareProposal {
+	return app.app.PrepareProposal(req)
+}
+
+func (app *PersistentKVStoreApplication) ProcessProposal(req types.RequestProcessProposal) types.ResponseProcessProposal {
+	return app.app.ProcessProposal(req)
 }
 
 //---------------------------------------------
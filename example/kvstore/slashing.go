@@ -0,0 +1,75 @@
+package kvstore
+
+import (
+	"bytes"
+
+	"github.com/tendermint/abci/types"
+)
+
+const (
+	// evidence older than this is ignored
+	maxEvidenceAgeBlocks  = 100000
+	maxEvidenceAgeSeconds = 60 * 60 * 24 * 21 // 3 weeks
+)
+
+// percentage of its power a validator loses for each type of misbehavior,
+// rounded up, so a validator with any power left loses at least 1
+var slashPercents = map[string]int64{
+	types.EvidenceTypeDuplicateVote: 50,
+	types.EvidenceTypeAmnesiaVote:   20,
+	types.EvidenceTypeLunaticHeader: 100,
+}
+
+// slash reduces the power of the validators with valid evidence against them.
+// Invalid evidence is skipped, like evidence whose total voting power differs
+// from the one of the first evidence from the same height.
+// The new powers are added to the ValUpdates.
+func (app *PersistentKVStoreApplication) slash(evidence []types.Evidence, header types.Header) {
+	totals := make(map[int64]int64)
+	for _, ev := range evidence {
+		if err := ev.ValidateBasic(); err != nil {
+			app.logger.Error("Ignoring invalid evidence", "err", err)
+			continue
+		}
+		if total, ok := totals[ev.Height]; ok && total != ev.TotalVotingPower {
+			app.logger.Error("Ignoring inconsistent evidence", "height", ev.Height,
+				"total", ev.TotalVotingPower, "expected", total)
+			continue
+		}
+		totals[ev.Height] = ev.TotalVotingPower
+
+		if err := ev.ValidateAge(header.Height, header.Time, maxEvidenceAgeBlocks, maxEvidenceAgeSeconds); err != nil {
+			app.logger.Info("Ignoring evidence", "err", err)
+			continue
+		}
+		percent, ok := slashPercents[ev.Type]
+		if !ok {
+			app.logger.Info("Ignoring evidence of unknown type", "type", ev.Type)
+			continue
+		}
+		validator, ok := app.validator(ev.Validator.PubKey)
+		if !ok {
+			// already removed
+			continue
+		}
+		validator.Power -= (validator.Power*percent + 99) / 100
+		if r := app.updateValidator(validator); r.IsErr() {
+			app.logger.Error("Error slashing validator", "r", r)
+			continue
+		}
+		app.logger.Info("Slashed validator", "pubkey", validator.PubKey.Data, "type", ev.Type,
+			"power", validator.Power)
+	}
+}
+
+// validator returns the validator with the pubkey, if any.
+func (app *PersistentKVStoreApplication) validator(pubkey types.PubKey) (validator types.Validator, ok bool) {
+	value := app.app.state.db.Get([]byte("val:" + string(pubkey.Data)))
+	if value == nil {
+		return validator, false
+	}
+	if err := types.ReadMessage(bytes.NewBuffer(value), &validator); err != nil {
+		panic(err)
+	}
+	return validator, true
+}
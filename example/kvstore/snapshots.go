@@ -0,0 +1,277 @@
+package kvstore
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/tendermint/abci/types"
+	cmn "github.com/tendermint/tmlibs/common"
+	dbm "github.com/tendermint/tmlibs/db"
+)
+
+const (
+	// SnapshotFormat is the only snapshot format of the persistent kvstore:
+	// each chunk is a list of length-delimited cmn.KVPair of the db,
+	// and the metadata is the list of the sha256 hashes of the chunks.
+	SnapshotFormat uint32 = 1
+
+	// number of snapshots kept, older ones are pruned
+	snapshotKeepRecent = 2
+)
+
+var (
+	snapshotPrefix      = []byte("snapshots/")
+	snapshotMetaPrefix  = []byte("snapshots/meta/")
+	snapshotChunkPrefix = []byte("snapshots/chunk/")
+
+	// max size of a chunk, but for a single large pair
+	snapshotChunkSize = 1 << 16
+)
+
+func snapshotMetaKey(height uint64) []byte {
+	key := make([]byte, len(snapshotMetaPrefix)+8)
+	copy(key, snapshotMetaPrefix)
+	binary.BigEndian.PutUint64(key[len(snapshotMetaPrefix):], height)
+	return key
+}
+
+func snapshotChunkKey(height uint64, chunk uint32) []byte {
+	key := make([]byte, len(snapshotChunkPrefix)+12)
+	copy(key, snapshotChunkPrefix)
+	binary.BigEndian.PutUint64(key[len(snapshotChunkPrefix):], height)
+	binary.BigEndian.PutUint32(key[len(snapshotChunkPrefix)+8:], chunk)
+	return key
+}
+
+func isSnapshotKey(key []byte) bool {
+	return bytes.HasPrefix(key, snapshotPrefix)
+}
+
+// A snapshot being restored
+type snapshotRestore struct {
+	snapshot types.Snapshot
+	appHash  []byte
+	applied  map[uint32]bool
+}
+
+// SetSnapshotInterval makes the app take a snapshot every interval heights,
+// zero (the default) disables snapshots.
+func (app *PersistentKVStoreApplication) SetSnapshotInterval(interval int64) {
+	app.snapshotInterval = interval
+}
+
+// takeSnapshot saves a snapshot of the state at the current height,
+// and prunes old snapshots.
+func (app *PersistentKVStoreApplication) takeSnapshot() {
+	db := app.app.state.db
+	height := uint64(app.app.state.Height)
+
+	// read all the pairs before writing the chunks
+	var chunks [][]byte
+	chunk := new(bytes.Buffer)
+	itr := db.Iterator(nil, nil)
+	for ; itr.Valid(); itr.Next() {
+		if isSnapshotKey(itr.Key()) {
+			continue
+		}
+		pair := &cmn.KVPair{Key: itr.Key(), Value: itr.Value()}
+		if err := types.WriteMessage(pair, chunk); err != nil {
+			panic(err)
+		}
+		if chunk.Len() >= snapshotChunkSize {
+			chunks = append(chunks, chunk.Bytes())
+			chunk = new(bytes.Buffer)
+		}
+	}
+	itr.Close()
+	if chunk.Len() > 0 || len(chunks) == 0 {
+		chunks = append(chunks, chunk.Bytes())
+	}
+
+	metadata := make([]byte, 0, len(chunks)*sha256.Size)
+	for i, chunk := range chunks {
+		hash := sha256.Sum256(chunk)
+		metadata = append(metadata, hash[:]...)
+		db.Set(snapshotChunkKey(height, uint32(i)), chunk)
+	}
+	hash := sha256.Sum256(metadata)
+	snapshot := &types.Snapshot{
+		Height:   height,
+		Format:   SnapshotFormat,
+		Chunks:   uint32(len(chunks)),
+		Hash:     hash[:],
+		Metadata: metadata,
+	}
+	value := new(bytes.Buffer)
+	if err := types.WriteMessage(snapshot, value); err != nil {
+		panic(err)
+	}
+	db.Set(snapshotMetaKey(height), value.Bytes())
+	app.logger.Info("Took snapshot", "height", height, "chunks", len(chunks))
+
+	snapshots := app.snapshots()
+	for len(snapshots) > snapshotKeepRecent {
+		app.deleteSnapshot(snapshots[0])
+		snapshots = snapshots[1:]
+	}
+}
+
+// snapshots returns the saved snapshots, oldest first.
+func (app *PersistentKVStoreApplication) snapshots() (snapshots []*types.Snapshot) {
+	itr := dbm.IteratePrefix(app.app.state.db, snapshotMetaPrefix)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		snapshot := new(types.Snapshot)
+		if err := types.ReadMessage(bytes.NewBuffer(itr.Value()), snapshot); err != nil {
+			panic(err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+func (app *PersistentKVStoreApplication) deleteSnapshot(snapshot *types.Snapshot) {
+	db := app.app.state.db
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		db.Delete(snapshotChunkKey(snapshot.Height, i))
+	}
+	db.Delete(snapshotMetaKey(snapshot.Height))
+}
+
+// resetState deletes everything but the snapshots.
+func (app *PersistentKVStoreApplication) resetState() {
+	db := app.app.state.db
+	var keys [][]byte
+	itr := db.Iterator(nil, nil)
+	for ; itr.Valid(); itr.Next() {
+		if !isSnapshotKey(itr.Key()) {
+			keys = append(keys, itr.Key())
+		}
+	}
+	itr.Close()
+	for _, key := range keys {
+		db.Delete(key)
+	}
+	app.app.state = loadState(db)
+}
+
+// abortRestore drops the chunks applied by the restore in progress, if any,
+// so they don't leak into the next one.
+func (app *PersistentKVStoreApplication) abortRestore() {
+	if app.restore == nil {
+		return
+	}
+	app.logger.Info("Aborting snapshot restore", "height", app.restore.snapshot.Height)
+	app.restore = nil
+	app.resetState()
+}
+
+func (app *PersistentKVStoreApplication) ListSnapshots(req types.RequestListSnapshots) types.ResponseListSnapshots {
+	return types.ResponseListSnapshots{Snapshots: app.snapshots()}
+}
+
+func (app *PersistentKVStoreApplication) LoadSnapshotChunk(req types.RequestLoadSnapshotChunk) types.ResponseLoadSnapshotChunk {
+	if req.Format != SnapshotFormat {
+		return types.ResponseLoadSnapshotChunk{}
+	}
+	return types.ResponseLoadSnapshotChunk{Chunk: app.app.state.db.Get(snapshotChunkKey(req.Height, req.Chunk))}
+}
+
+// Only an empty app can restore a snapshot.
+// A new offer abandons the restore in progress, dropping its chunks.
+func (app *PersistentKVStoreApplication) OfferSnapshot(req types.RequestOfferSnapshot) types.ResponseOfferSnapshot {
+	app.abortRestore()
+	snapshot := req.Snapshot
+	switch {
+	case app.app.state.Height != 0:
+		app.logger.Error("Cannot restore a snapshot over existing state", "height", app.app.state.Height)
+		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_Abort}
+	case snapshot == nil:
+		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_Reject}
+	case snapshot.Format != SnapshotFormat:
+		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_RejectFormat}
+	}
+	hash := sha256.Sum256(snapshot.Metadata)
+	if snapshot.Chunks == 0 || len(snapshot.Metadata) != int(snapshot.Chunks)*sha256.Size ||
+		!bytes.Equal(hash[:], snapshot.Hash) {
+		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_Reject}
+	}
+
+	app.restore = &snapshotRestore{
+		snapshot: *snapshot,
+		appHash:  req.AppHash,
+		applied:  make(map[uint32]bool),
+	}
+	return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_Accept}
+}
+
+// Chunks are checked against the hashes in the snapshot metadata,
+// and can be applied in any order.
+func (app *PersistentKVStoreApplication) ApplySnapshotChunk(req types.RequestApplySnapshotChunk) types.ResponseApplySnapshotChunk {
+	restore := app.restore
+	if restore == nil || req.Index >= restore.snapshot.Chunks {
+		app.abortRestore()
+		return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_Abort}
+	}
+
+	pairs, err := readSnapshotChunk(restore.snapshot.Metadata, req.Index, req.Chunk)
+	if err != nil {
+		app.logger.Error("Invalid snapshot chunk", "index", req.Index, "sender", req.Sender, "err", err)
+		res := types.ResponseApplySnapshotChunk{
+			Result:        types.ResponseApplySnapshotChunk_Retry,
+			RefetchChunks: []uint32{req.Index},
+		}
+		if req.Sender != "" {
+			res.RejectSenders = []string{req.Sender}
+		}
+		return res
+	}
+	db := app.app.state.db
+	for _, pair := range pairs {
+		// a chunk can't overwrite the local snapshots
+		if !isSnapshotKey(pair.Key) {
+			db.Set(pair.Key, pair.Value)
+		}
+	}
+	restore.applied[req.Index] = true
+	if len(restore.applied) < int(restore.snapshot.Chunks) {
+		return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_Accept}
+	}
+
+	// all chunks are applied, check the restored state
+	app.restore = nil
+	app.app.state = loadState(db)
+	if uint64(app.app.state.Height) != restore.snapshot.Height ||
+		!bytes.Equal(app.app.state.AppHash, restore.appHash) {
+		app.logger.Error("Restored state doesn't match", "height", app.app.state.Height,
+			"appHash", app.app.state.AppHash, "expected", restore.appHash)
+		app.resetState()
+		return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_RejectSnapshot}
+	}
+	app.app.gasMeter.SetParams(app.ConsensusParams())
+	app.logger.Info("Restored snapshot", "height", restore.snapshot.Height)
+	return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_Accept}
+}
+
+// readSnapshotChunk checks the hash of the chunk and decodes its pairs.
+func readSnapshotChunk(metadata []byte, index uint32, chunk []byte) (pairs []cmn.KVPair, err error) {
+	hash := sha256.Sum256(chunk)
+	if !bytes.Equal(hash[:], metadata[index*sha256.Size:(index+1)*sha256.Size]) {
+		return nil, errors.New("chunk hash mismatch")
+	}
+	r := bufio.NewReader(bytes.NewReader(chunk))
+	for {
+		var pair cmn.KVPair
+		err := types.ReadMessage(r, &pair)
+		if err == io.EOF {
+			return pairs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+}
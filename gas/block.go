@@ -0,0 +1,106 @@
+package gas
+
+import (
+	"fmt"
+
+	"github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+)
+
+// BlockMeter meters the gas of txs, each within the TxSize.MaxGas,
+// and together within the BlockSize.MaxGas of the block.
+type BlockMeter struct {
+	config   Config
+	maxGas   int64
+	maxTxGas int64
+	consumed int64
+}
+
+// NewBlockMeter returns a meter without limits until SetParams.
+func NewBlockMeter(config Config) *BlockMeter {
+	return &BlockMeter{
+		config:   config,
+		maxGas:   types.MaxGasUnlimited,
+		maxTxGas: types.MaxGasUnlimited,
+	}
+}
+
+// SetParams sets the limits from the params. Like with
+// types.ConsensusParams.Update, a zero MaxGas is unset and leaves its limit
+// unchanged; types.MaxGasUnlimited removes it.
+func (b *BlockMeter) SetParams(params *types.ConsensusParams) {
+	if max := params.GetBlockSize().GetMaxGas(); max != 0 {
+		b.maxGas = max
+	}
+	if max := params.GetTxSize().GetMaxGas(); max != 0 {
+		b.maxTxGas = max
+	}
+}
+
+// Reset starts a new block.
+func (b *BlockMeter) Reset() {
+	b.consumed = 0
+}
+
+// Consumed returns the gas consumed by the txs of the block.
+func (b *BlockMeter) Consumed() int64 {
+	return b.consumed
+}
+
+// TxLimit returns the gas a tx can consume: the least of the TxSize.MaxGas
+// and of the gas left in the block, or types.MaxGasUnlimited.
+func (b *BlockMeter) TxLimit() int64 {
+	limit := b.maxTxGas
+	if b.maxGas != types.MaxGasUnlimited {
+		left := b.maxGas - b.consumed
+		if limit == types.MaxGasUnlimited || left < limit {
+			limit = left
+		}
+	}
+	return limit
+}
+
+// DeliverTx delivers a tx with deliver, on a Store over the db
+// metered within the TxLimit. The writes of the store are applied
+// if the response is OK, and its gas is added to the block.
+// If it runs out of gas, the response has types.CodeTypeOutOfGas.
+// GasUsed is set to the gas consumed, and GasWanted to the same if unset.
+func (b *BlockMeter) DeliverTx(db dbm.DB, deliver func(*Store) types.ResponseDeliverTx) types.ResponseDeliverTx {
+	limit := b.TxLimit()
+	if limit == 0 {
+		return types.ResponseDeliverTx{
+			Code: types.CodeTypeOutOfGas,
+			Log:  fmt.Sprintf("Block gas limit %d reached", b.maxGas)}
+	}
+	store := NewStore(db, NewMeter(limit), b.config)
+	var res types.ResponseDeliverTx
+	if err := Execute(func() { res = deliver(store) }); err != nil {
+		res = types.ResponseDeliverTx{Code: types.CodeTypeOutOfGas, Log: err.Error()}
+	} else if res.IsOK() {
+		store.Write()
+	}
+	b.consumed += store.Meter().Consumed()
+	res.GasUsed = store.Meter().Consumed()
+	if res.GasWanted == 0 {
+		res.GasWanted = res.GasUsed
+	}
+	return res
+}
+
+// CheckTx checks a tx with check, on a Store over the db metered
+// within the TxSize.MaxGas. The writes of the store are discarded,
+// and the block gas is unchanged.
+// If it runs out of gas, the response has types.CodeTypeOutOfGas.
+// GasUsed is set to the gas consumed, and GasWanted to the same if unset.
+func (b *BlockMeter) CheckTx(db dbm.DB, check func(*Store) types.ResponseCheckTx) types.ResponseCheckTx {
+	store := NewStore(db, NewMeter(b.maxTxGas), b.config)
+	var res types.ResponseCheckTx
+	if err := Execute(func() { res = check(store) }); err != nil {
+		res = types.ResponseCheckTx{Code: types.CodeTypeOutOfGas, Log: err.Error()}
+	}
+	res.GasUsed = store.Meter().Consumed()
+	if res.GasWanted == 0 {
+		res.GasWanted = res.GasUsed
+	}
+	return res
+}
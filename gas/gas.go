@@ -0,0 +1,88 @@
+package gas
+
+import (
+	"fmt"
+
+	"github.com/tendermint/abci/types"
+)
+
+// Config is the gas cost of each store operation.
+type Config struct {
+	HasCost          int64
+	ReadCost         int64
+	ReadCostPerByte  int64
+	WriteCost        int64
+	WriteCostPerByte int64
+	DeleteCost       int64
+}
+
+// DefaultConfig returns a config where writes cost ten times reads.
+func DefaultConfig() Config {
+	return Config{
+		HasCost:          10,
+		ReadCost:         10,
+		ReadCostPerByte:  1,
+		WriteCost:        100,
+		WriteCostPerByte: 10,
+		DeleteCost:       100,
+	}
+}
+
+// ErrOutOfGas is the panic of a Meter exceeding its limit.
+type ErrOutOfGas struct {
+	Descriptor string // what consumed the gas
+	Limit      int64
+}
+
+func (err ErrOutOfGas) Error() string {
+	return fmt.Sprintf("Out of gas in %s, limit %d", err.Descriptor, err.Limit)
+}
+
+//-----------------------------------------
+
+// Meter counts the gas consumed, up to a limit.
+type Meter struct {
+	limit    int64
+	consumed int64
+}
+
+// NewMeter returns a meter of the limit,
+// types.MaxGasUnlimited for a meter without limit.
+func NewMeter(limit int64) *Meter {
+	return &Meter{limit: limit}
+}
+
+func (m *Meter) Limit() int64 {
+	return m.limit
+}
+
+func (m *Meter) Consumed() int64 {
+	return m.consumed
+}
+
+// Consume consumes the amount of gas.
+// It panics with ErrOutOfGas if it exceeds the limit,
+// the meter having then consumed all of it.
+func (m *Meter) Consume(amount int64, descriptor string) {
+	if m.limit != types.MaxGasUnlimited && m.consumed+amount > m.limit {
+		m.consumed = m.limit
+		panic(ErrOutOfGas{Descriptor: descriptor, Limit: m.limit})
+	}
+	m.consumed += amount
+}
+
+// Execute calls fn, and returns the ErrOutOfGas it panics with, if any.
+// Other panics are not recovered.
+func Execute(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			oog, ok := r.(ErrOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			err = oog
+		}
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,116 @@
+package gas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/tendermint/abci/types"
+)
+
+func TestMeter(t *testing.T) {
+	meter := NewMeter(10)
+	meter.Consume(4, "a")
+	meter.Consume(6, "b")
+	assert.EqualValues(t, 10, meter.Consumed())
+
+	err := Execute(func() { meter.Consume(1, "c") })
+	assert.Equal(t, ErrOutOfGas{Descriptor: "c", Limit: 10}, err)
+	assert.EqualValues(t, 10, meter.Consumed())
+
+	unlimited := NewMeter(types.MaxGasUnlimited)
+	assert.Nil(t, Execute(func() { unlimited.Consume(1<<40, "a") }))
+
+	assert.Panics(t, func() {
+		Execute(func() { panic("not gas") }) // nolint: errcheck
+	}, "other panics are not recovered")
+}
+
+func TestStore(t *testing.T) {
+	config := Config{HasCost: 1, ReadCost: 2, ReadCostPerByte: 1, WriteCost: 10, WriteCostPerByte: 2, DeleteCost: 5}
+	db := dbm.NewMemDB()
+	db.Set([]byte("a"), []byte("123"))
+	store := NewStore(db, NewMeter(types.MaxGasUnlimited), config)
+
+	assert.Equal(t, []byte("123"), store.Get([]byte("a")))
+	assert.EqualValues(t, 2+3, store.Meter().Consumed())
+	store.Set([]byte("b"), []byte("45"))
+	assert.EqualValues(t, 5+10+2*3, store.Meter().Consumed())
+	store.Delete([]byte("a"))
+	assert.EqualValues(t, 21+5, store.Meter().Consumed())
+	assert.False(t, store.Has([]byte("a")))
+	assert.True(t, store.Has([]byte("b")))
+	assert.EqualValues(t, 26+2, store.Meter().Consumed())
+
+	// writes are buffered
+	assert.True(t, db.Has([]byte("a")))
+	assert.False(t, db.Has([]byte("b")))
+	store.Write()
+	assert.False(t, db.Has([]byte("a")))
+	assert.Equal(t, []byte("45"), db.Get([]byte("b")))
+}
+
+func TestBlockMeter(t *testing.T) {
+	config := Config{WriteCost: 10}
+	db := dbm.NewMemDB()
+	b := NewBlockMeter(config)
+	b.SetParams(&types.ConsensusParams{
+		BlockSize: &types.BlockSize{MaxGas: 25},
+		TxSize:    &types.TxSize{MaxGas: 20},
+	})
+	writes := func(n int, key string) func(*Store) types.ResponseDeliverTx {
+		return func(store *Store) types.ResponseDeliverTx {
+			for i := 0; i < n; i++ {
+				store.Set([]byte(key), []byte{byte(i)})
+			}
+			return types.ResponseDeliverTx{}
+		}
+	}
+
+	assert.EqualValues(t, 20, b.TxLimit())
+	res := b.DeliverTx(db, writes(1, "a"))
+	require.True(t, res.IsOK(), res.Log)
+	assert.EqualValues(t, 10, res.GasUsed)
+	assert.EqualValues(t, 10, res.GasWanted)
+	assert.True(t, db.Has([]byte("a")))
+
+	// out of the gas left in the block, the writes are discarded
+	assert.EqualValues(t, 15, b.TxLimit())
+	res = b.DeliverTx(db, writes(2, "b"))
+	assert.Equal(t, types.CodeTypeOutOfGas, res.Code)
+	assert.EqualValues(t, 15, res.GasUsed)
+	assert.False(t, db.Has([]byte("b")))
+	assert.EqualValues(t, 25, b.Consumed())
+	assert.EqualValues(t, 0, b.TxLimit())
+	res = b.DeliverTx(db, writes(0, "b"))
+	assert.Equal(t, types.CodeTypeOutOfGas, res.Code, "block is full")
+
+	// out of the tx gas
+	b.Reset()
+	res = b.DeliverTx(db, writes(3, "b"))
+	assert.Equal(t, types.CodeTypeOutOfGas, res.Code)
+	assert.EqualValues(t, 20, res.GasUsed)
+	b.Reset()
+	res = b.DeliverTx(db, writes(2, "c"))
+	require.True(t, res.IsOK(), res.Log)
+	assert.EqualValues(t, 5, b.TxLimit())
+	res = b.DeliverTx(db, writes(1, "d"))
+	assert.Equal(t, types.CodeTypeOutOfGas, res.Code)
+	assert.False(t, db.Has([]byte("d")))
+
+	// CheckTx doesn't write, nor use block gas
+	resCheck := b.CheckTx(db, func(store *Store) types.ResponseCheckTx {
+		store.Set([]byte("e"), nil)
+		return types.ResponseCheckTx{}
+	})
+	require.True(t, resCheck.IsOK(), resCheck.Log)
+	assert.EqualValues(t, 10, resCheck.GasWanted)
+	assert.False(t, db.Has([]byte("e")))
+
+	// an unset MaxGas leaves its limit unchanged
+	b.Reset()
+	b.SetParams(&types.ConsensusParams{TxSize: &types.TxSize{MaxGas: types.MaxGasUnlimited}})
+	assert.EqualValues(t, 25, b.TxLimit())
+}
@@ -0,0 +1,84 @@
+package gas
+
+import (
+	dbm "github.com/tendermint/tmlibs/db"
+)
+
+// Store is a view of a db charging its operations to a meter.
+// The writes are buffered until Write, so those of a tx
+// running out of gas can be discarded.
+type Store struct {
+	db     dbm.DB
+	meter  *Meter
+	config Config
+
+	// buffered writes, a nil value for a delete
+	writes map[string][]byte
+	keys   []string // in the order of the first write
+}
+
+func NewStore(db dbm.DB, meter *Meter, config Config) *Store {
+	return &Store{
+		db:     db,
+		meter:  meter,
+		config: config,
+		writes: make(map[string][]byte),
+	}
+}
+
+func (s *Store) Meter() *Meter {
+	return s.meter
+}
+
+func (s *Store) Has(key []byte) bool {
+	s.meter.Consume(s.config.HasCost, "Has")
+	if value, ok := s.writes[string(key)]; ok {
+		return value != nil
+	}
+	return s.db.Has(key)
+}
+
+// Get charges a read and the bytes read.
+func (s *Store) Get(key []byte) []byte {
+	s.meter.Consume(s.config.ReadCost, "Get")
+	value, ok := s.writes[string(key)]
+	if !ok {
+		value = s.db.Get(key)
+	}
+	s.meter.Consume(s.config.ReadCostPerByte*int64(len(value)), "Get")
+	return value
+}
+
+// Set charges a write and the bytes of the key and value.
+func (s *Store) Set(key, value []byte) {
+	s.meter.Consume(s.config.WriteCost+s.config.WriteCostPerByte*int64(len(key)+len(value)), "Set")
+	if value == nil {
+		value = []byte{}
+	}
+	s.write(key, value)
+}
+
+func (s *Store) Delete(key []byte) {
+	s.meter.Consume(s.config.DeleteCost, "Delete")
+	s.write(key, nil)
+}
+
+func (s *Store) write(key, value []byte) {
+	if _, ok := s.writes[string(key)]; !ok {
+		s.keys = append(s.keys, string(key))
+	}
+	s.writes[string(key)] = value
+}
+
+// Write applies the buffered writes to the db, in order.
+func (s *Store) Write() {
+	for _, key := range s.keys {
+		if value := s.writes[key]; value != nil {
+			s.db.Set([]byte(key), value)
+		} else {
+			s.db.Delete([]byte(key))
+		}
+	}
+	s.writes = make(map[string][]byte)
+	s.keys = nil
+}
@@ -0,0 +1,333 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	abcicli "github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/pubsub/query"
+)
+
+// TxHeightKey is a tag indexed for every tx, besides those of its result.
+// The txs can also be searched by types.TxHashKey.
+const TxHeightKey = "tx.height"
+
+var (
+	heightKey    = []byte("height")
+	txPrefix     = []byte("tx/")
+	tagKeyPrefix = []byte("tag/")
+)
+
+// TxResult is a tx with its result, at an index of the block at a height.
+type TxResult struct {
+	Height int64                   `json:"height"`
+	Index  uint32                  `json:"index"`
+	Tx     []byte                  `json:"tx"`
+	Result types.ResponseDeliverTx `json:"result"`
+}
+
+// Indexer stores the results of the txs by hash, and by tag for Search.
+//
+// It tracks the height with the BeginBlock and Commit it sees,
+// starting from 1, and the index of the txs in the block.
+type Indexer struct {
+	mtx    sync.Mutex
+	db     dbm.DB
+	height int64
+	index  uint32
+}
+
+func NewIndexer(db dbm.DB) *Indexer {
+	idx := &Indexer{db: db, height: 1}
+	if bz := db.Get(heightKey); len(bz) == 8 {
+		idx.height = int64(binary.BigEndian.Uint64(bz))
+	}
+	return idx
+}
+
+// Subscribe makes the indexer index the DeliverTx results of the client.
+// It sets the response callback of the client, to one calling next after
+// indexing, if not nil: pass the callback the client had to keep it.
+func (idx *Indexer) Subscribe(cli abcicli.Client, next abcicli.Callback) {
+	cli.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		idx.Callback(req, res)
+		if next != nil {
+			next(req, res)
+		}
+	})
+}
+
+// Callback indexes the results of the DeliverTx requests,
+// and tracks the height with the BeginBlock requests and Commit responses.
+// It is an abcicli.Callback, to call from another response callback.
+func (idx *Indexer) Callback(req *types.Request, res *types.Response) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	switch r := res.Value.(type) {
+	case *types.Response_BeginBlock:
+		if height := req.GetBeginBlock().Header.Height; height > 0 {
+			idx.setHeight(height)
+		}
+	case *types.Response_DeliverTx:
+		idx.deliverTx(req.GetDeliverTx().Tx, *r.DeliverTx)
+	case *types.Response_DeliverTxBatch:
+		txs := req.GetDeliverTxBatch().Txs
+		for i, res := range r.DeliverTxBatch.Responses {
+			if i < len(txs) && res != nil {
+				idx.deliverTx(txs[i], *res)
+			}
+		}
+	case *types.Response_Commit:
+		idx.setHeight(idx.height + 1)
+	}
+}
+
+func (idx *Indexer) deliverTx(tx []byte, res types.ResponseDeliverTx) {
+	err := idx.store(TxResult{Height: idx.height, Index: idx.index, Tx: tx, Result: res})
+	if err != nil {
+		// only a bug can make a TxResult fail to encode
+		panic(err)
+	}
+	idx.index++
+}
+
+func (idx *Indexer) setHeight(height int64) {
+	idx.height = height
+	idx.index = 0
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	idx.db.Set(heightKey, bz)
+}
+
+// Index stores the result by the hash of its tx, and by tag.
+// It replaces the result of a tx already indexed, and its tags.
+func (idx *Indexer) Index(result TxResult) error {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+	return idx.store(result)
+}
+
+func (idx *Indexer) store(result TxResult) error {
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	hash := types.TxHash(result.Tx)
+	old, err := idx.Get(hash)
+	if err != nil {
+		return err
+	}
+
+	batch := idx.db.NewBatch()
+	if old != nil {
+		for _, key := range tagKeys(*old) {
+			batch.Delete(key)
+		}
+	}
+	for _, key := range tagKeys(result) {
+		batch.Set(key, hash)
+	}
+	batch.Set(append(txPrefix, hash...), bz)
+	batch.Write()
+	return nil
+}
+
+// tagKeys returns the keys indexing the result by tag
+func tagKeys(result TxResult) [][]byte {
+	keys := make([][]byte, 0, len(result.Result.Tags)+1)
+	for _, tag := range result.Result.Tags {
+		if len(tag.Key) == 0 {
+			continue
+		}
+		keys = append(keys, tagKey(string(tag.Key), string(tag.Value), result.Height, result.Index))
+	}
+	return append(keys, tagKey(TxHeightKey, strconv.FormatInt(result.Height, 10), result.Height, result.Index))
+}
+
+// Get returns the result of the tx with the hash, or nil.
+func (idx *Indexer) Get(hash []byte) (*TxResult, error) {
+	bz := idx.db.Get(append(txPrefix, hash...))
+	if bz == nil {
+		return nil, nil
+	}
+	result := new(TxResult)
+	if err := json.Unmarshal(bz, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Search returns the results of the txs matching all the conditions
+// of the query, sorted by height and index.
+// types.TxHashKey only supports equality, with a hex hash.
+func (idx *Indexer) Search(q *query.Query) ([]*TxResult, error) {
+	conditions := q.Conditions()
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("Query %q has no conditions", q)
+	}
+
+	var hashes map[string]bool
+	for i, c := range conditions {
+		matches, err := idx.match(c)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			hashes = matches
+			continue
+		}
+		for hash := range hashes {
+			if !matches[hash] {
+				delete(hashes, hash)
+			}
+		}
+	}
+
+	results := make([]*TxResult, 0, len(hashes))
+	for hash := range hashes {
+		result, err := idx.Get([]byte(hash))
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Height != results[j].Height {
+			return results[i].Height < results[j].Height
+		}
+		return results[i].Index < results[j].Index
+	})
+	return results, nil
+}
+
+// match returns the hashes of the txs matching the condition
+func (idx *Indexer) match(c query.Condition) (map[string]bool, error) {
+	hashes := make(map[string]bool)
+	if c.Tag == types.TxHashKey {
+		if c.Op != query.OpEqual {
+			return nil, fmt.Errorf("%s only supports =", types.TxHashKey)
+		}
+		hash, err := hex.DecodeString(strings.TrimPrefix(fmt.Sprintf("%v", c.Operand), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: %v", types.TxHashKey, err)
+		}
+		if idx.db.Has(append(txPrefix, hash...)) {
+			hashes[string(hash)] = true
+		}
+		return hashes, nil
+	}
+
+	keyPrefix := string(tagKeyPrefix) + escapeTag(c.Tag) + "/"
+	prefix := []byte(keyPrefix)
+	if c.Op == query.OpEqual {
+		// the values equal to the operand have their own prefix
+		if operand, ok := operandString(c.Operand); ok {
+			prefix = append(prefix, escapeTag(operand)+"/"...)
+		}
+	}
+	itr := dbm.IteratePrefix(idx.db, prefix)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		value, ok := tagValue(itr.Key(), len(keyPrefix))
+		if !ok {
+			continue
+		}
+		if matches, err := matchValue(value, c.Op, c.Operand); err != nil {
+			return nil, err
+		} else if matches {
+			hashes[string(itr.Value())] = true
+		}
+	}
+	return hashes, nil
+}
+
+// tagKey is "tag/<key>/<value>/<height>/<index>",
+// with the key and value escaped so they don't contain "/"
+func tagKey(key, value string, height int64, index uint32) []byte {
+	return []byte(fmt.Sprintf("%s%s/%s/%d/%d", tagKeyPrefix, escapeTag(key), escapeTag(value), height, index))
+}
+
+var (
+	tagEscaper   = strings.NewReplacer("%", "%25", "/", "%2F")
+	tagUnescaper = strings.NewReplacer("%25", "%", "%2F", "/")
+)
+
+func escapeTag(s string) string {
+	return tagEscaper.Replace(s)
+}
+
+// tagValue returns the unescaped value of a tagKey, from the start of the value.
+func tagValue(key []byte, start int) (string, bool) {
+	rest := key[start:]
+	i := bytes.IndexByte(rest, '/')
+	if i < 0 {
+		return "", false
+	}
+	return tagUnescaper.Replace(string(rest[:i])), true
+}
+
+func operandString(operand interface{}) (string, bool) {
+	switch o := operand.(type) {
+	case string:
+		return o, true
+	case int64:
+		return strconv.FormatInt(o, 10), true
+	}
+	return "", false
+}
+
+// matchValue compares the tag value to the operand, as a number if the
+// operand is one, as a string otherwise.
+func matchValue(value string, op query.Operator, operand interface{}) (bool, error) {
+	switch o := operand.(type) {
+	case string:
+		switch op {
+		case query.OpEqual:
+			return value == o, nil
+		case query.OpContains:
+			return strings.Contains(value, o), nil
+		}
+		return false, fmt.Errorf("Strings can only be compared with = or CONTAINS")
+	case int64, float64:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			// not a number
+			return false, nil
+		}
+		var f float64
+		if i, ok := o.(int64); ok {
+			f = float64(i)
+		} else {
+			f = o.(float64)
+		}
+		switch op {
+		case query.OpEqual:
+			return v == f, nil
+		case query.OpLess:
+			return v < f, nil
+		case query.OpLessEqual:
+			return v <= f, nil
+		case query.OpGreater:
+			return v > f, nil
+		case query.OpGreaterEqual:
+			return v >= f, nil
+		}
+		return false, fmt.Errorf("Numbers can't be compared with CONTAINS")
+	case time.Time:
+		return false, fmt.Errorf("Times are not indexed")
+	}
+	return false, fmt.Errorf("Unsupported operand %v", operand)
+}
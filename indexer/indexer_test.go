@@ -0,0 +1,120 @@
+package indexer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmn "github.com/tendermint/tmlibs/common"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/pubsub/query"
+
+	abcicli "github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/example/kvstore"
+	"github.com/tendermint/abci/types"
+)
+
+func search(t *testing.T, idx *Indexer, q string) []string {
+	results, err := idx.Search(query.MustParse(q))
+	require.Nil(t, err, q)
+	txs := make([]string, len(results))
+	for i, result := range results {
+		txs[i] = fmt.Sprintf("%d/%d/%s", result.Height, result.Index, result.Tx)
+	}
+	return txs
+}
+
+func TestIndexerCallback(t *testing.T) {
+	db := dbm.NewMemDB()
+	idx := NewIndexer(db)
+	cli := abcicli.NewLocalClient(nil, kvstore.NewKVStoreApplication())
+	var commits int
+	idx.Subscribe(cli, func(req *types.Request, res *types.Response) {
+		if res.GetCommit() != nil {
+			commits++
+		}
+	})
+
+	blocks := [][]string{
+		{"a=1", "b=2"},
+		{"a=3"},
+		{"c=4", "a=5"},
+	}
+	for _, txs := range blocks {
+		for _, tx := range txs {
+			cli.DeliverTxAsync([]byte(tx))
+		}
+		cli.CommitAsync()
+	}
+
+	assert.Equal(t, []string{"1/0/a=1", "2/0/a=3", "3/1/a=5"}, search(t, idx, "app.key='a'"))
+	assert.Equal(t, []string{"2/0/a=3", "3/1/a=5"}, search(t, idx, "app.key='a' AND tx.height>1"))
+	assert.Equal(t, []string{"3/0/c=4", "3/1/a=5"}, search(t, idx, "tx.height=3"))
+	assert.Equal(t, []string{"1/0/a=1", "1/1/b=2", "2/0/a=3"}, search(t, idx, "tx.height>=1 AND tx.height<3 AND app.creator='jae'"))
+	assert.Equal(t, []string{"1/0/a=1", "1/1/b=2"}, search(t, idx, "tx.height CONTAINS '1' AND app.creator CONTAINS 'ja'"))
+	assert.Empty(t, search(t, idx, "app.key='d'"))
+	assert.Equal(t, len(blocks), commits, "next callback called")
+
+	hash := fmt.Sprintf("%X", types.TxHash([]byte("b=2")))
+	assert.Equal(t, []string{"1/1/b=2"}, search(t, idx, "tx.hash='"+hash+"'"))
+
+	// the height is persisted, and set by BeginBlock
+	idx = NewIndexer(db)
+	idx.Subscribe(cli, nil)
+	cli.DeliverTxAsync([]byte("a=6"))
+	cli.BeginBlockAsync(types.RequestBeginBlock{Header: types.Header{Height: 10}})
+	cli.DeliverTxAsync([]byte("a=7"))
+	assert.Equal(t, []string{"4/0/a=6", "10/0/a=7"}, search(t, idx, "app.key='a' AND tx.height>3"))
+
+	result, err := idx.Get(types.TxHash([]byte("a=7")))
+	require.Nil(t, err)
+	require.NotNil(t, result)
+	assert.EqualValues(t, 10, result.Height)
+	assert.True(t, result.Result.IsOK())
+	result, err = idx.Get(types.TxHash([]byte("a=8")))
+	require.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestIndexerSearchErrors(t *testing.T) {
+	idx := NewIndexer(dbm.NewMemDB())
+	require.Nil(t, idx.Index(TxResult{Height: 1, Tx: []byte("tx"),
+		Result: types.ResponseDeliverTx{Tags: nil}}))
+
+	for _, q := range []string{
+		"tx.hash>1",
+		"tx.hash='zz'",
+	} {
+		_, err := idx.Search(query.MustParse(q))
+		assert.NotNil(t, err, q)
+	}
+}
+
+func TestIndexerTags(t *testing.T) {
+	idx := NewIndexer(dbm.NewMemDB())
+	index := func(height int64, i uint32, tx string, tags ...string) {
+		result := TxResult{Height: height, Index: i, Tx: []byte(tx)}
+		for i := 0; i < len(tags); i += 2 {
+			result.Result.Tags = append(result.Result.Tags,
+				cmn.KVPair{Key: []byte(tags[i]), Value: []byte(tags[i+1])})
+		}
+		require.Nil(t, idx.Index(result))
+	}
+
+	// keys and values with "/" don't match others
+	index(1, 0, "a", "k/1", "v", "k", "1/v")
+	index(1, 1, "b", "k", "1%2Fv")
+	assert.Equal(t, []string{"1/0/a"}, search(t, idx, "k/1='v'"))
+	assert.Equal(t, []string{"1/0/a"}, search(t, idx, "k='1/v'"))
+	assert.Equal(t, []string{"1/1/b"}, search(t, idx, "k='1%2Fv'"))
+	assert.Equal(t, []string{"1/0/a", "1/1/b"}, search(t, idx, "k CONTAINS '1'"))
+	assert.Empty(t, search(t, idx, "k CONTAINS 'v/'"))
+
+	// a tx indexed again loses its old tags
+	index(2, 0, "a", "k", "2")
+	assert.Equal(t, []string{"2/0/a"}, search(t, idx, "k='2'"))
+	assert.Empty(t, search(t, idx, "k='1/v'"))
+	assert.Empty(t, search(t, idx, "k/1='v'"))
+	assert.Equal(t, []string{"1/1/b"}, search(t, idx, "tx.height=1"))
+}
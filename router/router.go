@@ -0,0 +1,255 @@
+package router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tendermint/abci/types"
+	cmn "github.com/tendermint/tmlibs/common"
+)
+
+// TxTypeSeparator ends the type of a tx, eg. "val:" for "val:pubkey/power".
+const TxTypeSeparator = ":"
+
+// Module is a part of the application, with its own state and txs.
+// Any Application is a Module.
+//
+// Modules can also implement InitChain, to be initialized at genesis,
+// and Info, to report the last block they committed.
+type Module interface {
+	CheckTx(types.RequestCheckTx) types.ResponseCheckTx
+	DeliverTx(tx []byte) types.ResponseDeliverTx
+	BeginBlock(types.RequestBeginBlock) types.ResponseBeginBlock
+	EndBlock(types.RequestEndBlock) types.ResponseEndBlock
+	Commit() types.ResponseCommit // Data is the hash of the module state
+}
+
+type initChainer interface {
+	InitChain(types.RequestInitChain) types.ResponseInitChain
+}
+
+type infoer interface {
+	Info(types.RequestInfo) types.ResponseInfo
+}
+
+type module struct {
+	name   string
+	module Module
+	hash   []byte // last committed
+}
+
+//-----------------------------------------
+
+var _ types.Application = (*Router)(nil)
+
+// Router is an Application made of modules.
+// CheckTx and DeliverTx are routed to a module by the type of the tx,
+// the other calls go to all the modules in the order they were added,
+// and their responses are merged.
+type Router struct {
+	types.BaseApplication
+
+	modules []*module
+	routes  map[string]*module
+
+	// last committed block, loaded from the modules by the first Info
+	height int64
+	loaded bool
+}
+
+func NewRouter() *Router {
+	return &Router{
+		routes: make(map[string]*module),
+	}
+}
+
+// AddModule adds a module handling the txs of the given types.
+// The empty type routes the txs without a type, or of a type without a route.
+// It panics if the name or a type is already used.
+func (r *Router) AddModule(name string, m Module, txTypes ...string) *Router {
+	for _, mod := range r.modules {
+		if mod.name == name {
+			cmn.PanicSanity(cmn.Fmt("Module %s already added", name))
+		}
+	}
+	mod := &module{name: name, module: m}
+	for _, txType := range txTypes {
+		if route, ok := r.routes[txType]; ok {
+			cmn.PanicSanity(cmn.Fmt("Tx type %q is already routed to module %s", txType, route.name))
+		}
+		r.routes[txType] = mod
+	}
+	r.modules = append(r.modules, mod)
+	return r
+}
+
+// TxType returns the type of the tx, before the first TxTypeSeparator,
+// or "" if it has none.
+func TxType(tx []byte) string {
+	i := bytes.Index(tx, []byte(TxTypeSeparator))
+	if i < 0 {
+		return ""
+	}
+	return string(tx[:i])
+}
+
+func (r *Router) route(tx []byte) (*module, bool) {
+	if mod, ok := r.routes[TxType(tx)]; ok {
+		return mod, true
+	}
+	mod, ok := r.routes[""]
+	return mod, ok
+}
+
+// Info reports the last block committed by the router.
+// Until the first Commit, it's loaded from the modules implementing Info.
+// They must all report the same height, as the router can't replay blocks
+// to a single module: it panics otherwise.
+func (r *Router) Info(req types.RequestInfo) types.ResponseInfo {
+	if !r.loaded {
+		r.load(req)
+	}
+	names := make([]string, len(r.modules))
+	for i, mod := range r.modules {
+		names[i] = mod.name
+	}
+	data, err := json.Marshal(map[string][]string{"modules": names})
+	if err != nil {
+		panic(err)
+	}
+	return types.ResponseInfo{
+		Data:             string(data),
+		LastBlockHeight:  r.height,
+		LastBlockAppHash: r.appHash(),
+	}
+}
+
+func (r *Router) load(req types.RequestInfo) {
+	var first *module
+	for _, mod := range r.modules {
+		m, ok := mod.module.(infoer)
+		if !ok {
+			continue
+		}
+		res := m.Info(req)
+		mod.hash = res.LastBlockAppHash
+		if first == nil {
+			first, r.height = mod, res.LastBlockHeight
+		} else if res.LastBlockHeight != r.height {
+			cmn.PanicCrisis(cmn.Fmt("Module %s is at height %d, but module %s at height %d",
+				mod.name, res.LastBlockHeight, first.name, r.height))
+		}
+	}
+	r.loaded = true
+}
+
+func (r *Router) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	mod, ok := r.route(req.Tx)
+	if !ok {
+		return types.ResponseCheckTx{
+			Code: types.CodeTypeUnknownTx,
+			Log:  fmt.Sprintf("No route for tx type %q", TxType(req.Tx))}
+	}
+	return mod.module.CheckTx(req)
+}
+
+func (r *Router) DeliverTx(tx []byte) types.ResponseDeliverTx {
+	mod, ok := r.route(tx)
+	if !ok {
+		return types.ResponseDeliverTx{
+			Code: types.CodeTypeUnknownTx,
+			Log:  fmt.Sprintf("No route for tx type %q", TxType(tx))}
+	}
+	return mod.module.DeliverTx(tx)
+}
+
+func (r *Router) InitChain(req types.RequestInitChain) types.ResponseInitChain {
+	var res types.ResponseInitChain
+	for _, mod := range r.modules {
+		m, ok := mod.module.(initChainer)
+		if !ok {
+			continue
+		}
+		resMod := m.InitChain(req)
+		res.Validators = mergeValidators(res.Validators, resMod.Validators)
+		if resMod.ConsensusParams != nil {
+			res.ConsensusParams = res.ConsensusParams.Update(resMod.ConsensusParams)
+		}
+	}
+	return res
+}
+
+func (r *Router) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
+	var res types.ResponseBeginBlock
+	for _, mod := range r.modules {
+		resMod := mod.module.BeginBlock(req)
+		res.Tags = append(res.Tags, resMod.Tags...)
+	}
+	return res
+}
+
+// The validator updates of the modules are merged, a later module
+// overriding the updates of the same validator by an earlier one.
+// So are the consensus params updates.
+func (r *Router) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
+	var res types.ResponseEndBlock
+	for _, mod := range r.modules {
+		resMod := mod.module.EndBlock(req)
+		res.ValidatorUpdates = mergeValidators(res.ValidatorUpdates, resMod.ValidatorUpdates)
+		if resMod.ConsensusParamUpdates != nil {
+			res.ConsensusParamUpdates = res.ConsensusParamUpdates.Update(resMod.ConsensusParamUpdates)
+		}
+		res.Tags = append(res.Tags, resMod.Tags...)
+	}
+	return res
+}
+
+// Commit commits all the modules, and returns the hash of their hashes.
+func (r *Router) Commit() types.ResponseCommit {
+	for _, mod := range r.modules {
+		mod.hash = mod.module.Commit().Data
+	}
+	r.height++
+	r.loaded = true
+	return types.ResponseCommit{Data: r.appHash()}
+}
+
+// appHash is the sha256 hash of the names and hashes of the modules,
+// each length-prefixed, in order. It is nil without modules.
+func (r *Router) appHash() []byte {
+	if len(r.modules) == 0 {
+		return nil
+	}
+	hasher := sha256.New()
+	buf := make([]byte, binary.MaxVarintLen64)
+	for _, mod := range r.modules {
+		for _, bz := range [][]byte{[]byte(mod.name), mod.hash} {
+			n := binary.PutUvarint(buf, uint64(len(bz)))
+			hasher.Write(buf[:n]) // nolint: errcheck
+			hasher.Write(bz)      // nolint: errcheck
+		}
+	}
+	return hasher.Sum(nil)
+}
+
+// mergeValidators appends the updates to the validators,
+// replacing those with the same pubkey.
+func mergeValidators(validators, updates []types.Validator) []types.Validator {
+	for _, update := range updates {
+		replaced := false
+		for i, v := range validators {
+			if bytes.Equal(v.PubKey.Data, update.PubKey.Data) {
+				validators[i] = update
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			validators = append(validators, update)
+		}
+	}
+	return validators
+}
@@ -0,0 +1,184 @@
+package router
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmn "github.com/tendermint/tmlibs/common"
+
+	"github.com/tendermint/abci/example/code"
+	"github.com/tendermint/abci/example/kvstore"
+	"github.com/tendermint/abci/types"
+)
+
+// testModule counts its txs, and ends blocks with the given updates
+type testModule struct {
+	types.BaseApplication
+
+	name       string
+	txs        int
+	calls      *[]string
+	validators []types.Validator
+	params     *types.ConsensusParams
+}
+
+func (m *testModule) DeliverTx(tx []byte) types.ResponseDeliverTx {
+	m.txs++
+	return types.ResponseDeliverTx{Code: code.CodeTypeOK, Data: []byte(m.name)}
+}
+
+func (m *testModule) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
+	*m.calls = append(*m.calls, "begin/"+m.name)
+	return types.ResponseBeginBlock{}
+}
+
+func (m *testModule) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
+	*m.calls = append(*m.calls, "end/"+m.name)
+	return types.ResponseEndBlock{
+		ValidatorUpdates:      m.validators,
+		ConsensusParamUpdates: m.params,
+		Tags:                  []cmn.KVPair{{Key: []byte("module"), Value: []byte(m.name)}},
+	}
+}
+
+func (m *testModule) Commit() types.ResponseCommit {
+	*m.calls = append(*m.calls, "commit/"+m.name)
+	hash := sha256.Sum256([]byte(cmn.Fmt("%s/%d", m.name, m.txs)))
+	return types.ResponseCommit{Data: hash[:]}
+}
+
+func TestTxType(t *testing.T) {
+	assert.Equal(t, "val", TxType([]byte("val:pubkey/10")))
+	assert.Equal(t, "", TxType([]byte(":foo")))
+	assert.Equal(t, "", TxType([]byte("key=value")))
+}
+
+func TestRouterRoutes(t *testing.T) {
+	var calls []string
+	a := &testModule{name: "a", calls: &calls}
+	b := &testModule{name: "b", calls: &calls}
+	r := NewRouter().
+		AddModule("a", a, "a", "aa").
+		AddModule("b", b, "b")
+
+	assert.Equal(t, []byte("a"), r.DeliverTx([]byte("a:1")).Data)
+	assert.Equal(t, []byte("a"), r.DeliverTx([]byte("aa:1")).Data)
+	assert.Equal(t, []byte("b"), r.DeliverTx([]byte("b:1")).Data)
+	assert.Equal(t, 2, a.txs)
+	assert.Equal(t, 1, b.txs)
+
+	res := r.DeliverTx([]byte("c:1"))
+	assert.Equal(t, types.CodeTypeUnknownTx, res.Code)
+	assert.Equal(t, types.CodeTypeUnknownTx, r.DeliverTx([]byte("no type")).Code)
+	assert.Equal(t, types.CodeTypeUnknownTx, r.CheckTx(types.RequestCheckTx{Tx: []byte("c:1")}).Code)
+
+	// the default route takes the rest
+	r.AddModule("kvstore", kvstore.NewKVStoreApplication(), "")
+	assert.True(t, r.DeliverTx([]byte("c:1=2")).IsOK())
+	assert.True(t, r.CheckTx(types.RequestCheckTx{Tx: []byte("key=value")}).IsOK())
+	assert.Equal(t, 2, a.txs)
+
+	assert.Panics(t, func() { r.AddModule("a", &testModule{}) }, "same name")
+	assert.Panics(t, func() { r.AddModule("c", &testModule{}, "b") }, "same type")
+}
+
+func TestRouterBlock(t *testing.T) {
+	var calls []string
+	v1 := types.Ed25519Validator([]byte("pubkey1"), 10)
+	v2 := types.Ed25519Validator([]byte("pubkey2"), 10)
+	v1Update := types.Ed25519Validator([]byte("pubkey1"), 0)
+	a := &testModule{name: "a", calls: &calls,
+		validators: []types.Validator{v1, v2},
+		params:     &types.ConsensusParams{BlockSize: &types.BlockSize{MaxGas: 100}},
+	}
+	b := &testModule{name: "b", calls: &calls,
+		validators: []types.Validator{v1Update},
+		params:     &types.ConsensusParams{TxSize: &types.TxSize{MaxGas: 10}},
+	}
+	r := NewRouter().
+		AddModule("a", a, "a").
+		AddModule("b", b, "b")
+
+	r.BeginBlock(types.RequestBeginBlock{})
+	r.DeliverTx([]byte("a:1"))
+	res := r.EndBlock(types.RequestEndBlock{Height: 1})
+	resCommit := r.Commit()
+	assert.Equal(t, []string{"begin/a", "begin/b", "end/a", "end/b", "commit/a", "commit/b"}, calls)
+
+	assert.Equal(t, []types.Validator{v1Update, v2}, res.ValidatorUpdates)
+	assert.Equal(t, &types.ConsensusParams{
+		BlockSize: &types.BlockSize{MaxGas: 100},
+		TxSize:    &types.TxSize{MaxGas: 10},
+	}, res.ConsensusParamUpdates)
+	require.Len(t, res.Tags, 2)
+	assert.Equal(t, []byte("a"), res.Tags[0].Value)
+	assert.Equal(t, []byte("b"), res.Tags[1].Value)
+
+	// the app hash changes with the state of any module
+	assert.Len(t, resCommit.Data, sha256.Size)
+	assert.Equal(t, resCommit.Data, r.Commit().Data)
+	r.DeliverTx([]byte("b:1"))
+	assert.NotEqual(t, resCommit.Data, r.Commit().Data)
+
+	assert.Nil(t, NewRouter().Commit().Data)
+}
+
+// infoModule reports a last block
+type infoModule struct {
+	testModule
+	height int64
+	hash   []byte
+}
+
+func (m *infoModule) Info(req types.RequestInfo) types.ResponseInfo {
+	return types.ResponseInfo{LastBlockHeight: m.height, LastBlockAppHash: m.hash}
+}
+
+func TestRouterInfo(t *testing.T) {
+	var calls []string
+	r := NewRouter().
+		AddModule("kvstore", kvstore.NewKVStoreApplication(), "").
+		AddModule("a", &testModule{name: "a", calls: &calls}, "a")
+
+	res := r.Info(types.RequestInfo{})
+	assert.Equal(t, `{"modules":["kvstore","a"]}`, res.Data)
+	assert.EqualValues(t, 0, res.LastBlockHeight)
+
+	r.DeliverTx([]byte("key=value"))
+	appHash := r.Commit().Data
+	res = r.Info(types.RequestInfo{})
+	assert.EqualValues(t, 1, res.LastBlockHeight)
+	assert.Equal(t, appHash, res.LastBlockAppHash)
+}
+
+func TestRouterInfoLoad(t *testing.T) {
+	var calls []string
+	a := &infoModule{testModule: testModule{name: "a", calls: &calls}, height: 5, hash: []byte("a")}
+	b := &infoModule{testModule: testModule{name: "b", calls: &calls}, height: 5, hash: []byte("b")}
+	r := NewRouter().
+		AddModule("a", a, "a").
+		AddModule("b", b, "b")
+
+	// the height, and the app hash of the hashes of the modules
+	res := r.Info(types.RequestInfo{})
+	assert.EqualValues(t, 5, res.LastBlockHeight)
+	b.hash = []byte("c")
+	assert.Equal(t, res.LastBlockAppHash, r.Info(types.RequestInfo{}).LastBlockAppHash, "loaded once")
+
+	r2 := NewRouter().
+		AddModule("a", a, "a").
+		AddModule("b", b, "b")
+	assert.NotEqual(t, res.LastBlockAppHash, r2.Info(types.RequestInfo{}).LastBlockAppHash)
+
+	r.Commit()
+	assert.EqualValues(t, 6, r.Info(types.RequestInfo{}).LastBlockHeight)
+
+	// modules at different heights
+	b.height = 4
+	r3 := NewRouter().
+		AddModule("a", a, "a").
+		AddModule("b", b, "b")
+	assert.Panics(t, func() { r3.Info(types.RequestInfo{}) })
+}
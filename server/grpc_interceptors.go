@@ -0,0 +1,105 @@
+package server
+
+import (
+	"runtime/debug"
+	"time"
+
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/tmlibs/log"
+)
+
+// LoggingUnaryServerInterceptor logs every unary call, with its duration,
+// at debug level, or at error level if it fails.
+func LoggingUnaryServerInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		logCall(logger, info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+// LoggingStreamServerInterceptor logs every stream, with its duration,
+// at debug level, or at error level if it fails.
+func LoggingStreamServerInterceptor(logger log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(logger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logCall(logger log.Logger, method string, start time.Time, err error) {
+	if err != nil {
+		logger.Error("gRPC call failed", "method", method, "took", time.Since(start), "err", err)
+		return
+	}
+	logger.Debug("gRPC call", "method", method, "took", time.Since(start))
+}
+
+// RecoveryUnaryServerInterceptor recovers from panics in unary handlers,
+// logs them and returns an Internal error to the caller instead of
+// crashing the server.
+func RecoveryUnaryServerInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverCall(logger, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamServerInterceptor recovers from panics in stream handlers,
+// logs them and returns an Internal error to the caller instead of
+// crashing the server.
+func RecoveryStreamServerInterceptor(logger log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverCall(logger, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recoverCall(logger log.Logger, method string, r interface{}) error {
+	logger.Error("Panic in gRPC handler", "method", method, "err", r, "stack", string(debug.Stack()))
+	return status.Errorf(codes.Internal, "panic in %s: %v", method, r)
+}
+
+//----------------------------------------
+// grpc takes a single interceptor of each kind, so we chain them ourselves.
+
+func chainUnaryServerInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
+func chainStreamServerInterceptors(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+		return chained(srv, ss)
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/tmlibs/log"
+)
+
+func TestChainUnaryServerInterceptors(t *testing.T) {
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name+":before")
+			res, err := handler(ctx, req)
+			calls = append(calls, name+":after")
+			return res, err
+		}
+	}
+	chained := chainUnaryServerInterceptors([]grpc.UnaryServerInterceptor{record("a"), record("b")})
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/types.ABCIApplication/Echo"}
+	res, err := chained(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "req", res)
+	assert.Equal(t, []string{"a:before", "b:before", "handler", "b:after", "a:after"}, calls)
+}
+
+func TestRecoveryUnaryServerInterceptor(t *testing.T) {
+	interceptor := RecoveryUnaryServerInterceptor(log.TestingLogger())
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/types.ABCIApplication/DeliverTx"}
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Contains(t, st.Message(), "boom")
+}
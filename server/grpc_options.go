@@ -0,0 +1,53 @@
+package server
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// GRPCServerOption sets an optional parameter on the GRPCServer.
+type GRPCServerOption func(*GRPCServer)
+
+// GRPCUnaryInterceptors adds unary interceptors to the server.
+// Interceptors are chained in the order they are given,
+// the first one being the outermost.
+func GRPCUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) GRPCServerOption {
+	return func(s *GRPCServer) {
+		s.unaryInterceptors = append(s.unaryInterceptors, interceptors...)
+	}
+}
+
+// GRPCStreamInterceptors adds stream interceptors to the server.
+// Interceptors are chained in the order they are given,
+// the first one being the outermost.
+func GRPCStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) GRPCServerOption {
+	return func(s *GRPCServer) {
+		s.streamInterceptors = append(s.streamInterceptors, interceptors...)
+	}
+}
+
+// GRPCKeepalive sets the keepalive parameters and enforcement policy of the server.
+func GRPCKeepalive(params keepalive.ServerParameters, policy keepalive.EnforcementPolicy) GRPCServerOption {
+	return GRPCServerOptions(grpc.KeepaliveParams(params), grpc.KeepaliveEnforcementPolicy(policy))
+}
+
+// GRPCMaxMsgSize sets the maximum size in bytes of messages
+// the server can receive and send.
+func GRPCMaxMsgSize(recv, send int) GRPCServerOption {
+	return GRPCServerOptions(grpc.MaxRecvMsgSize(recv), grpc.MaxSendMsgSize(send))
+}
+
+// GRPCCreds sets the transport credentials of the server.
+func GRPCCreds(creds credentials.TransportCredentials) GRPCServerOption {
+	return GRPCServerOptions(grpc.Creds(creds))
+}
+
+// GRPCServerOptions passes raw grpc.ServerOptions through to grpc.NewServer.
+// Use GRPCUnaryInterceptors and GRPCStreamInterceptors for interceptors,
+// since grpc only accepts one of each.
+func GRPCServerOptions(opts ...grpc.ServerOption) GRPCServerOption {
+	return func(s *GRPCServer) {
+		s.serverOpts = append(s.serverOpts, opts...)
+	}
+}
@@ -2,13 +2,21 @@ package server
 
 import (
 	"net"
+	"sync"
+	"time"
 
+	context "golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/tendermint/abci/types"
 	cmn "github.com/tendermint/tmlibs/common"
 )
 
+const appReadyRetryIntervalSeconds = 1
+
 type GRPCServer struct {
 	cmn.BaseService
 
@@ -17,11 +25,18 @@ type GRPCServer struct {
 	listener net.Listener
 	server   *grpc.Server
 
+	healthMtx sync.Mutex // so a stopped server is never reported as serving
+	health    *health.Server
+
+	serverOpts         []grpc.ServerOption
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+
 	app types.ABCIApplicationServer
 }
 
 // NewGRPCServer returns a new gRPC ABCI server
-func NewGRPCServer(protoAddr string, app types.ABCIApplicationServer) cmn.Service {
+func NewGRPCServer(protoAddr string, app types.ABCIApplicationServer, options ...GRPCServerOption) cmn.Service {
 	proto, addr := cmn.ProtocolAndAddress(protoAddr)
 	s := &GRPCServer{
 		proto:    proto,
@@ -30,10 +45,16 @@ func NewGRPCServer(protoAddr string, app types.ABCIApplicationServer) cmn.Servic
 		app:      app,
 	}
 	s.BaseService = *cmn.NewBaseService(nil, "ABCIServer", s)
+	for _, option := range options {
+		option(s)
+	}
 	return s
 }
 
-// OnStart starts the gRPC service
+// OnStart starts the gRPC service.
+// Besides ABCIApplication, it registers the standard gRPC health service,
+// which reports NOT_SERVING for the ABCI service until the application
+// answers an Info request, and the gRPC server reflection service.
 func (s *GRPCServer) OnStart() error {
 	if err := s.BaseService.OnStart(); err != nil {
 		return err
@@ -44,14 +65,66 @@ func (s *GRPCServer) OnStart() error {
 	}
 	s.Logger.Info("Listening", "proto", s.proto, "addr", s.addr)
 	s.listener = ln
-	s.server = grpc.NewServer()
+	s.server = grpc.NewServer(s.grpcServerOptions()...)
 	types.RegisterABCIApplicationServer(s.server, s.app)
+
+	s.health = health.NewServer()
+	s.health.SetServingStatus(types.ABCIApplicationServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s.server, s.health)
+
+	reflection.Register(s.server)
+
 	go s.server.Serve(s.listener)
+	go s.waitForAppRoutine()
 	return nil
 }
 
 // OnStop stops the gRPC server
 func (s *GRPCServer) OnStop() {
 	s.BaseService.OnStop()
+	s.healthMtx.Lock()
+	s.health.SetServingStatus(types.ABCIApplicationServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	s.healthMtx.Unlock()
 	s.server.Stop()
 }
+
+func (s *GRPCServer) grpcServerOptions() []grpc.ServerOption {
+	opts := append([]grpc.ServerOption{}, s.serverOpts...)
+	if len(s.unaryInterceptors) > 0 {
+		opts = append(opts, grpc.UnaryInterceptor(chainUnaryServerInterceptors(s.unaryInterceptors)))
+	}
+	if len(s.streamInterceptors) > 0 {
+		opts = append(opts, grpc.StreamInterceptor(chainStreamServerInterceptors(s.streamInterceptors)))
+	}
+	return opts
+}
+
+// setServing reports the ABCI service as serving, unless the server was stopped.
+func (s *GRPCServer) setServing() {
+	s.healthMtx.Lock()
+	defer s.healthMtx.Unlock()
+	if !s.IsRunning() {
+		return
+	}
+	s.Logger.Info("Application is ready")
+	s.health.SetServingStatus(types.ABCIApplicationServiceName, healthpb.HealthCheckResponse_SERVING)
+}
+
+// Probe the application with Info until it answers,
+// then report the ABCI service as serving.
+func (s *GRPCServer) waitForAppRoutine() {
+	for {
+		_, err := s.app.Info(context.Background(), &types.RequestInfo{})
+		if err == nil {
+			s.setServing()
+			return
+		}
+		s.Logger.Error("Application is not ready", "err", err)
+
+		select {
+		case <-s.Quit():
+			return
+		case <-time.After(time.Second * appReadyRetryIntervalSeconds):
+		}
+	}
+}
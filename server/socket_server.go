@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/tendermint/abci/types"
+	"github.com/tendermint/abci/version"
 	cmn "github.com/tendermint/tmlibs/common"
 )
 
@@ -173,6 +174,7 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 		responses <- types.ToResponseFlush()
 	case *types.Request_Info:
 		res := s.app.Info(*r.Info)
+		res.AbciVersion = version.ABCIVersion
 		responses <- types.ToResponseInfo(res)
 	case *types.Request_SetOption:
 		res := s.app.SetOption(*r.SetOption)
@@ -181,7 +183,7 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 		res := s.app.DeliverTx(r.DeliverTx.Tx)
 		responses <- types.ToResponseDeliverTx(res)
 	case *types.Request_CheckTx:
-		res := s.app.CheckTx(r.CheckTx.Tx)
+		res := s.app.CheckTx(*r.CheckTx)
 		responses <- types.ToResponseCheckTx(res)
 	case *types.Request_Commit:
 		res := s.app.Commit()
@@ -198,6 +200,38 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 	case *types.Request_EndBlock:
 		res := s.app.EndBlock(*r.EndBlock)
 		responses <- types.ToResponseEndBlock(res)
+	case *types.Request_DeliverTxBatch:
+		res, err := types.DeliverTxBatch(s.app, *r.DeliverTxBatch)
+		if err != nil {
+			responses <- types.ToResponseException(err.Error())
+			return
+		}
+		responses <- types.ToResponseDeliverTxBatch(res)
+	case *types.Request_CheckTxBatch:
+		res, err := types.CheckTxBatch(s.app, *r.CheckTxBatch)
+		if err != nil {
+			responses <- types.ToResponseException(err.Error())
+			return
+		}
+		responses <- types.ToResponseCheckTxBatch(res)
+	case *types.Request_ListSnapshots:
+		res := s.app.ListSnapshots(*r.ListSnapshots)
+		responses <- types.ToResponseListSnapshots(res)
+	case *types.Request_OfferSnapshot:
+		res := s.app.OfferSnapshot(*r.OfferSnapshot)
+		responses <- types.ToResponseOfferSnapshot(res)
+	case *types.Request_LoadSnapshotChunk:
+		res := s.app.LoadSnapshotChunk(*r.LoadSnapshotChunk)
+		responses <- types.ToResponseLoadSnapshotChunk(res)
+	case *types.Request_ApplySnapshotChunk:
+		res := s.app.ApplySnapshotChunk(*r.ApplySnapshotChunk)
+		responses <- types.ToResponseApplySnapshotChunk(res)
+	case *types.Request_PrepareProposal:
+		res := s.app.PrepareProposal(*r.PrepareProposal)
+		responses <- types.ToResponsePrepareProposal(res)
+	case *types.Request_ProcessProposal:
+		res := s.app.ProcessProposal(*r.ProcessProposal)
+		responses <- types.ToResponseProcessProposal(res)
 	default:
 		responses <- types.ToResponseException("Unknown request")
 	}
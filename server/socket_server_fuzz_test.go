@@ -0,0 +1,64 @@
+//go:build go1.18
+// +build go1.18
+
+package server
+
+import (
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/tendermint/abci/example/kvstore"
+	"github.com/tendermint/abci/tests/fuzz"
+	"github.com/tendermint/abci/types"
+)
+
+// FuzzSocketServer feeds arbitrary byte streams to the requests handler
+// of a socket server over a kvstore app: it must not panic, and answer
+// each request it decodes with a response that can be written.
+func FuzzSocketServer(f *testing.F) {
+	seeds, err := fuzz.SeedRequests(filepath.Join("..", fuzz.SeedDir))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, reqs := range seeds {
+		stream, err := fuzz.EncodeRequests(reqs)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(stream)
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0x01}) // a negative length
+
+	f.Fuzz(func(t *testing.T, stream []byte) {
+		s := NewSocketServer("unix://fuzz.sock", kvstore.NewKVStoreApplication()).(*SocketServer)
+		reqs, _ := fuzz.DecodeRequests(stream)
+
+		conn, clientConn := net.Pipe()
+		go func() {
+			clientConn.Write(stream)
+			clientConn.Close()
+		}()
+
+		// enough room for the responses, as nobody reads them until the end
+		responses := make(chan *types.Response, len(reqs)+1)
+		closeConn := make(chan error, 1)
+		s.handleRequests(closeConn, conn, responses)
+		conn.Close()
+		close(responses)
+
+		if len(responses) != len(reqs) {
+			t.Fatalf("%d responses to %d requests", len(responses), len(reqs))
+		}
+		for res := range responses {
+			if err := types.WriteMessage(res, ioutil.Discard); err != nil {
+				t.Fatalf("Error writing %v: %v", res, err)
+			}
+		}
+		if err := <-closeConn; err == nil {
+			t.Fatal("Connection closed without error")
+		}
+	})
+}
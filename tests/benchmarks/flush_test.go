@@ -0,0 +1,63 @@
+package benchmarks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/tendermint/abci/client"
+	"github.com/tendermint/abci/server"
+	"github.com/tendermint/abci/types"
+)
+
+// Compare the throughput of concurrent CheckTxSync callers
+// under the socket client flush policies.
+
+func BenchmarkSocketFlushInterval(b *testing.B) {
+	benchmarkCheckTxSync(b, "interval")
+}
+
+func BenchmarkSocketFlushEvery(b *testing.B) {
+	benchmarkCheckTxSync(b, "every", abcicli.SocketFlushEvery(16))
+}
+
+func BenchmarkSocketFlushImmediate(b *testing.B) {
+	benchmarkCheckTxSync(b, "immediate", abcicli.SocketFlushImmediate())
+}
+
+func BenchmarkSocketFlushCoalesced(b *testing.B) {
+	benchmarkCheckTxSync(b, "coalesced", abcicli.SocketCoalesceFlushes())
+}
+
+func benchmarkCheckTxSync(b *testing.B, name string, options ...abcicli.SocketClientOption) {
+	socket := fmt.Sprintf("unix://bench-flush-%s.sock", name)
+	srv, err := server.NewServer(socket, "socket", types.NewBaseApplication())
+	if err != nil {
+		b.Fatal(err)
+	}
+	srv.SetLogger(log.NewNopLogger())
+	if err := srv.Start(); err != nil {
+		b.Fatal(err)
+	}
+	defer srv.Stop()
+
+	cli := abcicli.NewSocketClient(socket, true, options...)
+	cli.SetLogger(log.NewNopLogger())
+	if err := cli.Start(); err != nil {
+		b.Fatal(err)
+	}
+	defer cli.Stop()
+
+	tx := []byte("benchmark")
+	b.SetParallelism(8)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := cli.CheckTxSync(types.RequestCheckTx{Tx: tx}); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
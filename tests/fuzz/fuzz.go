@@ -0,0 +1,208 @@
+// Package fuzz has the seed corpus and the crash reproduction helpers
+// of the fuzz targets of the types and server packages.
+//
+// The fuzz targets need Go 1.18 or later:
+//
+//	go test ./types -run=- -fuzz=FuzzReadMessage
+//	go test ./types -run=- -fuzz=FuzzRequestRoundTrip
+//	go test ./server -run=- -fuzz=FuzzSocketServer
+//
+// A crashing input is saved in the testdata/fuzz/<target> directory of the
+// package, and replayed by `go test -run=<target>/<file>`.
+// ReadCorpusFile reads it back, and DecodeRequests shows the requests of
+// a crashing stream of the socket server.
+package fuzz
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tendermint/abci/types"
+)
+
+// corpusHeader is the first line of the files of a Go fuzzing corpus
+const corpusHeader = "go test fuzz v1"
+
+// SeedDir is the directory of the abci-cli batch files of the seed corpus,
+// relative to the root of the repo.
+const SeedDir = "tests/test_cli"
+
+// ParseCommands parses abci-cli batch commands into requests,
+// skipping the commands run by the client, like search.
+func ParseCommands(r io.Reader) ([]*types.Request, error) {
+	var reqs []*types.Request
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		args := strings.Fields(scanner.Text())
+		if len(args) == 0 {
+			continue
+		}
+		req, err := parseCommand(args[0], args[1:])
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing %q: %v", scanner.Text(), err)
+		}
+		if req != nil {
+			reqs = append(reqs, req)
+		}
+	}
+	return reqs, scanner.Err()
+}
+
+func parseCommand(cmd string, args []string) (*types.Request, error) {
+	arg := func(i int) ([]byte, error) {
+		if i >= len(args) {
+			return nil, fmt.Errorf("Missing argument %d", i)
+		}
+		return types.StringOrHexToBytes(args[i])
+	}
+	switch cmd {
+	case "echo":
+		return types.ToRequestEcho(strings.Join(args, " ")), nil
+	case "info":
+		return types.ToRequestInfo(types.RequestInfo{}), nil
+	case "set_option":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("Want a key and a value")
+		}
+		return types.ToRequestSetOption(types.RequestSetOption{Key: args[0], Value: args[1]}), nil
+	case "deliver_tx":
+		tx, err := arg(0)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToRequestDeliverTx(tx), nil
+	case "check_tx":
+		tx, err := arg(0)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToRequestCheckTx(types.RequestCheckTx{Tx: tx}), nil
+	case "query":
+		data, err := arg(0)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToRequestQuery(types.RequestQuery{Path: "/store", Data: data}), nil
+	case "paths":
+		return types.ToRequestQuery(types.RequestQuery{Path: types.QueryPathsPath}), nil
+	case "end_block":
+		var height int64
+		if len(args) > 0 {
+			var err error
+			if height, err = strconv.ParseInt(args[0], 10, 64); err != nil {
+				return nil, err
+			}
+		}
+		return types.ToRequestEndBlock(types.RequestEndBlock{Height: height}), nil
+	case "commit":
+		return types.ToRequestCommit(), nil
+	}
+	return nil, nil
+}
+
+// SeedRequests returns the requests of the *.abci batch files of the dir.
+func SeedRequests(dir string) ([][]*types.Request, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.abci"))
+	if err != nil {
+		return nil, err
+	}
+	var seeds [][]*types.Request
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		reqs, err := ParseCommands(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		seeds = append(seeds, reqs)
+	}
+	return seeds, nil
+}
+
+// EncodeRequests returns the requests as sent to a socket server,
+// each ending with a flush.
+func EncodeRequests(reqs []*types.Request) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	for _, req := range reqs {
+		if err := types.WriteMessage(req, buf); err != nil {
+			return nil, err
+		}
+		if err := types.WriteMessage(types.ToRequestFlush(), buf); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeRequests returns the requests of a stream sent to a socket server,
+// up to the first that can't be decoded, and its error.
+func DecodeRequests(stream []byte) ([]*types.Request, error) {
+	var reqs []*types.Request
+	r := bufio.NewReader(bytes.NewReader(stream))
+	for {
+		req := new(types.Request)
+		if err := types.ReadMessage(r, req); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return reqs, err
+		}
+		reqs = append(reqs, req)
+	}
+}
+
+// ReadCorpusFile returns the []byte and string values of a file of
+// a Go fuzzing corpus, eg. a crashing input in testdata/fuzz/<target>.
+func ReadCorpusFile(path string) ([][]byte, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(bz)), "\n")
+	if len(lines) == 0 || lines[0] != corpusHeader {
+		return nil, fmt.Errorf("%s is not a fuzzing corpus file", path)
+	}
+	var values [][]byte
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		var quoted string
+		switch {
+		case strings.HasPrefix(line, "[]byte(") && strings.HasSuffix(line, ")"):
+			quoted = line[len("[]byte(") : len(line)-1]
+		case strings.HasPrefix(line, "string(") && strings.HasSuffix(line, ")"):
+			quoted = line[len("string(") : len(line)-1]
+		default:
+			return nil, fmt.Errorf("Unsupported value in %s: %s", path, line)
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value in %s: %v", path, err)
+		}
+		values = append(values, []byte(value))
+	}
+	return values, nil
+}
+
+// WriteCorpusFile writes the values as a file of a Go fuzzing corpus,
+// eg. to add an input to testdata/fuzz/<target>.
+func WriteCorpusFile(path string, values ...[]byte) error {
+	buf := new(bytes.Buffer)
+	fmt.Fprintln(buf, corpusHeader)
+	for _, value := range values {
+		fmt.Fprintf(buf, "[]byte(%q)\n", value)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
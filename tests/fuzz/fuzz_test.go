@@ -0,0 +1,77 @@
+package fuzz
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/abci/types"
+)
+
+func TestParseCommands(t *testing.T) {
+	reqs, err := ParseCommands(strings.NewReader(`echo hello world
+set_option serial on
+deliver_tx "abc"
+check_tx 0x00ff
+
+search app.key='abc'
+end_block 5
+commit
+`))
+	require.Nil(t, err)
+	require.Len(t, reqs, 6)
+	assert.Equal(t, "hello world", reqs[0].GetEcho().Message)
+	assert.Equal(t, "on", reqs[1].GetSetOption().Value)
+	assert.Equal(t, []byte("abc"), reqs[2].GetDeliverTx().Tx)
+	assert.Equal(t, []byte{0x00, 0xff}, reqs[3].GetCheckTx().Tx)
+	assert.EqualValues(t, 5, reqs[4].GetEndBlock().Height)
+	assert.NotNil(t, reqs[5].GetCommit())
+
+	_, err = ParseCommands(strings.NewReader("deliver_tx abc"))
+	assert.NotNil(t, err)
+
+	seeds, err := SeedRequests(filepath.Join("..", "..", SeedDir))
+	require.Nil(t, err)
+	assert.NotEmpty(t, seeds)
+}
+
+func TestEncodeDecodeRequests(t *testing.T) {
+	reqs := []*types.Request{
+		types.ToRequestDeliverTx([]byte("abc")),
+		types.ToRequestCommit(),
+	}
+	stream, err := EncodeRequests(reqs)
+	require.Nil(t, err)
+	decoded, err := DecodeRequests(stream)
+	require.Nil(t, err)
+	require.Len(t, decoded, 4, "each with a flush")
+	assert.Equal(t, []byte("abc"), decoded[0].GetDeliverTx().Tx)
+	assert.NotNil(t, decoded[1].GetFlush())
+
+	// up to the first that can't be decoded
+	decoded, err = DecodeRequests(append(stream, 0x01))
+	assert.NotNil(t, err)
+	assert.Len(t, decoded, 4)
+}
+
+func TestCorpusFile(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "abci-fuzz-test")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "FuzzTarget", "crash")
+	values := [][]byte{{0x00, 0xff, '"', '\n'}, []byte("abc")}
+	require.Nil(t, WriteCorpusFile(path, values...))
+	read, err := ReadCorpusFile(path)
+	require.Nil(t, err)
+	assert.Equal(t, values, read)
+
+	require.Nil(t, ioutil.WriteFile(path, []byte("go test fuzz v1\nstring(\"abc\")\nint(1)\n"), 0644))
+	_, err = ReadCorpusFile(path)
+	assert.NotNil(t, err)
+}
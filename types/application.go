@@ -1,13 +1,17 @@
 package types // nolint: goimports
 
 import (
+	"fmt"
+
 	context "golang.org/x/net/context"
+
+	"github.com/tendermint/abci/version"
 )
 
 // Application is an interface that enables any finite, deterministic state machine
 // to be driven by a blockchain-based replication engine via the ABCI.
 // All methods take a RequestXxx argument and return a ResponseXxx argument,
-// except CheckTx/DeliverTx, which take `tx []byte`, and `Commit`, which takes nothing.
+// except DeliverTx, which takes `tx []byte`, and `Commit`, which takes nothing.
 type Application interface {
 	// Info/Query Connection
 	Info(RequestInfo) ResponseInfo                // Return application info
@@ -15,14 +19,75 @@ type Application interface {
 	Query(RequestQuery) ResponseQuery             // Query for state
 
 	// Mempool Connection
-	CheckTx(tx []byte) ResponseCheckTx // Validate a tx for the mempool
+	CheckTx(RequestCheckTx) ResponseCheckTx // Validate a tx for the mempool, new or rechecked
 
 	// Consensus Connection
-	InitChain(RequestInitChain) ResponseInitChain    // Initialize blockchain with validators and other info from TendermintCore
-	BeginBlock(RequestBeginBlock) ResponseBeginBlock // Signals the beginning of a block
-	DeliverTx(tx []byte) ResponseDeliverTx           // Deliver a tx for full processing
-	EndBlock(RequestEndBlock) ResponseEndBlock       // Signals the end of a block, returns changes to the validator set
-	Commit() ResponseCommit                          // Commit the state and return the application Merkle root hash
+	InitChain(RequestInitChain) ResponseInitChain                   // Initialize blockchain with validators and other info from TendermintCore
+	BeginBlock(RequestBeginBlock) ResponseBeginBlock                // Signals the beginning of a block
+	DeliverTx(tx []byte) ResponseDeliverTx                          // Deliver a tx for full processing
+	EndBlock(RequestEndBlock) ResponseEndBlock                      // Signals the end of a block, returns changes to the validator set
+	Commit() ResponseCommit                                         // Commit the state and return the application Merkle root hash
+	PrepareProposal(RequestPrepareProposal) ResponsePrepareProposal // Choose the txs of a block to propose
+	ProcessProposal(RequestProcessProposal) ResponseProcessProposal // Accept or reject a proposed block
+
+	// State Sync Connection
+	ListSnapshots(RequestListSnapshots) ResponseListSnapshots                // List available snapshots
+	OfferSnapshot(RequestOfferSnapshot) ResponseOfferSnapshot                // Offer a snapshot to the application
+	LoadSnapshotChunk(RequestLoadSnapshotChunk) ResponseLoadSnapshotChunk    // Load a snapshot chunk
+	ApplySnapshotChunk(RequestApplySnapshotChunk) ResponseApplySnapshotChunk // Apply a snapshot chunk
+}
+
+// BatchApplication is an optional interface for apps that can process
+// a batch of txs better than one at a time.
+// The responses must be in the order of the txs.
+type BatchApplication interface {
+	Application
+
+	CheckTxBatch(RequestCheckTxBatch) []*ResponseCheckTx       // Validate a batch of txs for the mempool
+	DeliverTxBatch(RequestDeliverTxBatch) []*ResponseDeliverTx // Deliver a batch of txs for full processing
+}
+
+// DeliverTxBatch delivers the txs to the app, in one call if it is a
+// BatchApplication, or else one tx at a time.
+// It returns an error if a BatchApplication doesn't return one response
+// per tx, along with the responses it returned.
+func DeliverTxBatch(app Application, req RequestDeliverTxBatch) (ResponseDeliverTxBatch, error) {
+	if batchApp, ok := app.(BatchApplication); ok {
+		responses := batchApp.DeliverTxBatch(req)
+		return ResponseDeliverTxBatch{responses}, checkBatchLen("DeliverTxBatch", len(responses), len(req.Txs))
+	}
+	responses := make([]*ResponseDeliverTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		res := app.DeliverTx(tx)
+		responses[i] = &res
+	}
+	return ResponseDeliverTxBatch{responses}, nil
+}
+
+// CheckTxBatch checks the txs with the app, in one call if it is a
+// BatchApplication, or else one tx at a time.
+// It returns an error if a BatchApplication doesn't return one response
+// per tx, along with the responses it returned.
+func CheckTxBatch(app Application, req RequestCheckTxBatch) (ResponseCheckTxBatch, error) {
+	if batchApp, ok := app.(BatchApplication); ok {
+		responses := batchApp.CheckTxBatch(req)
+		return ResponseCheckTxBatch{responses}, checkBatchLen("CheckTxBatch", len(responses), len(req.Txs))
+	}
+	responses := make([]*ResponseCheckTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		res := app.CheckTx(RequestCheckTx{Tx: tx, Type: req.Type})
+		responses[i] = &res
+	}
+	return ResponseCheckTxBatch{responses}, nil
+}
+
+// checkBatchLen returns an error if a batch app broke the mapping of
+// responses to txs.
+func checkBatchLen(method string, responses, txs int) error {
+	if responses != txs {
+		return fmt.Errorf("BatchApplication.%s returned %d responses for %d txs", method, responses, txs)
+	}
+	return nil
 }
 
 //-------------------------------------------------------
@@ -49,7 +114,7 @@ func (BaseApplication) DeliverTx(tx []byte) ResponseDeliverTx {
 	return ResponseDeliverTx{Code: CodeTypeOK}
 }
 
-func (BaseApplication) CheckTx(tx []byte) ResponseCheckTx {
+func (BaseApplication) CheckTx(req RequestCheckTx) ResponseCheckTx {
 	return ResponseCheckTx{Code: CodeTypeOK}
 }
 
@@ -73,8 +138,47 @@ func (BaseApplication) EndBlock(req RequestEndBlock) ResponseEndBlock {
 	return ResponseEndBlock{}
 }
 
+// PrepareProposal keeps the txs in order, as long as their total size
+// is within MaxTxBytes, if positive.
+func (BaseApplication) PrepareProposal(req RequestPrepareProposal) ResponsePrepareProposal {
+	var txs [][]byte
+	var size int64
+	for _, tx := range req.Txs {
+		size += int64(len(tx))
+		if req.MaxTxBytes > 0 && size > req.MaxTxBytes {
+			break
+		}
+		txs = append(txs, tx)
+	}
+	return ResponsePrepareProposal{Txs: txs}
+}
+
+func (BaseApplication) ProcessProposal(req RequestProcessProposal) ResponseProcessProposal {
+	return ResponseProcessProposal{Status: ResponseProcessProposal_Accept}
+}
+
+func (BaseApplication) ListSnapshots(req RequestListSnapshots) ResponseListSnapshots {
+	return ResponseListSnapshots{}
+}
+
+func (BaseApplication) OfferSnapshot(req RequestOfferSnapshot) ResponseOfferSnapshot {
+	return ResponseOfferSnapshot{Result: ResponseOfferSnapshot_Abort}
+}
+
+func (BaseApplication) LoadSnapshotChunk(req RequestLoadSnapshotChunk) ResponseLoadSnapshotChunk {
+	return ResponseLoadSnapshotChunk{}
+}
+
+func (BaseApplication) ApplySnapshotChunk(req RequestApplySnapshotChunk) ResponseApplySnapshotChunk {
+	return ResponseApplySnapshotChunk{Result: ResponseApplySnapshotChunk_Abort}
+}
+
 //-------------------------------------------------------
 
+// ABCIApplicationServiceName is the fully qualified name of the ABCIApplication
+// gRPC service, as reported by the gRPC health service.
+const ABCIApplicationServiceName = "types.ABCIApplication"
+
 // GRPCApplication is a GRPC wrapper for Application
 type GRPCApplication struct {
 	app Application
@@ -94,6 +198,7 @@ func (app *GRPCApplication) Flush(ctx context.Context, req *RequestFlush) (*Resp
 
 func (app *GRPCApplication) Info(ctx context.Context, req *RequestInfo) (*ResponseInfo, error) {
 	res := app.app.Info(*req)
+	res.AbciVersion = version.ABCIVersion
 	return &res, nil
 }
 
@@ -108,7 +213,7 @@ func (app *GRPCApplication) DeliverTx(ctx context.Context, req *RequestDeliverTx
 }
 
 func (app *GRPCApplication) CheckTx(ctx context.Context, req *RequestCheckTx) (*ResponseCheckTx, error) {
-	res := app.app.CheckTx(req.Tx)
+	res := app.app.CheckTx(*req)
 	return &res, nil
 }
 
@@ -136,3 +241,49 @@ func (app *GRPCApplication) EndBlock(ctx context.Context, req *RequestEndBlock)
 	res := app.app.EndBlock(*req)
 	return &res, nil
 }
+
+func (app *GRPCApplication) DeliverTxBatch(ctx context.Context, req *RequestDeliverTxBatch) (*ResponseDeliverTxBatch, error) {
+	res, err := DeliverTxBatch(app.app, *req)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (app *GRPCApplication) CheckTxBatch(ctx context.Context, req *RequestCheckTxBatch) (*ResponseCheckTxBatch, error) {
+	res, err := CheckTxBatch(app.app, *req)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (app *GRPCApplication) ListSnapshots(ctx context.Context, req *RequestListSnapshots) (*ResponseListSnapshots, error) {
+	res := app.app.ListSnapshots(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) OfferSnapshot(ctx context.Context, req *RequestOfferSnapshot) (*ResponseOfferSnapshot, error) {
+	res := app.app.OfferSnapshot(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) LoadSnapshotChunk(ctx context.Context, req *RequestLoadSnapshotChunk) (*ResponseLoadSnapshotChunk, error) {
+	res := app.app.LoadSnapshotChunk(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) ApplySnapshotChunk(ctx context.Context, req *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error) {
+	res := app.app.ApplySnapshotChunk(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) PrepareProposal(ctx context.Context, req *RequestPrepareProposal) (*ResponsePrepareProposal, error) {
+	res := app.app.PrepareProposal(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) ProcessProposal(ctx context.Context, req *RequestProcessProposal) (*ResponseProcessProposal, error) {
+	res := app.app.ProcessProposal(*req)
+	return &res, nil
+}
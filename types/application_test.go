@@ -0,0 +1,90 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// batchApp answers a batch with one response per tx, telling
+// whether it went through the batch method.
+type batchApp struct {
+	BaseApplication
+}
+
+func (batchApp) DeliverTxBatch(req RequestDeliverTxBatch) []*ResponseDeliverTx {
+	responses := make([]*ResponseDeliverTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		responses[i] = &ResponseDeliverTx{Data: tx, Info: "batch"}
+	}
+	return responses
+}
+
+func (batchApp) CheckTxBatch(req RequestCheckTxBatch) []*ResponseCheckTx {
+	responses := make([]*ResponseCheckTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		responses[i] = &ResponseCheckTx{Data: tx, Info: "batch"}
+	}
+	return responses
+}
+
+func TestTxBatch(t *testing.T) {
+	txs := [][]byte{[]byte("a"), []byte("b")}
+
+	// fan out to the Application methods
+	deliverRes, err := DeliverTxBatch(NewBaseApplication(), RequestDeliverTxBatch{Txs: txs})
+	assert.Nil(t, err)
+	assert.Equal(t, []*ResponseDeliverTx{{Code: CodeTypeOK}, {Code: CodeTypeOK}}, deliverRes.Responses)
+	checkRes, err := CheckTxBatch(NewBaseApplication(), RequestCheckTxBatch{Txs: txs})
+	assert.Nil(t, err)
+	assert.Equal(t, []*ResponseCheckTx{{Code: CodeTypeOK}, {Code: CodeTypeOK}}, checkRes.Responses)
+
+	// use the BatchApplication methods
+	deliverRes, err = DeliverTxBatch(batchApp{}, RequestDeliverTxBatch{Txs: txs})
+	assert.Nil(t, err)
+	assert.Equal(t, []*ResponseDeliverTx{{Data: txs[0], Info: "batch"}, {Data: txs[1], Info: "batch"}},
+		deliverRes.Responses)
+	checkRes, err = CheckTxBatch(batchApp{}, RequestCheckTxBatch{Txs: txs})
+	assert.Nil(t, err)
+	assert.Equal(t, []*ResponseCheckTx{{Data: txs[0], Info: "batch"}, {Data: txs[1], Info: "batch"}},
+		checkRes.Responses)
+}
+
+// shortBatchApp drops the last response of a batch.
+type shortBatchApp struct {
+	batchApp
+}
+
+func (app shortBatchApp) DeliverTxBatch(req RequestDeliverTxBatch) []*ResponseDeliverTx {
+	responses := app.batchApp.DeliverTxBatch(req)
+	return responses[:len(responses)-1]
+}
+
+func (app shortBatchApp) CheckTxBatch(req RequestCheckTxBatch) []*ResponseCheckTx {
+	responses := app.batchApp.CheckTxBatch(req)
+	return responses[:len(responses)-1]
+}
+
+func TestTxBatchLength(t *testing.T) {
+	txs := [][]byte{[]byte("a"), []byte("b")}
+	deliverRes, err := DeliverTxBatch(shortBatchApp{}, RequestDeliverTxBatch{Txs: txs})
+	assert.NotNil(t, err)
+	assert.Len(t, deliverRes.Responses, 1)
+	_, err = CheckTxBatch(shortBatchApp{}, RequestCheckTxBatch{Txs: txs})
+	assert.EqualError(t, err, "BatchApplication.CheckTxBatch returned 1 responses for 2 txs")
+}
+
+func TestPrepareProposal(t *testing.T) {
+	txs := [][]byte{[]byte("ab"), []byte("cd"), []byte("e"), []byte("f")}
+	app := NewBaseApplication()
+
+	// the txs are kept in order, up to the byte limit
+	res := app.PrepareProposal(RequestPrepareProposal{MaxTxBytes: 4, Txs: txs})
+	assert.Equal(t, txs[:2], res.Txs)
+	res = app.PrepareProposal(RequestPrepareProposal{MaxTxBytes: 3, Txs: txs})
+	assert.Equal(t, txs[:1], res.Txs)
+	res = app.PrepareProposal(RequestPrepareProposal{Txs: txs})
+	assert.Equal(t, txs, res.Txs)
+
+	assert.Equal(t, ResponseProcessProposal_Accept, app.ProcessProposal(RequestProcessProposal{Txs: txs}).Status)
+}
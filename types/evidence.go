@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+)
+
+// Well-known evidence types, for Evidence.Type
+const (
+	EvidenceTypeDuplicateVote = "duplicate/vote" // signed conflicting votes at the same height and round
+	EvidenceTypeAmnesiaVote   = "amnesia/vote"   // voted for a block other than the one it was locked on
+	EvidenceTypeLunaticHeader = "lunatic/header" // signed a header with invalid state
+)
+
+// IsKnownEvidenceType returns true if typ is one of the EvidenceTypeXxx.
+func IsKnownEvidenceType(typ string) bool {
+	switch typ {
+	case EvidenceTypeDuplicateVote, EvidenceTypeAmnesiaVote, EvidenceTypeLunaticHeader:
+		return true
+	}
+	return false
+}
+
+// ValidateBasic checks the evidence is well-formed,
+// and that the power of the validator is consistent with the total.
+func (ev Evidence) ValidateBasic() error {
+	switch {
+	case ev.Type == "":
+		return fmt.Errorf("Evidence has no type")
+	case ev.Height <= 0:
+		return fmt.Errorf("Evidence has invalid height %d", ev.Height)
+	case len(ev.Validator.PubKey.Data) == 0:
+		return fmt.Errorf("Evidence has no validator pubkey")
+	case ev.Validator.Power < 0:
+		return fmt.Errorf("Evidence validator has negative power %d", ev.Validator.Power)
+	case ev.TotalVotingPower <= 0:
+		return fmt.Errorf("Evidence has invalid total voting power %d", ev.TotalVotingPower)
+	case ev.Validator.Power > ev.TotalVotingPower:
+		return fmt.Errorf("Evidence validator power %d is greater than the total voting power %d",
+			ev.Validator.Power, ev.TotalVotingPower)
+	}
+	return nil
+}
+
+// ValidateAge checks the evidence is from before the block at height and time,
+// and no older than maxAgeBlocks and maxAgeSeconds, if positive.
+func (ev Evidence) ValidateAge(height, time, maxAgeBlocks, maxAgeSeconds int64) error {
+	switch {
+	case ev.Height > height:
+		return fmt.Errorf("Evidence from height %d is from after height %d", ev.Height, height)
+	case ev.Time > time:
+		return fmt.Errorf("Evidence from time %d is from after time %d", ev.Time, time)
+	case maxAgeBlocks > 0 && height-ev.Height > maxAgeBlocks:
+		return fmt.Errorf("Evidence from height %d is older than %d blocks at height %d",
+			ev.Height, maxAgeBlocks, height)
+	case maxAgeSeconds > 0 && time-ev.Time > maxAgeSeconds:
+		return fmt.Errorf("Evidence from time %d is older than %d seconds at time %d",
+			ev.Time, maxAgeSeconds, time)
+	}
+	return nil
+}
+
+// ValidateEvidence checks each evidence with ValidateBasic,
+// and that all the evidence from the same height has the same total voting power.
+func ValidateEvidence(evidence []Evidence) error {
+	totals := make(map[int64]int64)
+	for _, ev := range evidence {
+		if err := ev.ValidateBasic(); err != nil {
+			return err
+		}
+		total, ok := totals[ev.Height]
+		if ok && total != ev.TotalVotingPower {
+			return fmt.Errorf("Evidence from height %d has total voting powers %d and %d",
+				ev.Height, total, ev.TotalVotingPower)
+		}
+		totals[ev.Height] = ev.TotalVotingPower
+	}
+	return nil
+}
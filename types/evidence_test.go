@@ -0,0 +1,65 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeEvidence(height, time, power, total int64) Evidence {
+	return Evidence{
+		Type:             EvidenceTypeDuplicateVote,
+		Validator:        Ed25519Validator([]byte("pubkey"), power),
+		Height:           height,
+		Time:             time,
+		TotalVotingPower: total,
+	}
+}
+
+func TestEvidenceValidateBasic(t *testing.T) {
+	assert.Nil(t, makeEvidence(1, 10, 5, 10).ValidateBasic())
+	assert.Nil(t, makeEvidence(1, 10, 0, 10).ValidateBasic())
+
+	ev := makeEvidence(1, 10, 5, 10)
+	ev.Type = ""
+	assert.NotNil(t, ev.ValidateBasic(), "no type")
+	ev = makeEvidence(1, 10, 5, 10)
+	ev.Validator.PubKey.Data = nil
+	assert.NotNil(t, ev.ValidateBasic(), "no pubkey")
+	assert.NotNil(t, makeEvidence(0, 10, 5, 10).ValidateBasic(), "zero height")
+	assert.NotNil(t, makeEvidence(1, 10, -1, 10).ValidateBasic(), "negative power")
+	assert.NotNil(t, makeEvidence(1, 10, 5, 0).ValidateBasic(), "zero total")
+	assert.NotNil(t, makeEvidence(1, 10, 11, 10).ValidateBasic(), "power greater than total")
+
+	assert.True(t, IsKnownEvidenceType(EvidenceTypeDuplicateVote))
+	assert.False(t, IsKnownEvidenceType("duplicate/foo"))
+}
+
+func TestEvidenceValidateAge(t *testing.T) {
+	ev := makeEvidence(10, 100, 5, 10)
+	assert.Nil(t, ev.ValidateAge(10, 100, 0, 0))
+	assert.Nil(t, ev.ValidateAge(15, 150, 5, 50))
+	assert.Nil(t, ev.ValidateAge(1000, 10000, 0, 0), "no max age")
+
+	assert.NotNil(t, ev.ValidateAge(9, 100, 0, 0), "from a later height")
+	assert.NotNil(t, ev.ValidateAge(10, 99, 0, 0), "from a later time")
+	assert.NotNil(t, ev.ValidateAge(16, 150, 5, 50), "too many blocks ago")
+	assert.NotNil(t, ev.ValidateAge(15, 151, 5, 50), "too many seconds ago")
+}
+
+func TestValidateEvidence(t *testing.T) {
+	assert.Nil(t, ValidateEvidence(nil))
+	assert.Nil(t, ValidateEvidence([]Evidence{
+		makeEvidence(1, 10, 5, 10),
+		makeEvidence(1, 10, 3, 10),
+		makeEvidence(2, 20, 3, 20),
+	}))
+	assert.NotNil(t, ValidateEvidence([]Evidence{
+		makeEvidence(1, 10, 5, 10),
+		makeEvidence(1, 10, 3, 20),
+	}), "inconsistent total voting power")
+	assert.NotNil(t, ValidateEvidence([]Evidence{
+		makeEvidence(1, 10, 5, 10),
+		makeEvidence(0, 10, 3, 10),
+	}), "invalid evidence")
+}
@@ -0,0 +1,112 @@
+//go:build go1.18
+// +build go1.18
+
+package types_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/abci/tests/fuzz"
+	"github.com/tendermint/abci/types"
+)
+
+// seedRequests returns the requests of the seed corpus
+func seedRequests(f *testing.F) []*types.Request {
+	seeds, err := fuzz.SeedRequests(filepath.Join("..", fuzz.SeedDir))
+	if err != nil {
+		f.Fatal(err)
+	}
+	var reqs []*types.Request
+	for _, seed := range seeds {
+		reqs = append(reqs, seed...)
+	}
+	if len(reqs) == 0 {
+		f.Fatal("no seed requests")
+	}
+	return reqs
+}
+
+func varint(i int64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutVarint(buf, i)]
+}
+
+func FuzzReadMessage(f *testing.F) {
+	for _, req := range seedRequests(f) {
+		buf := new(bytes.Buffer)
+		if err := types.WriteMessage(req, buf); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes())
+	}
+	// length edge cases
+	f.Add([]byte{})
+	f.Add(varint(0))
+	f.Add(varint(-1))
+	f.Add(varint(-1 << 62))
+	f.Add(append(varint(5), 0x0a))                                                  // short
+	f.Add(varint(104857601))                                                        // over the max size
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}) // varint overflow
+	f.Add([]byte{0x80})                                                             // truncated varint
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		req := new(types.Request)
+		if err := types.ReadMessage(bytes.NewReader(data), req); err != nil {
+			return
+		}
+		// a decoded message reads back the same
+		buf := new(bytes.Buffer)
+		if err := types.WriteMessage(req, buf); err != nil {
+			t.Fatalf("Error writing %v: %v", req, err)
+		}
+		req2 := new(types.Request)
+		if err := types.ReadMessage(bufio.NewReader(buf), req2); err != nil {
+			t.Fatalf("Error reading back %v: %v", req, err)
+		}
+		if !proto.Equal(req, req2) {
+			t.Fatalf("Read back %v as %v", req, req2)
+		}
+	})
+}
+
+func FuzzRequestRoundTrip(f *testing.F) {
+	for _, req := range seedRequests(f) {
+		bz, err := proto.Marshal(req)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(bz)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		req := new(types.Request)
+		if err := proto.Unmarshal(data, req); err != nil {
+			return
+		}
+		bz, err := proto.Marshal(req)
+		if err != nil {
+			t.Fatalf("Error marshaling %v: %v", req, err)
+		}
+		req2 := new(types.Request)
+		if err := proto.Unmarshal(bz, req2); err != nil {
+			t.Fatalf("Error unmarshaling %v: %v", req, err)
+		}
+		// the oneof value keeps its type
+		if req.Value != nil && req2.Value == nil {
+			t.Fatalf("Lost the value of %v", req)
+		}
+		bz2, err := proto.Marshal(req2)
+		if err != nil {
+			t.Fatalf("Error marshaling %v: %v", req2, err)
+		}
+		if !bytes.Equal(bz, bz2) {
+			t.Fatalf("Marshaled %v as 0x%X, then 0x%X", req, bz, bz2)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// MaxGasUnlimited is the MaxGas of blocks and txs without a gas limit.
+const MaxGasUnlimited int64 = -1
+
+// DefaultConsensusParams returns the params Tendermint uses by default.
+func DefaultConsensusParams() *ConsensusParams {
+	return &ConsensusParams{
+		BlockSize: &BlockSize{
+			MaxBytes: 22020096, // 21MB
+			MaxTxs:   100000,
+			MaxGas:   MaxGasUnlimited,
+		},
+		TxSize: &TxSize{
+			MaxBytes: 10240, // 10kB
+			MaxGas:   MaxGasUnlimited,
+		},
+		BlockGossip: &BlockGossip{
+			BlockPartSizeBytes: 65536, // 64kB
+		},
+	}
+}
+
+// Validate checks the params are complete and consistent:
+// the limits are positive (MaxGasUnlimited for no gas limit),
+// and a tx fits in a block. A zero field is unset, so it is rejected.
+func (params *ConsensusParams) Validate() error {
+	blockSize, txSize, blockGossip := params.GetBlockSize(), params.GetTxSize(), params.GetBlockGossip()
+	switch {
+	case blockSize == nil:
+		return fmt.Errorf("ConsensusParams has no BlockSize")
+	case txSize == nil:
+		return fmt.Errorf("ConsensusParams has no TxSize")
+	case blockGossip == nil:
+		return fmt.Errorf("ConsensusParams has no BlockGossip")
+	case blockSize.MaxBytes <= 0:
+		return fmt.Errorf("BlockSize.MaxBytes must be positive, got %d", blockSize.MaxBytes)
+	case blockSize.MaxTxs <= 0:
+		return fmt.Errorf("BlockSize.MaxTxs must be positive, got %d", blockSize.MaxTxs)
+	case !validMaxGas(blockSize.MaxGas):
+		return fmt.Errorf("BlockSize.MaxGas must be positive or %d, got %d", MaxGasUnlimited, blockSize.MaxGas)
+	case txSize.MaxBytes <= 0:
+		return fmt.Errorf("TxSize.MaxBytes must be positive, got %d", txSize.MaxBytes)
+	case !validMaxGas(txSize.MaxGas):
+		return fmt.Errorf("TxSize.MaxGas must be positive or %d, got %d", MaxGasUnlimited, txSize.MaxGas)
+	case txSize.MaxBytes > blockSize.MaxBytes:
+		return fmt.Errorf("TxSize.MaxBytes %d is greater than BlockSize.MaxBytes %d",
+			txSize.MaxBytes, blockSize.MaxBytes)
+	case blockSize.MaxGas != MaxGasUnlimited && txSize.MaxGas > blockSize.MaxGas:
+		return fmt.Errorf("TxSize.MaxGas %d is greater than BlockSize.MaxGas %d",
+			txSize.MaxGas, blockSize.MaxGas)
+	case blockGossip.BlockPartSizeBytes <= 0:
+		return fmt.Errorf("BlockGossip.BlockPartSizeBytes must be positive, got %d",
+			blockGossip.BlockPartSizeBytes)
+	}
+	return nil
+}
+
+func validMaxGas(maxGas int64) bool {
+	return maxGas > 0 || maxGas == MaxGasUnlimited
+}
+
+// Update returns a copy of the params with the updates applied.
+// Only the non-zero fields of the updates are changed, nil params or updates
+// are treated as empty. The result should be checked with Validate.
+func (params *ConsensusParams) Update(updates *ConsensusParams) *ConsensusParams {
+	res := &ConsensusParams{}
+	if bs := params.GetBlockSize(); bs != nil {
+		copyBS := *bs
+		res.BlockSize = &copyBS
+	}
+	if ts := params.GetTxSize(); ts != nil {
+		copyTS := *ts
+		res.TxSize = &copyTS
+	}
+	if bg := params.GetBlockGossip(); bg != nil {
+		copyBG := *bg
+		res.BlockGossip = &copyBG
+	}
+
+	if bs := updates.GetBlockSize(); bs != nil {
+		if res.BlockSize == nil {
+			res.BlockSize = &BlockSize{}
+		}
+		if bs.MaxBytes != 0 {
+			res.BlockSize.MaxBytes = bs.MaxBytes
+		}
+		if bs.MaxTxs != 0 {
+			res.BlockSize.MaxTxs = bs.MaxTxs
+		}
+		if bs.MaxGas != 0 {
+			res.BlockSize.MaxGas = bs.MaxGas
+		}
+	}
+	if ts := updates.GetTxSize(); ts != nil {
+		if res.TxSize == nil {
+			res.TxSize = &TxSize{}
+		}
+		if ts.MaxBytes != 0 {
+			res.TxSize.MaxBytes = ts.MaxBytes
+		}
+		if ts.MaxGas != 0 {
+			res.TxSize.MaxGas = ts.MaxGas
+		}
+	}
+	if bg := updates.GetBlockGossip(); bg != nil {
+		if res.BlockGossip == nil {
+			res.BlockGossip = &BlockGossip{}
+		}
+		if bg.BlockPartSizeBytes != 0 {
+			res.BlockGossip.BlockPartSizeBytes = bg.BlockPartSizeBytes
+		}
+	}
+	return res
+}
+
+// Hash returns the sha256 hash of the fields of the params,
+// in a fixed order and encoding, nil fields hashing as zero.
+// It doesn't depend on the proto encoding.
+func (params *ConsensusParams) Hash() []byte {
+	fields := []int64{
+		int64(params.GetBlockSize().GetMaxBytes()),
+		int64(params.GetBlockSize().GetMaxTxs()),
+		params.GetBlockSize().GetMaxGas(),
+		int64(params.GetTxSize().GetMaxBytes()),
+		params.GetTxSize().GetMaxGas(),
+		int64(params.GetBlockGossip().GetBlockPartSizeBytes()),
+	}
+	hasher := sha256.New()
+	buf := make([]byte, 8)
+	for _, field := range fields {
+		binary.BigEndian.PutUint64(buf, uint64(field))
+		hasher.Write(buf) // nolint: errcheck
+	}
+	return hasher.Sum(nil)
+}
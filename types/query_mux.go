@@ -0,0 +1,176 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cmn "github.com/tendermint/tmlibs/common"
+)
+
+// QueryPathsPath is the path of the query listing the paths of a QueryMux.
+const QueryPathsPath = "/_paths"
+
+// QueryHandler answers a query, given the parameters of the path.
+type QueryHandler func(req RequestQuery, params map[string]string) ResponseQuery
+
+type queryRoute struct {
+	pattern  string
+	segments []string
+	provable bool
+	handler  QueryHandler
+}
+
+// QueryMux answers queries with the handler registered for their path.
+// Patterns are paths whose segments can be parameters,
+// eg. "/store/{key}" matches "/store/abc" with params["key"] == "abc".
+// Literal segments take precedence over parameters,
+// and the leading and trailing slashes of paths are ignored.
+//
+// The QueryPathsPath query returns the JSON list of the patterns.
+type QueryMux struct {
+	routes []*queryRoute
+	height func() int64
+}
+
+func NewQueryMux() *QueryMux {
+	return &QueryMux{}
+}
+
+// SetHeight sets the function returning the last committed height.
+// The queries for another height are then rejected,
+// and the responses have the height set, if the handler didn't.
+func (mux *QueryMux) SetHeight(height func() int64) *QueryMux {
+	mux.height = height
+	return mux
+}
+
+// Handle registers the handler for the pattern.
+// The queries asking for a proof are rejected.
+func (mux *QueryMux) Handle(pattern string, handler QueryHandler) *QueryMux {
+	return mux.handle(pattern, false, handler)
+}
+
+// HandleProvable registers the handler for the pattern,
+// including the queries asking for a proof.
+func (mux *QueryMux) HandleProvable(pattern string, handler QueryHandler) *QueryMux {
+	return mux.handle(pattern, true, handler)
+}
+
+func (mux *QueryMux) handle(pattern string, provable bool, handler QueryHandler) *QueryMux {
+	segments := splitPath(pattern)
+	pattern = "/" + strings.Join(segments, "/")
+	if pattern == QueryPathsPath {
+		cmn.PanicSanity(cmn.Fmt("Query path %s is reserved", pattern))
+	}
+	for _, route := range mux.routes {
+		if route.pattern == pattern {
+			cmn.PanicSanity(cmn.Fmt("Query path %s already registered", pattern))
+		}
+	}
+	mux.routes = append(mux.routes, &queryRoute{
+		pattern:  pattern,
+		segments: segments,
+		provable: provable,
+		handler:  handler,
+	})
+	return mux
+}
+
+// Paths returns the registered patterns, in order.
+func (mux *QueryMux) Paths() []string {
+	paths := make([]string, len(mux.routes))
+	for i, route := range mux.routes {
+		paths[i] = route.pattern
+	}
+	return paths
+}
+
+// Query answers the query with the handler of its path.
+func (mux *QueryMux) Query(req RequestQuery) ResponseQuery {
+	if "/"+strings.Join(splitPath(req.Path), "/") == QueryPathsPath {
+		value, err := json.Marshal(mux.Paths())
+		if err != nil {
+			panic(err)
+		}
+		return ResponseQuery{Value: value}
+	}
+
+	route, params := mux.match(req.Path)
+	if route == nil {
+		return ResponseQuery{
+			Code: CodeTypeUnknownPath,
+			Log:  fmt.Sprintf("Unknown query path %q, see %s", req.Path, QueryPathsPath)}
+	}
+	if req.Prove && !route.provable {
+		return ResponseQuery{
+			Code: CodeTypeProofUnsupported,
+			Log:  fmt.Sprintf("Query path %s doesn't support proofs", route.pattern)}
+	}
+	var height int64
+	if mux.height != nil {
+		height = mux.height()
+		if req.Height != 0 && req.Height != height {
+			return ResponseQuery{
+				Code:   CodeTypeInvalidHeight,
+				Log:    fmt.Sprintf("Cannot query height %d, only the last height %d", req.Height, height),
+				Height: height}
+		}
+	}
+
+	res := route.handler(req, params)
+	if res.Height == 0 {
+		res.Height = height
+	}
+	return res
+}
+
+// match returns the route matching the path with the most literal segments,
+// and the params of the path.
+func (mux *QueryMux) match(path string) (match *queryRoute, params map[string]string) {
+	segments := splitPath(path)
+	bestLiterals := -1
+	for _, route := range mux.routes {
+		if len(route.segments) != len(segments) {
+			continue
+		}
+		literals := 0
+		matches := true
+		for i, segment := range route.segments {
+			if isQueryParam(segment) {
+				continue
+			}
+			if segment != segments[i] {
+				matches = false
+				break
+			}
+			literals++
+		}
+		if matches && literals > bestLiterals {
+			match, bestLiterals = route, literals
+		}
+	}
+	if match == nil {
+		return nil, nil
+	}
+	params = make(map[string]string)
+	for i, segment := range match.segments {
+		if isQueryParam(segment) {
+			params[segment[1:len(segment)-1]] = segments[i]
+		}
+	}
+	return match, params
+}
+
+func isQueryParam(segment string) bool {
+	return len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// splitPath returns the segments of the path, "" and "/" having none.
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queryHandler(name string) QueryHandler {
+	return func(req RequestQuery, params map[string]string) ResponseQuery {
+		value, err := json.Marshal(params)
+		if err != nil {
+			panic(err)
+		}
+		return ResponseQuery{Key: []byte(name), Value: value}
+	}
+}
+
+func TestQueryMuxMatch(t *testing.T) {
+	mux := NewQueryMux().
+		Handle("/", queryHandler("root")).
+		Handle("/store/{key}", queryHandler("key")).
+		Handle("/store/{key}/{field}", queryHandler("field")).
+		Handle("/store/_size", queryHandler("size"))
+
+	cases := []struct {
+		path   string
+		name   string
+		params string
+	}{
+		{"", "root", `{}`},
+		{"/", "root", `{}`},
+		{"/store/abc", "key", `{"key":"abc"}`},
+		{"store/abc/", "key", `{"key":"abc"}`},
+		{"/store/abc/def", "field", `{"field":"def","key":"abc"}`},
+		{"/store/_size", "size", `{}`},
+	}
+	for _, c := range cases {
+		res := mux.Query(RequestQuery{Path: c.path})
+		require.True(t, res.IsOK(), c.path)
+		assert.Equal(t, c.name, string(res.Key), c.path)
+		assert.Equal(t, c.params, string(res.Value), c.path)
+	}
+
+	for _, path := range []string{"/store", "/store/a/b/c", "/other"} {
+		res := mux.Query(RequestQuery{Path: path})
+		assert.Equal(t, CodeTypeUnknownPath, res.Code, path)
+	}
+
+	assert.Panics(t, func() { mux.Handle("store/{key}/", queryHandler("again")) })
+	assert.Panics(t, func() { mux.Handle(QueryPathsPath, queryHandler("paths")) })
+}
+
+func TestQueryMuxPaths(t *testing.T) {
+	mux := NewQueryMux().
+		Handle("/store/{key}", queryHandler("key")).
+		Handle("hash", queryHandler("hash"))
+
+	assert.Equal(t, []string{"/store/{key}", "/hash"}, mux.Paths())
+	res := mux.Query(RequestQuery{Path: QueryPathsPath})
+	require.True(t, res.IsOK())
+	assert.Equal(t, `["/store/{key}","/hash"]`, string(res.Value))
+}
+
+func TestQueryMuxHeightAndProve(t *testing.T) {
+	height := int64(5)
+	mux := NewQueryMux().
+		SetHeight(func() int64 { return height }).
+		Handle("/a", queryHandler("a")).
+		HandleProvable("/b", queryHandler("b"))
+
+	res := mux.Query(RequestQuery{Path: "/a"})
+	require.True(t, res.IsOK())
+	assert.EqualValues(t, 5, res.Height, "last height by default")
+	res = mux.Query(RequestQuery{Path: "/a", Height: 5})
+	assert.True(t, res.IsOK())
+	res = mux.Query(RequestQuery{Path: "/a", Height: 4})
+	assert.Equal(t, CodeTypeInvalidHeight, res.Code)
+
+	assert.Equal(t, CodeTypeProofUnsupported, mux.Query(RequestQuery{Path: "/a", Prove: true}).Code)
+	assert.True(t, mux.Query(RequestQuery{Path: "/b", Prove: true}).IsOK())
+
+	// without a height, any is passed to the handler
+	mux = NewQueryMux().Handle("/a", queryHandler("a"))
+	res = mux.Query(RequestQuery{Path: "/a", Height: 4})
+	assert.True(t, res.IsOK())
+	assert.EqualValues(t, 0, res.Height)
+}
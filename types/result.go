@@ -11,6 +11,21 @@ const (
 	CodeTypeOK uint32 = 0
 )
 
+// Codes reserved for the responses of the abci packages.
+// Apps should not use them for their own errors.
+const (
+	// CodeTypeUnknownPath is returned by the QueryMux for a path without a handler.
+	CodeTypeUnknownPath uint32 = 250
+	// CodeTypeInvalidHeight is returned by the QueryMux for a height it can't answer at.
+	CodeTypeInvalidHeight uint32 = 251
+	// CodeTypeProofUnsupported is returned by the QueryMux for a proof it can't give.
+	CodeTypeProofUnsupported uint32 = 252
+	// CodeTypeOutOfGas is returned by the gas package for txs running out of gas.
+	CodeTypeOutOfGas uint32 = 254
+	// CodeTypeUnknownTx is returned by the router for txs of a type without a route.
+	CodeTypeUnknownTx uint32 = 255
+)
+
 // IsOK returns true if Code is OK.
 func (r ResponseCheckTx) IsOK() bool {
 	return r.Code == CodeTypeOK
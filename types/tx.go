@@ -0,0 +1,16 @@
+package types
+
+import (
+	"crypto/sha256"
+)
+
+// TxHashKey is the tag of the hex hash of a tx,
+// in the tx indexer and in the DeliverTx events of the event bus.
+const TxHashKey = "tx.hash"
+
+// TxHash returns the sha256 hash of the tx, the key of its result in the
+// tx indexer.
+func TxHash(tx []byte) []byte {
+	hash := sha256.Sum256(tx)
+	return hash[:]
+}
@@ -20,6 +20,14 @@ It has these top-level messages:
 	RequestDeliverTx
 	RequestEndBlock
 	RequestCommit
+	RequestDeliverTxBatch
+	RequestCheckTxBatch
+	RequestListSnapshots
+	RequestOfferSnapshot
+	RequestLoadSnapshotChunk
+	RequestApplySnapshotChunk
+	RequestPrepareProposal
+	RequestProcessProposal
 	Response
 	ResponseException
 	ResponseEcho
@@ -33,6 +41,14 @@ It has these top-level messages:
 	ResponseDeliverTx
 	ResponseEndBlock
 	ResponseCommit
+	ResponseDeliverTxBatch
+	ResponseCheckTxBatch
+	ResponseListSnapshots
+	ResponseOfferSnapshot
+	ResponseLoadSnapshotChunk
+	ResponseApplySnapshotChunk
+	ResponsePrepareProposal
+	ResponseProcessProposal
 	ConsensusParams
 	BlockSize
 	TxSize
@@ -42,6 +58,7 @@ It has these top-level messages:
 	SigningValidator
 	PubKey
 	Evidence
+	Snapshot
 */
 //nolint: gas
 package types
@@ -66,6 +83,150 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+// Type of a CheckTx: a tx submitted to the mempool is New,
+// a tx checked again after a block is committed is a Recheck
+type CheckTxType int32
+
+const (
+	CheckTxType_New     CheckTxType = 0
+	CheckTxType_Recheck CheckTxType = 1
+)
+
+var CheckTxType_name = map[int32]string{
+	0: "New",
+	1: "Recheck",
+}
+var CheckTxType_value = map[string]int32{
+	"New":     0,
+	"Recheck": 1,
+}
+
+func (x CheckTxType) String() string {
+	return proto.EnumName(CheckTxType_name, int32(x))
+}
+func (CheckTxType) EnumDescriptor() ([]byte, []int) { return fileDescriptorTypes, []int{0} }
+
+// Hint for a mempool that must evict txs to make room
+type EvictionHint int32
+
+const (
+	EvictionHint_Normal    EvictionHint = 0
+	EvictionHint_Evictable EvictionHint = 1
+	EvictionHint_Pinned    EvictionHint = 2
+)
+
+var EvictionHint_name = map[int32]string{
+	0: "Normal",
+	1: "Evictable",
+	2: "Pinned",
+}
+var EvictionHint_value = map[string]int32{
+	"Normal":    0,
+	"Evictable": 1,
+	"Pinned":    2,
+}
+
+func (x EvictionHint) String() string {
+	return proto.EnumName(EvictionHint_name, int32(x))
+}
+func (EvictionHint) EnumDescriptor() ([]byte, []int) { return fileDescriptorTypes, []int{1} }
+
+type ResponseOfferSnapshot_Result int32
+
+const (
+	ResponseOfferSnapshot_Unknown      ResponseOfferSnapshot_Result = 0
+	ResponseOfferSnapshot_Accept       ResponseOfferSnapshot_Result = 1
+	ResponseOfferSnapshot_Abort        ResponseOfferSnapshot_Result = 2
+	ResponseOfferSnapshot_Reject       ResponseOfferSnapshot_Result = 3
+	ResponseOfferSnapshot_RejectFormat ResponseOfferSnapshot_Result = 4
+	ResponseOfferSnapshot_RejectSender ResponseOfferSnapshot_Result = 5
+)
+
+var ResponseOfferSnapshot_Result_name = map[int32]string{
+	0: "Unknown",
+	1: "Accept",
+	2: "Abort",
+	3: "Reject",
+	4: "RejectFormat",
+	5: "RejectSender",
+}
+var ResponseOfferSnapshot_Result_value = map[string]int32{
+	"Unknown":      0,
+	"Accept":       1,
+	"Abort":        2,
+	"Reject":       3,
+	"RejectFormat": 4,
+	"RejectSender": 5,
+}
+
+func (x ResponseOfferSnapshot_Result) String() string {
+	return proto.EnumName(ResponseOfferSnapshot_Result_name, int32(x))
+}
+func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorTypes, []int{36, 0}
+}
+
+type ResponseApplySnapshotChunk_Result int32
+
+const (
+	ResponseApplySnapshotChunk_Unknown        ResponseApplySnapshotChunk_Result = 0
+	ResponseApplySnapshotChunk_Accept         ResponseApplySnapshotChunk_Result = 1
+	ResponseApplySnapshotChunk_Abort          ResponseApplySnapshotChunk_Result = 2
+	ResponseApplySnapshotChunk_Retry          ResponseApplySnapshotChunk_Result = 3
+	ResponseApplySnapshotChunk_RetrySnapshot  ResponseApplySnapshotChunk_Result = 4
+	ResponseApplySnapshotChunk_RejectSnapshot ResponseApplySnapshotChunk_Result = 5
+)
+
+var ResponseApplySnapshotChunk_Result_name = map[int32]string{
+	0: "Unknown",
+	1: "Accept",
+	2: "Abort",
+	3: "Retry",
+	4: "RetrySnapshot",
+	5: "RejectSnapshot",
+}
+var ResponseApplySnapshotChunk_Result_value = map[string]int32{
+	"Unknown":        0,
+	"Accept":         1,
+	"Abort":          2,
+	"Retry":          3,
+	"RetrySnapshot":  4,
+	"RejectSnapshot": 5,
+}
+
+func (x ResponseApplySnapshotChunk_Result) String() string {
+	return proto.EnumName(ResponseApplySnapshotChunk_Result_name, int32(x))
+}
+func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorTypes, []int{38, 0}
+}
+
+type ResponseProcessProposal_Status int32
+
+const (
+	ResponseProcessProposal_Unknown ResponseProcessProposal_Status = 0
+	ResponseProcessProposal_Accept  ResponseProcessProposal_Status = 1
+	ResponseProcessProposal_Reject  ResponseProcessProposal_Status = 2
+)
+
+var ResponseProcessProposal_Status_name = map[int32]string{
+	0: "Unknown",
+	1: "Accept",
+	2: "Reject",
+}
+var ResponseProcessProposal_Status_value = map[string]int32{
+	"Unknown": 0,
+	"Accept":  1,
+	"Reject":  2,
+}
+
+func (x ResponseProcessProposal_Status) String() string {
+	return proto.EnumName(ResponseProcessProposal_Status_name, int32(x))
+}
+func (ResponseProcessProposal_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorTypes, []int{40, 0}
+}
+
 type Request struct {
 	// Types that are valid to be assigned to Value:
 	//	*Request_Echo
@@ -79,6 +240,14 @@ type Request struct {
 	//	*Request_DeliverTx
 	//	*Request_EndBlock
 	//	*Request_Commit
+	//	*Request_DeliverTxBatch
+	//	*Request_CheckTxBatch
+	//	*Request_ListSnapshots
+	//	*Request_OfferSnapshot
+	//	*Request_LoadSnapshotChunk
+	//	*Request_ApplySnapshotChunk
+	//	*Request_PrepareProposal
+	//	*Request_ProcessProposal
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -124,18 +293,50 @@ type Request_EndBlock struct {
 type Request_Commit struct {
 	Commit *RequestCommit `protobuf:"bytes,12,opt,name=commit,oneof"`
 }
+type Request_DeliverTxBatch struct {
+	DeliverTxBatch *RequestDeliverTxBatch `protobuf:"bytes,20,opt,name=deliver_tx_batch,json=deliverTxBatch,oneof"`
+}
+type Request_CheckTxBatch struct {
+	CheckTxBatch *RequestCheckTxBatch `protobuf:"bytes,21,opt,name=check_tx_batch,json=checkTxBatch,oneof"`
+}
+type Request_ListSnapshots struct {
+	ListSnapshots *RequestListSnapshots `protobuf:"bytes,22,opt,name=list_snapshots,json=listSnapshots,oneof"`
+}
+type Request_OfferSnapshot struct {
+	OfferSnapshot *RequestOfferSnapshot `protobuf:"bytes,23,opt,name=offer_snapshot,json=offerSnapshot,oneof"`
+}
+type Request_LoadSnapshotChunk struct {
+	LoadSnapshotChunk *RequestLoadSnapshotChunk `protobuf:"bytes,24,opt,name=load_snapshot_chunk,json=loadSnapshotChunk,oneof"`
+}
+type Request_ApplySnapshotChunk struct {
+	ApplySnapshotChunk *RequestApplySnapshotChunk `protobuf:"bytes,25,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,oneof"`
+}
+type Request_PrepareProposal struct {
+	PrepareProposal *RequestPrepareProposal `protobuf:"bytes,26,opt,name=prepare_proposal,json=prepareProposal,oneof"`
+}
+type Request_ProcessProposal struct {
+	ProcessProposal *RequestProcessProposal `protobuf:"bytes,27,opt,name=process_proposal,json=processProposal,oneof"`
+}
 
-func (*Request_Echo) isRequest_Value()       {}
-func (*Request_Flush) isRequest_Value()      {}
-func (*Request_Info) isRequest_Value()       {}
-func (*Request_SetOption) isRequest_Value()  {}
-func (*Request_InitChain) isRequest_Value()  {}
-func (*Request_Query) isRequest_Value()      {}
-func (*Request_BeginBlock) isRequest_Value() {}
-func (*Request_CheckTx) isRequest_Value()    {}
-func (*Request_DeliverTx) isRequest_Value()  {}
-func (*Request_EndBlock) isRequest_Value()   {}
-func (*Request_Commit) isRequest_Value()     {}
+func (*Request_Echo) isRequest_Value()               {}
+func (*Request_Flush) isRequest_Value()              {}
+func (*Request_Info) isRequest_Value()               {}
+func (*Request_SetOption) isRequest_Value()          {}
+func (*Request_InitChain) isRequest_Value()          {}
+func (*Request_Query) isRequest_Value()              {}
+func (*Request_BeginBlock) isRequest_Value()         {}
+func (*Request_CheckTx) isRequest_Value()            {}
+func (*Request_DeliverTx) isRequest_Value()          {}
+func (*Request_EndBlock) isRequest_Value()           {}
+func (*Request_Commit) isRequest_Value()             {}
+func (*Request_DeliverTxBatch) isRequest_Value()     {}
+func (*Request_CheckTxBatch) isRequest_Value()       {}
+func (*Request_ListSnapshots) isRequest_Value()      {}
+func (*Request_OfferSnapshot) isRequest_Value()      {}
+func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
+func (*Request_ApplySnapshotChunk) isRequest_Value() {}
+func (*Request_PrepareProposal) isRequest_Value()    {}
+func (*Request_ProcessProposal) isRequest_Value()    {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -221,6 +422,62 @@ func (m *Request) GetCommit() *RequestCommit {
 	return nil
 }
 
+func (m *Request) GetDeliverTxBatch() *RequestDeliverTxBatch {
+	if x, ok := m.GetValue().(*Request_DeliverTxBatch); ok {
+		return x.DeliverTxBatch
+	}
+	return nil
+}
+
+func (m *Request) GetCheckTxBatch() *RequestCheckTxBatch {
+	if x, ok := m.GetValue().(*Request_CheckTxBatch); ok {
+		return x.CheckTxBatch
+	}
+	return nil
+}
+
+func (m *Request) GetListSnapshots() *RequestListSnapshots {
+	if x, ok := m.GetValue().(*Request_ListSnapshots); ok {
+		return x.ListSnapshots
+	}
+	return nil
+}
+
+func (m *Request) GetOfferSnapshot() *RequestOfferSnapshot {
+	if x, ok := m.GetValue().(*Request_OfferSnapshot); ok {
+		return x.OfferSnapshot
+	}
+	return nil
+}
+
+func (m *Request) GetLoadSnapshotChunk() *RequestLoadSnapshotChunk {
+	if x, ok := m.GetValue().(*Request_LoadSnapshotChunk); ok {
+		return x.LoadSnapshotChunk
+	}
+	return nil
+}
+
+func (m *Request) GetApplySnapshotChunk() *RequestApplySnapshotChunk {
+	if x, ok := m.GetValue().(*Request_ApplySnapshotChunk); ok {
+		return x.ApplySnapshotChunk
+	}
+	return nil
+}
+
+func (m *Request) GetPrepareProposal() *RequestPrepareProposal {
+	if x, ok := m.GetValue().(*Request_PrepareProposal); ok {
+		return x.PrepareProposal
+	}
+	return nil
+}
+
+func (m *Request) GetProcessProposal() *RequestProcessProposal {
+	if x, ok := m.GetValue().(*Request_ProcessProposal); ok {
+		return x.ProcessProposal
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Request) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Request_OneofMarshaler, _Request_OneofUnmarshaler, _Request_OneofSizer, []interface{}{
@@ -235,6 +492,14 @@ func (*Request) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error
 		(*Request_DeliverTx)(nil),
 		(*Request_EndBlock)(nil),
 		(*Request_Commit)(nil),
+		(*Request_DeliverTxBatch)(nil),
+		(*Request_CheckTxBatch)(nil),
+		(*Request_ListSnapshots)(nil),
+		(*Request_OfferSnapshot)(nil),
+		(*Request_LoadSnapshotChunk)(nil),
+		(*Request_ApplySnapshotChunk)(nil),
+		(*Request_PrepareProposal)(nil),
+		(*Request_ProcessProposal)(nil),
 	}
 }
 
@@ -297,6 +562,46 @@ func _Request_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Commit); err != nil {
 			return err
 		}
+	case *Request_DeliverTxBatch:
+		_ = b.EncodeVarint(20<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DeliverTxBatch); err != nil {
+			return err
+		}
+	case *Request_CheckTxBatch:
+		_ = b.EncodeVarint(21<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CheckTxBatch); err != nil {
+			return err
+		}
+	case *Request_ListSnapshots:
+		_ = b.EncodeVarint(22<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ListSnapshots); err != nil {
+			return err
+		}
+	case *Request_OfferSnapshot:
+		_ = b.EncodeVarint(23<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OfferSnapshot); err != nil {
+			return err
+		}
+	case *Request_LoadSnapshotChunk:
+		_ = b.EncodeVarint(24<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LoadSnapshotChunk); err != nil {
+			return err
+		}
+	case *Request_ApplySnapshotChunk:
+		_ = b.EncodeVarint(25<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ApplySnapshotChunk); err != nil {
+			return err
+		}
+	case *Request_PrepareProposal:
+		_ = b.EncodeVarint(26<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PrepareProposal); err != nil {
+			return err
+		}
+	case *Request_ProcessProposal:
+		_ = b.EncodeVarint(27<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ProcessProposal); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Request.Value has unexpected type %T", x)
@@ -395,6 +700,70 @@ func _Request_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer
 		err := b.DecodeMessage(msg)
 		m.Value = &Request_Commit{msg}
 		return true, err
+	case 20: // value.deliver_tx_batch
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestDeliverTxBatch)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_DeliverTxBatch{msg}
+		return true, err
+	case 21: // value.check_tx_batch
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestCheckTxBatch)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_CheckTxBatch{msg}
+		return true, err
+	case 22: // value.list_snapshots
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestListSnapshots)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_ListSnapshots{msg}
+		return true, err
+	case 23: // value.offer_snapshot
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestOfferSnapshot)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_OfferSnapshot{msg}
+		return true, err
+	case 24: // value.load_snapshot_chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestLoadSnapshotChunk)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_LoadSnapshotChunk{msg}
+		return true, err
+	case 25: // value.apply_snapshot_chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestApplySnapshotChunk)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_ApplySnapshotChunk{msg}
+		return true, err
+	case 26: // value.prepare_proposal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestPrepareProposal)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_PrepareProposal{msg}
+		return true, err
+	case 27: // value.process_proposal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestProcessProposal)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_ProcessProposal{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -459,6 +828,46 @@ func _Request_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(12<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Request_DeliverTxBatch:
+		s := proto.Size(x.DeliverTxBatch)
+		n += proto.SizeVarint(20<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_CheckTxBatch:
+		s := proto.Size(x.CheckTxBatch)
+		n += proto.SizeVarint(21<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ListSnapshots:
+		s := proto.Size(x.ListSnapshots)
+		n += proto.SizeVarint(22<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_OfferSnapshot:
+		s := proto.Size(x.OfferSnapshot)
+		n += proto.SizeVarint(23<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_LoadSnapshotChunk:
+		s := proto.Size(x.LoadSnapshotChunk)
+		n += proto.SizeVarint(24<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ApplySnapshotChunk:
+		s := proto.Size(x.ApplySnapshotChunk)
+		n += proto.SizeVarint(25<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_PrepareProposal:
+		s := proto.Size(x.PrepareProposal)
+		n += proto.SizeVarint(26<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ProcessProposal:
+		s := proto.Size(x.ProcessProposal)
+		n += proto.SizeVarint(27<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -491,7 +900,9 @@ func (*RequestFlush) ProtoMessage()               {}
 func (*RequestFlush) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{2} }
 
 type RequestInfo struct {
-	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Version      string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	BlockVersion uint64 `protobuf:"varint,2,opt,name=block_version,json=blockVersion,proto3" json:"block_version,omitempty"`
+	AbciVersion  string `protobuf:"bytes,3,opt,name=abci_version,json=abciVersion,proto3" json:"abci_version,omitempty"`
 }
 
 func (m *RequestInfo) Reset()                    { *m = RequestInfo{} }
@@ -506,6 +917,20 @@ func (m *RequestInfo) GetVersion() string {
 	return ""
 }
 
+func (m *RequestInfo) GetBlockVersion() uint64 {
+	if m != nil {
+		return m.BlockVersion
+	}
+	return 0
+}
+
+func (m *RequestInfo) GetAbciVersion() string {
+	if m != nil {
+		return m.AbciVersion
+	}
+	return ""
+}
+
 // nondeterministic
 type RequestSetOption struct {
 	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -660,7 +1085,8 @@ func (m *RequestBeginBlock) GetByzantineValidators() []Evidence {
 }
 
 type RequestCheckTx struct {
-	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Tx   []byte      `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Type CheckTxType `protobuf:"varint,2,opt,name=type,proto3,enum=types.CheckTxType" json:"type,omitempty"`
 }
 
 func (m *RequestCheckTx) Reset()                    { *m = RequestCheckTx{} }
@@ -675,6 +1101,13 @@ func (m *RequestCheckTx) GetTx() []byte {
 	return nil
 }
 
+func (m *RequestCheckTx) GetType() CheckTxType {
+	if m != nil {
+		return m.Type
+	}
+	return CheckTxType_New
+}
+
 type RequestDeliverTx struct {
 	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
 }
@@ -710,11 +1143,209 @@ func (m *RequestEndBlock) GetHeight() int64 {
 type RequestCommit struct {
 }
 
-func (m *RequestCommit) Reset()                    { *m = RequestCommit{} }
-func (m *RequestCommit) String() string            { return proto.CompactTextString(m) }
-func (*RequestCommit) ProtoMessage()               {}
-func (*RequestCommit) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{11} }
-
+func (m *RequestCommit) Reset()                    { *m = RequestCommit{} }
+func (m *RequestCommit) String() string            { return proto.CompactTextString(m) }
+func (*RequestCommit) ProtoMessage()               {}
+func (*RequestCommit) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{11} }
+
+type RequestDeliverTxBatch struct {
+	Txs [][]byte `protobuf:"bytes,1,rep,name=txs" json:"txs,omitempty"`
+}
+
+func (m *RequestDeliverTxBatch) Reset()                    { *m = RequestDeliverTxBatch{} }
+func (m *RequestDeliverTxBatch) String() string            { return proto.CompactTextString(m) }
+func (*RequestDeliverTxBatch) ProtoMessage()               {}
+func (*RequestDeliverTxBatch) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{12} }
+
+func (m *RequestDeliverTxBatch) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+type RequestCheckTxBatch struct {
+	Txs  [][]byte    `protobuf:"bytes,1,rep,name=txs" json:"txs,omitempty"`
+	Type CheckTxType `protobuf:"varint,2,opt,name=type,proto3,enum=types.CheckTxType" json:"type,omitempty"`
+}
+
+func (m *RequestCheckTxBatch) Reset()                    { *m = RequestCheckTxBatch{} }
+func (m *RequestCheckTxBatch) String() string            { return proto.CompactTextString(m) }
+func (*RequestCheckTxBatch) ProtoMessage()               {}
+func (*RequestCheckTxBatch) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{13} }
+
+func (m *RequestCheckTxBatch) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *RequestCheckTxBatch) GetType() CheckTxType {
+	if m != nil {
+		return m.Type
+	}
+	return CheckTxType_New
+}
+
+// lists available snapshots
+type RequestListSnapshots struct {
+}
+
+func (m *RequestListSnapshots) Reset()                    { *m = RequestListSnapshots{} }
+func (m *RequestListSnapshots) String() string            { return proto.CompactTextString(m) }
+func (*RequestListSnapshots) ProtoMessage()               {}
+func (*RequestListSnapshots) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{14} }
+
+// offers a snapshot to the application
+type RequestOfferSnapshot struct {
+	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot" json:"snapshot,omitempty"`
+	AppHash  []byte    `protobuf:"bytes,2,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+}
+
+func (m *RequestOfferSnapshot) Reset()                    { *m = RequestOfferSnapshot{} }
+func (m *RequestOfferSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*RequestOfferSnapshot) ProtoMessage()               {}
+func (*RequestOfferSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{15} }
+
+func (m *RequestOfferSnapshot) GetSnapshot() *Snapshot {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+func (m *RequestOfferSnapshot) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
+// loads a snapshot chunk
+type RequestLoadSnapshotChunk struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Chunk  uint32 `protobuf:"varint,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (m *RequestLoadSnapshotChunk) Reset()                    { *m = RequestLoadSnapshotChunk{} }
+func (m *RequestLoadSnapshotChunk) String() string            { return proto.CompactTextString(m) }
+func (*RequestLoadSnapshotChunk) ProtoMessage()               {}
+func (*RequestLoadSnapshotChunk) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{16} }
+
+func (m *RequestLoadSnapshotChunk) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RequestLoadSnapshotChunk) GetFormat() uint32 {
+	if m != nil {
+		return m.Format
+	}
+	return 0
+}
+
+func (m *RequestLoadSnapshotChunk) GetChunk() uint32 {
+	if m != nil {
+		return m.Chunk
+	}
+	return 0
+}
+
+// applies a snapshot chunk
+type RequestApplySnapshotChunk struct {
+	Index  uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Chunk  []byte `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Sender string `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+}
+
+func (m *RequestApplySnapshotChunk) Reset()                    { *m = RequestApplySnapshotChunk{} }
+func (m *RequestApplySnapshotChunk) String() string            { return proto.CompactTextString(m) }
+func (*RequestApplySnapshotChunk) ProtoMessage()               {}
+func (*RequestApplySnapshotChunk) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{17} }
+
+func (m *RequestApplySnapshotChunk) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *RequestApplySnapshotChunk) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+func (m *RequestApplySnapshotChunk) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+// prepares the txs of a block the node proposes
+type RequestPrepareProposal struct {
+	MaxTxBytes int64    `protobuf:"varint,1,opt,name=max_tx_bytes,json=maxTxBytes,proto3" json:"max_tx_bytes,omitempty"`
+	Txs        [][]byte `protobuf:"bytes,2,rep,name=txs" json:"txs,omitempty"`
+	Header     Header   `protobuf:"bytes,3,opt,name=header" json:"header"`
+}
+
+func (m *RequestPrepareProposal) Reset()                    { *m = RequestPrepareProposal{} }
+func (m *RequestPrepareProposal) String() string            { return proto.CompactTextString(m) }
+func (*RequestPrepareProposal) ProtoMessage()               {}
+func (*RequestPrepareProposal) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{18} }
+
+func (m *RequestPrepareProposal) GetMaxTxBytes() int64 {
+	if m != nil {
+		return m.MaxTxBytes
+	}
+	return 0
+}
+
+func (m *RequestPrepareProposal) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *RequestPrepareProposal) GetHeader() Header {
+	if m != nil {
+		return m.Header
+	}
+	return Header{}
+}
+
+// processes a block proposed by another node, before voting for it
+type RequestProcessProposal struct {
+	Txs    [][]byte `protobuf:"bytes,1,rep,name=txs" json:"txs,omitempty"`
+	Header Header   `protobuf:"bytes,2,opt,name=header" json:"header"`
+}
+
+func (m *RequestProcessProposal) Reset()                    { *m = RequestProcessProposal{} }
+func (m *RequestProcessProposal) String() string            { return proto.CompactTextString(m) }
+func (*RequestProcessProposal) ProtoMessage()               {}
+func (*RequestProcessProposal) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{19} }
+
+func (m *RequestProcessProposal) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *RequestProcessProposal) GetHeader() Header {
+	if m != nil {
+		return m.Header
+	}
+	return Header{}
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//	*Response_Exception
@@ -729,13 +1360,21 @@ type Response struct {
 	//	*Response_DeliverTx
 	//	*Response_EndBlock
 	//	*Response_Commit
+	//	*Response_DeliverTxBatch
+	//	*Response_CheckTxBatch
+	//	*Response_ListSnapshots
+	//	*Response_OfferSnapshot
+	//	*Response_LoadSnapshotChunk
+	//	*Response_ApplySnapshotChunk
+	//	*Response_PrepareProposal
+	//	*Response_ProcessProposal
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
 func (m *Response) Reset()                    { *m = Response{} }
 func (m *Response) String() string            { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()               {}
-func (*Response) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{12} }
+func (*Response) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{20} }
 
 type isResponse_Value interface {
 	isResponse_Value()
@@ -777,19 +1416,51 @@ type Response_EndBlock struct {
 type Response_Commit struct {
 	Commit *ResponseCommit `protobuf:"bytes,12,opt,name=commit,oneof"`
 }
+type Response_DeliverTxBatch struct {
+	DeliverTxBatch *ResponseDeliverTxBatch `protobuf:"bytes,13,opt,name=deliver_tx_batch,json=deliverTxBatch,oneof"`
+}
+type Response_CheckTxBatch struct {
+	CheckTxBatch *ResponseCheckTxBatch `protobuf:"bytes,14,opt,name=check_tx_batch,json=checkTxBatch,oneof"`
+}
+type Response_ListSnapshots struct {
+	ListSnapshots *ResponseListSnapshots `protobuf:"bytes,15,opt,name=list_snapshots,json=listSnapshots,oneof"`
+}
+type Response_OfferSnapshot struct {
+	OfferSnapshot *ResponseOfferSnapshot `protobuf:"bytes,16,opt,name=offer_snapshot,json=offerSnapshot,oneof"`
+}
+type Response_LoadSnapshotChunk struct {
+	LoadSnapshotChunk *ResponseLoadSnapshotChunk `protobuf:"bytes,17,opt,name=load_snapshot_chunk,json=loadSnapshotChunk,oneof"`
+}
+type Response_ApplySnapshotChunk struct {
+	ApplySnapshotChunk *ResponseApplySnapshotChunk `protobuf:"bytes,18,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,oneof"`
+}
+type Response_PrepareProposal struct {
+	PrepareProposal *ResponsePrepareProposal `protobuf:"bytes,19,opt,name=prepare_proposal,json=prepareProposal,oneof"`
+}
+type Response_ProcessProposal struct {
+	ProcessProposal *ResponseProcessProposal `protobuf:"bytes,20,opt,name=process_proposal,json=processProposal,oneof"`
+}
 
-func (*Response_Exception) isResponse_Value()  {}
-func (*Response_Echo) isResponse_Value()       {}
-func (*Response_Flush) isResponse_Value()      {}
-func (*Response_Info) isResponse_Value()       {}
-func (*Response_SetOption) isResponse_Value()  {}
-func (*Response_InitChain) isResponse_Value()  {}
-func (*Response_Query) isResponse_Value()      {}
-func (*Response_BeginBlock) isResponse_Value() {}
-func (*Response_CheckTx) isResponse_Value()    {}
-func (*Response_DeliverTx) isResponse_Value()  {}
-func (*Response_EndBlock) isResponse_Value()   {}
-func (*Response_Commit) isResponse_Value()     {}
+func (*Response_Exception) isResponse_Value()          {}
+func (*Response_Echo) isResponse_Value()               {}
+func (*Response_Flush) isResponse_Value()              {}
+func (*Response_Info) isResponse_Value()               {}
+func (*Response_SetOption) isResponse_Value()          {}
+func (*Response_InitChain) isResponse_Value()          {}
+func (*Response_Query) isResponse_Value()              {}
+func (*Response_BeginBlock) isResponse_Value()         {}
+func (*Response_CheckTx) isResponse_Value()            {}
+func (*Response_DeliverTx) isResponse_Value()          {}
+func (*Response_EndBlock) isResponse_Value()           {}
+func (*Response_Commit) isResponse_Value()             {}
+func (*Response_DeliverTxBatch) isResponse_Value()     {}
+func (*Response_CheckTxBatch) isResponse_Value()       {}
+func (*Response_ListSnapshots) isResponse_Value()      {}
+func (*Response_OfferSnapshot) isResponse_Value()      {}
+func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
+func (*Response_ApplySnapshotChunk) isResponse_Value() {}
+func (*Response_PrepareProposal) isResponse_Value()    {}
+func (*Response_ProcessProposal) isResponse_Value()    {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -882,6 +1553,62 @@ func (m *Response) GetCommit() *ResponseCommit {
 	return nil
 }
 
+func (m *Response) GetDeliverTxBatch() *ResponseDeliverTxBatch {
+	if x, ok := m.GetValue().(*Response_DeliverTxBatch); ok {
+		return x.DeliverTxBatch
+	}
+	return nil
+}
+
+func (m *Response) GetCheckTxBatch() *ResponseCheckTxBatch {
+	if x, ok := m.GetValue().(*Response_CheckTxBatch); ok {
+		return x.CheckTxBatch
+	}
+	return nil
+}
+
+func (m *Response) GetListSnapshots() *ResponseListSnapshots {
+	if x, ok := m.GetValue().(*Response_ListSnapshots); ok {
+		return x.ListSnapshots
+	}
+	return nil
+}
+
+func (m *Response) GetOfferSnapshot() *ResponseOfferSnapshot {
+	if x, ok := m.GetValue().(*Response_OfferSnapshot); ok {
+		return x.OfferSnapshot
+	}
+	return nil
+}
+
+func (m *Response) GetLoadSnapshotChunk() *ResponseLoadSnapshotChunk {
+	if x, ok := m.GetValue().(*Response_LoadSnapshotChunk); ok {
+		return x.LoadSnapshotChunk
+	}
+	return nil
+}
+
+func (m *Response) GetApplySnapshotChunk() *ResponseApplySnapshotChunk {
+	if x, ok := m.GetValue().(*Response_ApplySnapshotChunk); ok {
+		return x.ApplySnapshotChunk
+	}
+	return nil
+}
+
+func (m *Response) GetPrepareProposal() *ResponsePrepareProposal {
+	if x, ok := m.GetValue().(*Response_PrepareProposal); ok {
+		return x.PrepareProposal
+	}
+	return nil
+}
+
+func (m *Response) GetProcessProposal() *ResponseProcessProposal {
+	if x, ok := m.GetValue().(*Response_ProcessProposal); ok {
+		return x.ProcessProposal
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Response) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Response_OneofMarshaler, _Response_OneofUnmarshaler, _Response_OneofSizer, []interface{}{
@@ -897,6 +1624,14 @@ func (*Response) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) erro
 		(*Response_DeliverTx)(nil),
 		(*Response_EndBlock)(nil),
 		(*Response_Commit)(nil),
+		(*Response_DeliverTxBatch)(nil),
+		(*Response_CheckTxBatch)(nil),
+		(*Response_ListSnapshots)(nil),
+		(*Response_OfferSnapshot)(nil),
+		(*Response_LoadSnapshotChunk)(nil),
+		(*Response_ApplySnapshotChunk)(nil),
+		(*Response_PrepareProposal)(nil),
+		(*Response_ProcessProposal)(nil),
 	}
 }
 
@@ -964,6 +1699,46 @@ func _Response_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Commit); err != nil {
 			return err
 		}
+	case *Response_DeliverTxBatch:
+		_ = b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DeliverTxBatch); err != nil {
+			return err
+		}
+	case *Response_CheckTxBatch:
+		_ = b.EncodeVarint(14<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CheckTxBatch); err != nil {
+			return err
+		}
+	case *Response_ListSnapshots:
+		_ = b.EncodeVarint(15<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ListSnapshots); err != nil {
+			return err
+		}
+	case *Response_OfferSnapshot:
+		_ = b.EncodeVarint(16<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OfferSnapshot); err != nil {
+			return err
+		}
+	case *Response_LoadSnapshotChunk:
+		_ = b.EncodeVarint(17<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LoadSnapshotChunk); err != nil {
+			return err
+		}
+	case *Response_ApplySnapshotChunk:
+		_ = b.EncodeVarint(18<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ApplySnapshotChunk); err != nil {
+			return err
+		}
+	case *Response_PrepareProposal:
+		_ = b.EncodeVarint(19<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PrepareProposal); err != nil {
+			return err
+		}
+	case *Response_ProcessProposal:
+		_ = b.EncodeVarint(20<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ProcessProposal); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Response.Value has unexpected type %T", x)
@@ -1070,6 +1845,70 @@ func _Response_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffe
 		err := b.DecodeMessage(msg)
 		m.Value = &Response_Commit{msg}
 		return true, err
+	case 13: // value.deliver_tx_batch
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseDeliverTxBatch)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_DeliverTxBatch{msg}
+		return true, err
+	case 14: // value.check_tx_batch
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseCheckTxBatch)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_CheckTxBatch{msg}
+		return true, err
+	case 15: // value.list_snapshots
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseListSnapshots)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_ListSnapshots{msg}
+		return true, err
+	case 16: // value.offer_snapshot
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseOfferSnapshot)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_OfferSnapshot{msg}
+		return true, err
+	case 17: // value.load_snapshot_chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseLoadSnapshotChunk)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_LoadSnapshotChunk{msg}
+		return true, err
+	case 18: // value.apply_snapshot_chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseApplySnapshotChunk)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_ApplySnapshotChunk{msg}
+		return true, err
+	case 19: // value.prepare_proposal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponsePrepareProposal)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_PrepareProposal{msg}
+		return true, err
+	case 20: // value.process_proposal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseProcessProposal)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_ProcessProposal{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1139,6 +1978,46 @@ func _Response_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(12<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Response_DeliverTxBatch:
+		s := proto.Size(x.DeliverTxBatch)
+		n += proto.SizeVarint(13<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_CheckTxBatch:
+		s := proto.Size(x.CheckTxBatch)
+		n += proto.SizeVarint(14<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_ListSnapshots:
+		s := proto.Size(x.ListSnapshots)
+		n += proto.SizeVarint(15<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_OfferSnapshot:
+		s := proto.Size(x.OfferSnapshot)
+		n += proto.SizeVarint(16<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_LoadSnapshotChunk:
+		s := proto.Size(x.LoadSnapshotChunk)
+		n += proto.SizeVarint(17<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_ApplySnapshotChunk:
+		s := proto.Size(x.ApplySnapshotChunk)
+		n += proto.SizeVarint(18<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_PrepareProposal:
+		s := proto.Size(x.PrepareProposal)
+		n += proto.SizeVarint(19<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_ProcessProposal:
+		s := proto.Size(x.ProcessProposal)
+		n += proto.SizeVarint(20<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1154,7 +2033,7 @@ type ResponseException struct {
 func (m *ResponseException) Reset()                    { *m = ResponseException{} }
 func (m *ResponseException) String() string            { return proto.CompactTextString(m) }
 func (*ResponseException) ProtoMessage()               {}
-func (*ResponseException) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{13} }
+func (*ResponseException) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{21} }
 
 func (m *ResponseException) GetError() string {
 	if m != nil {
@@ -1170,7 +2049,7 @@ type ResponseEcho struct {
 func (m *ResponseEcho) Reset()                    { *m = ResponseEcho{} }
 func (m *ResponseEcho) String() string            { return proto.CompactTextString(m) }
 func (*ResponseEcho) ProtoMessage()               {}
-func (*ResponseEcho) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{14} }
+func (*ResponseEcho) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{22} }
 
 func (m *ResponseEcho) GetMessage() string {
 	if m != nil {
@@ -1185,19 +2064,21 @@ type ResponseFlush struct {
 func (m *ResponseFlush) Reset()                    { *m = ResponseFlush{} }
 func (m *ResponseFlush) String() string            { return proto.CompactTextString(m) }
 func (*ResponseFlush) ProtoMessage()               {}
-func (*ResponseFlush) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{15} }
+func (*ResponseFlush) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{23} }
 
 type ResponseInfo struct {
 	Data             string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	Version          string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
 	LastBlockHeight  int64  `protobuf:"varint,3,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
 	LastBlockAppHash []byte `protobuf:"bytes,4,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+	AppVersion       uint64 `protobuf:"varint,5,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	AbciVersion      string `protobuf:"bytes,6,opt,name=abci_version,json=abciVersion,proto3" json:"abci_version,omitempty"`
 }
 
 func (m *ResponseInfo) Reset()                    { *m = ResponseInfo{} }
 func (m *ResponseInfo) String() string            { return proto.CompactTextString(m) }
 func (*ResponseInfo) ProtoMessage()               {}
-func (*ResponseInfo) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{16} }
+func (*ResponseInfo) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{24} }
 
 func (m *ResponseInfo) GetData() string {
 	if m != nil {
@@ -1227,6 +2108,20 @@ func (m *ResponseInfo) GetLastBlockAppHash() []byte {
 	return nil
 }
 
+func (m *ResponseInfo) GetAppVersion() uint64 {
+	if m != nil {
+		return m.AppVersion
+	}
+	return 0
+}
+
+func (m *ResponseInfo) GetAbciVersion() string {
+	if m != nil {
+		return m.AbciVersion
+	}
+	return ""
+}
+
 // nondeterministic
 type ResponseSetOption struct {
 	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -1238,7 +2133,7 @@ type ResponseSetOption struct {
 func (m *ResponseSetOption) Reset()                    { *m = ResponseSetOption{} }
 func (m *ResponseSetOption) String() string            { return proto.CompactTextString(m) }
 func (*ResponseSetOption) ProtoMessage()               {}
-func (*ResponseSetOption) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{17} }
+func (*ResponseSetOption) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{25} }
 
 func (m *ResponseSetOption) GetCode() uint32 {
 	if m != nil {
@@ -1269,7 +2164,7 @@ type ResponseInitChain struct {
 func (m *ResponseInitChain) Reset()                    { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string            { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()               {}
-func (*ResponseInitChain) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{18} }
+func (*ResponseInitChain) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{26} }
 
 func (m *ResponseInitChain) GetConsensusParams() *ConsensusParams {
 	if m != nil {
@@ -1300,7 +2195,7 @@ type ResponseQuery struct {
 func (m *ResponseQuery) Reset()                    { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string            { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()               {}
-func (*ResponseQuery) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{19} }
+func (*ResponseQuery) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{27} }
 
 func (m *ResponseQuery) GetCode() uint32 {
 	if m != nil {
@@ -1365,7 +2260,7 @@ type ResponseBeginBlock struct {
 func (m *ResponseBeginBlock) Reset()                    { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string            { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()               {}
-func (*ResponseBeginBlock) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{20} }
+func (*ResponseBeginBlock) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{28} }
 
 func (m *ResponseBeginBlock) GetTags() []common.KVPair {
 	if m != nil {
@@ -1383,12 +2278,16 @@ type ResponseCheckTx struct {
 	GasUsed   int64           `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
 	Tags      []common.KVPair `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
 	Fee       common.KI64Pair `protobuf:"bytes,8,opt,name=fee" json:"fee"`
+	Priority  int64           `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	Sender    string          `protobuf:"bytes,10,opt,name=sender,proto3" json:"sender,omitempty"`
+	Nonce     uint64          `protobuf:"varint,11,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Eviction  EvictionHint    `protobuf:"varint,12,opt,name=eviction,proto3,enum=types.EvictionHint" json:"eviction,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()                    { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string            { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()               {}
-func (*ResponseCheckTx) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{21} }
+func (*ResponseCheckTx) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{29} }
 
 func (m *ResponseCheckTx) GetCode() uint32 {
 	if m != nil {
@@ -1446,6 +2345,34 @@ func (m *ResponseCheckTx) GetFee() common.KI64Pair {
 	return common.KI64Pair{}
 }
 
+func (m *ResponseCheckTx) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetEviction() EvictionHint {
+	if m != nil {
+		return m.Eviction
+	}
+	return EvictionHint_Normal
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32          `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -1460,7 +2387,7 @@ type ResponseDeliverTx struct {
 func (m *ResponseDeliverTx) Reset()                    { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string            { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()               {}
-func (*ResponseDeliverTx) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{22} }
+func (*ResponseDeliverTx) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{30} }
 
 func (m *ResponseDeliverTx) GetCode() uint32 {
 	if m != nil {
@@ -1471,100 +2398,253 @@ func (m *ResponseDeliverTx) GetCode() uint32 {
 
 func (m *ResponseDeliverTx) GetData() []byte {
 	if m != nil {
-		return m.Data
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ResponseDeliverTx) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
+}
+
+func (m *ResponseDeliverTx) GetInfo() string {
+	if m != nil {
+		return m.Info
+	}
+	return ""
+}
+
+func (m *ResponseDeliverTx) GetGasWanted() int64 {
+	if m != nil {
+		return m.GasWanted
+	}
+	return 0
+}
+
+func (m *ResponseDeliverTx) GetGasUsed() int64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *ResponseDeliverTx) GetTags() []common.KVPair {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ResponseDeliverTx) GetFee() common.KI64Pair {
+	if m != nil {
+		return m.Fee
+	}
+	return common.KI64Pair{}
+}
+
+type ResponseEndBlock struct {
+	ValidatorUpdates      []Validator      `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates" json:"validator_updates"`
+	ConsensusParamUpdates *ConsensusParams `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates" json:"consensus_param_updates,omitempty"`
+	Tags                  []common.KVPair  `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+}
+
+func (m *ResponseEndBlock) Reset()                    { *m = ResponseEndBlock{} }
+func (m *ResponseEndBlock) String() string            { return proto.CompactTextString(m) }
+func (*ResponseEndBlock) ProtoMessage()               {}
+func (*ResponseEndBlock) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{31} }
+
+func (m *ResponseEndBlock) GetValidatorUpdates() []Validator {
+	if m != nil {
+		return m.ValidatorUpdates
+	}
+	return nil
+}
+
+func (m *ResponseEndBlock) GetConsensusParamUpdates() *ConsensusParams {
+	if m != nil {
+		return m.ConsensusParamUpdates
+	}
+	return nil
+}
+
+func (m *ResponseEndBlock) GetTags() []common.KVPair {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type ResponseCommit struct {
+	// reserve 1
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *ResponseCommit) Reset()                    { *m = ResponseCommit{} }
+func (m *ResponseCommit) String() string            { return proto.CompactTextString(m) }
+func (*ResponseCommit) ProtoMessage()               {}
+func (*ResponseCommit) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{32} }
+
+func (m *ResponseCommit) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// one response per tx, in the order of the request.
+// NOTE: nullable, as the golang/protobuf codec used by grpc
+// can't encode repeated non-nullable messages
+type ResponseDeliverTxBatch struct {
+	Responses []*ResponseDeliverTx `protobuf:"bytes,1,rep,name=responses" json:"responses,omitempty"`
+}
+
+func (m *ResponseDeliverTxBatch) Reset()                    { *m = ResponseDeliverTxBatch{} }
+func (m *ResponseDeliverTxBatch) String() string            { return proto.CompactTextString(m) }
+func (*ResponseDeliverTxBatch) ProtoMessage()               {}
+func (*ResponseDeliverTxBatch) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{33} }
+
+func (m *ResponseDeliverTxBatch) GetResponses() []*ResponseDeliverTx {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+// one response per tx, in the order of the request.
+// NOTE: nullable, see ResponseDeliverTxBatch
+type ResponseCheckTxBatch struct {
+	Responses []*ResponseCheckTx `protobuf:"bytes,1,rep,name=responses" json:"responses,omitempty"`
+}
+
+func (m *ResponseCheckTxBatch) Reset()                    { *m = ResponseCheckTxBatch{} }
+func (m *ResponseCheckTxBatch) String() string            { return proto.CompactTextString(m) }
+func (*ResponseCheckTxBatch) ProtoMessage()               {}
+func (*ResponseCheckTxBatch) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{34} }
+
+func (m *ResponseCheckTxBatch) GetResponses() []*ResponseCheckTx {
+	if m != nil {
+		return m.Responses
 	}
 	return nil
 }
 
-func (m *ResponseDeliverTx) GetLog() string {
-	if m != nil {
-		return m.Log
-	}
-	return ""
+// NOTE: nullable, see ResponseDeliverTxBatch
+type ResponseListSnapshots struct {
+	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=snapshots" json:"snapshots,omitempty"`
 }
 
-func (m *ResponseDeliverTx) GetInfo() string {
+func (m *ResponseListSnapshots) Reset()                    { *m = ResponseListSnapshots{} }
+func (m *ResponseListSnapshots) String() string            { return proto.CompactTextString(m) }
+func (*ResponseListSnapshots) ProtoMessage()               {}
+func (*ResponseListSnapshots) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{35} }
+
+func (m *ResponseListSnapshots) GetSnapshots() []*Snapshot {
 	if m != nil {
-		return m.Info
+		return m.Snapshots
 	}
-	return ""
+	return nil
 }
 
-func (m *ResponseDeliverTx) GetGasWanted() int64 {
-	if m != nil {
-		return m.GasWanted
-	}
-	return 0
+type ResponseOfferSnapshot struct {
+	Result ResponseOfferSnapshot_Result `protobuf:"varint,1,opt,name=result,proto3,enum=types.ResponseOfferSnapshot_Result" json:"result,omitempty"`
 }
 
-func (m *ResponseDeliverTx) GetGasUsed() int64 {
+func (m *ResponseOfferSnapshot) Reset()                    { *m = ResponseOfferSnapshot{} }
+func (m *ResponseOfferSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*ResponseOfferSnapshot) ProtoMessage()               {}
+func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{36} }
+
+func (m *ResponseOfferSnapshot) GetResult() ResponseOfferSnapshot_Result {
 	if m != nil {
-		return m.GasUsed
+		return m.Result
 	}
-	return 0
+	return ResponseOfferSnapshot_Unknown
 }
 
-func (m *ResponseDeliverTx) GetTags() []common.KVPair {
+type ResponseLoadSnapshotChunk struct {
+	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (m *ResponseLoadSnapshotChunk) Reset()                    { *m = ResponseLoadSnapshotChunk{} }
+func (m *ResponseLoadSnapshotChunk) String() string            { return proto.CompactTextString(m) }
+func (*ResponseLoadSnapshotChunk) ProtoMessage()               {}
+func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{37} }
+
+func (m *ResponseLoadSnapshotChunk) GetChunk() []byte {
 	if m != nil {
-		return m.Tags
+		return m.Chunk
 	}
 	return nil
 }
 
-func (m *ResponseDeliverTx) GetFee() common.KI64Pair {
-	if m != nil {
-		return m.Fee
-	}
-	return common.KI64Pair{}
+type ResponseApplySnapshotChunk struct {
+	Result        ResponseApplySnapshotChunk_Result `protobuf:"varint,1,opt,name=result,proto3,enum=types.ResponseApplySnapshotChunk_Result" json:"result,omitempty"`
+	RefetchChunks []uint32                          `protobuf:"varint,2,rep,packed,name=refetch_chunks,json=refetchChunks" json:"refetch_chunks,omitempty"`
+	RejectSenders []string                          `protobuf:"bytes,3,rep,name=reject_senders,json=rejectSenders" json:"reject_senders,omitempty"`
 }
 
-type ResponseEndBlock struct {
-	ValidatorUpdates      []Validator      `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates" json:"validator_updates"`
-	ConsensusParamUpdates *ConsensusParams `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates" json:"consensus_param_updates,omitempty"`
-	Tags                  []common.KVPair  `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapshotChunk{} }
+func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
+func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptorTypes, []int{38}
 }
 
-func (m *ResponseEndBlock) Reset()                    { *m = ResponseEndBlock{} }
-func (m *ResponseEndBlock) String() string            { return proto.CompactTextString(m) }
-func (*ResponseEndBlock) ProtoMessage()               {}
-func (*ResponseEndBlock) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{23} }
+func (m *ResponseApplySnapshotChunk) GetResult() ResponseApplySnapshotChunk_Result {
+	if m != nil {
+		return m.Result
+	}
+	return ResponseApplySnapshotChunk_Unknown
+}
 
-func (m *ResponseEndBlock) GetValidatorUpdates() []Validator {
+func (m *ResponseApplySnapshotChunk) GetRefetchChunks() []uint32 {
 	if m != nil {
-		return m.ValidatorUpdates
+		return m.RefetchChunks
 	}
 	return nil
 }
 
-func (m *ResponseEndBlock) GetConsensusParamUpdates() *ConsensusParams {
+func (m *ResponseApplySnapshotChunk) GetRejectSenders() []string {
 	if m != nil {
-		return m.ConsensusParamUpdates
+		return m.RejectSenders
 	}
 	return nil
 }
 
-func (m *ResponseEndBlock) GetTags() []common.KVPair {
+// the txs of the block, reordered, dropped or added by the app
+type ResponsePrepareProposal struct {
+	Txs [][]byte `protobuf:"bytes,1,rep,name=txs" json:"txs,omitempty"`
+}
+
+func (m *ResponsePrepareProposal) Reset()                    { *m = ResponsePrepareProposal{} }
+func (m *ResponsePrepareProposal) String() string            { return proto.CompactTextString(m) }
+func (*ResponsePrepareProposal) ProtoMessage()               {}
+func (*ResponsePrepareProposal) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{39} }
+
+func (m *ResponsePrepareProposal) GetTxs() [][]byte {
 	if m != nil {
-		return m.Tags
+		return m.Txs
 	}
 	return nil
 }
 
-type ResponseCommit struct {
-	// reserve 1
-	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+type ResponseProcessProposal struct {
+	Status ResponseProcessProposal_Status `protobuf:"varint,1,opt,name=status,proto3,enum=types.ResponseProcessProposal_Status" json:"status,omitempty"`
 }
 
-func (m *ResponseCommit) Reset()                    { *m = ResponseCommit{} }
-func (m *ResponseCommit) String() string            { return proto.CompactTextString(m) }
-func (*ResponseCommit) ProtoMessage()               {}
-func (*ResponseCommit) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{24} }
+func (m *ResponseProcessProposal) Reset()                    { *m = ResponseProcessProposal{} }
+func (m *ResponseProcessProposal) String() string            { return proto.CompactTextString(m) }
+func (*ResponseProcessProposal) ProtoMessage()               {}
+func (*ResponseProcessProposal) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{40} }
 
-func (m *ResponseCommit) GetData() []byte {
+func (m *ResponseProcessProposal) GetStatus() ResponseProcessProposal_Status {
 	if m != nil {
-		return m.Data
+		return m.Status
 	}
-	return nil
+	return ResponseProcessProposal_Unknown
 }
 
 // ConsensusParams contains all consensus-relevant parameters
@@ -1578,7 +2658,7 @@ type ConsensusParams struct {
 func (m *ConsensusParams) Reset()                    { *m = ConsensusParams{} }
 func (m *ConsensusParams) String() string            { return proto.CompactTextString(m) }
 func (*ConsensusParams) ProtoMessage()               {}
-func (*ConsensusParams) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{25} }
+func (*ConsensusParams) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{41} }
 
 func (m *ConsensusParams) GetBlockSize() *BlockSize {
 	if m != nil {
@@ -1611,7 +2691,7 @@ type BlockSize struct {
 func (m *BlockSize) Reset()                    { *m = BlockSize{} }
 func (m *BlockSize) String() string            { return proto.CompactTextString(m) }
 func (*BlockSize) ProtoMessage()               {}
-func (*BlockSize) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{26} }
+func (*BlockSize) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{42} }
 
 func (m *BlockSize) GetMaxBytes() int32 {
 	if m != nil {
@@ -1643,7 +2723,7 @@ type TxSize struct {
 func (m *TxSize) Reset()                    { *m = TxSize{} }
 func (m *TxSize) String() string            { return proto.CompactTextString(m) }
 func (*TxSize) ProtoMessage()               {}
-func (*TxSize) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{27} }
+func (*TxSize) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{43} }
 
 func (m *TxSize) GetMaxBytes() int32 {
 	if m != nil {
@@ -1669,7 +2749,7 @@ type BlockGossip struct {
 func (m *BlockGossip) Reset()                    { *m = BlockGossip{} }
 func (m *BlockGossip) String() string            { return proto.CompactTextString(m) }
 func (*BlockGossip) ProtoMessage()               {}
-func (*BlockGossip) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{28} }
+func (*BlockGossip) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{44} }
 
 func (m *BlockGossip) GetBlockPartSizeBytes() int32 {
 	if m != nil {
@@ -1698,7 +2778,7 @@ type Header struct {
 func (m *Header) Reset()                    { *m = Header{} }
 func (m *Header) String() string            { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()               {}
-func (*Header) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{29} }
+func (*Header) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{45} }
 
 func (m *Header) GetChainID() string {
 	if m != nil {
@@ -1773,7 +2853,7 @@ type Validator struct {
 func (m *Validator) Reset()                    { *m = Validator{} }
 func (m *Validator) String() string            { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()               {}
-func (*Validator) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{30} }
+func (*Validator) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{46} }
 
 func (m *Validator) GetAddress() []byte {
 	if m != nil {
@@ -1805,7 +2885,7 @@ type SigningValidator struct {
 func (m *SigningValidator) Reset()                    { *m = SigningValidator{} }
 func (m *SigningValidator) String() string            { return proto.CompactTextString(m) }
 func (*SigningValidator) ProtoMessage()               {}
-func (*SigningValidator) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{31} }
+func (*SigningValidator) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{47} }
 
 func (m *SigningValidator) GetValidator() Validator {
 	if m != nil {
@@ -1829,7 +2909,7 @@ type PubKey struct {
 func (m *PubKey) Reset()                    { *m = PubKey{} }
 func (m *PubKey) String() string            { return proto.CompactTextString(m) }
 func (*PubKey) ProtoMessage()               {}
-func (*PubKey) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{32} }
+func (*PubKey) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{48} }
 
 func (m *PubKey) GetType() string {
 	if m != nil {
@@ -1856,7 +2936,7 @@ type Evidence struct {
 func (m *Evidence) Reset()                    { *m = Evidence{} }
 func (m *Evidence) String() string            { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()               {}
-func (*Evidence) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{33} }
+func (*Evidence) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{49} }
 
 func (m *Evidence) GetType() string {
 	if m != nil {
@@ -1893,6 +2973,54 @@ func (m *Evidence) GetTotalVotingPower() int64 {
 	return 0
 }
 
+type Snapshot struct {
+	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format   uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Chunks   uint32 `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Hash     []byte `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (m *Snapshot) Reset()                    { *m = Snapshot{} }
+func (m *Snapshot) String() string            { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()               {}
+func (*Snapshot) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{50} }
+
+func (m *Snapshot) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Snapshot) GetFormat() uint32 {
+	if m != nil {
+		return m.Format
+	}
+	return 0
+}
+
+func (m *Snapshot) GetChunks() uint32 {
+	if m != nil {
+		return m.Chunks
+	}
+	return 0
+}
+
+func (m *Snapshot) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Snapshot) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Request)(nil), "types.Request")
 	proto.RegisterType((*RequestEcho)(nil), "types.RequestEcho")
@@ -1906,6 +3034,14 @@ func init() {
 	proto.RegisterType((*RequestDeliverTx)(nil), "types.RequestDeliverTx")
 	proto.RegisterType((*RequestEndBlock)(nil), "types.RequestEndBlock")
 	proto.RegisterType((*RequestCommit)(nil), "types.RequestCommit")
+	proto.RegisterType((*RequestDeliverTxBatch)(nil), "types.RequestDeliverTxBatch")
+	proto.RegisterType((*RequestCheckTxBatch)(nil), "types.RequestCheckTxBatch")
+	proto.RegisterType((*RequestListSnapshots)(nil), "types.RequestListSnapshots")
+	proto.RegisterType((*RequestOfferSnapshot)(nil), "types.RequestOfferSnapshot")
+	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "types.RequestLoadSnapshotChunk")
+	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "types.RequestApplySnapshotChunk")
+	proto.RegisterType((*RequestPrepareProposal)(nil), "types.RequestPrepareProposal")
+	proto.RegisterType((*RequestProcessProposal)(nil), "types.RequestProcessProposal")
 	proto.RegisterType((*Response)(nil), "types.Response")
 	proto.RegisterType((*ResponseException)(nil), "types.ResponseException")
 	proto.RegisterType((*ResponseEcho)(nil), "types.ResponseEcho")
@@ -1919,6 +3055,14 @@ func init() {
 	proto.RegisterType((*ResponseDeliverTx)(nil), "types.ResponseDeliverTx")
 	proto.RegisterType((*ResponseEndBlock)(nil), "types.ResponseEndBlock")
 	proto.RegisterType((*ResponseCommit)(nil), "types.ResponseCommit")
+	proto.RegisterType((*ResponseDeliverTxBatch)(nil), "types.ResponseDeliverTxBatch")
+	proto.RegisterType((*ResponseCheckTxBatch)(nil), "types.ResponseCheckTxBatch")
+	proto.RegisterType((*ResponseListSnapshots)(nil), "types.ResponseListSnapshots")
+	proto.RegisterType((*ResponseOfferSnapshot)(nil), "types.ResponseOfferSnapshot")
+	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "types.ResponseLoadSnapshotChunk")
+	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "types.ResponseApplySnapshotChunk")
+	proto.RegisterType((*ResponsePrepareProposal)(nil), "types.ResponsePrepareProposal")
+	proto.RegisterType((*ResponseProcessProposal)(nil), "types.ResponseProcessProposal")
 	proto.RegisterType((*ConsensusParams)(nil), "types.ConsensusParams")
 	proto.RegisterType((*BlockSize)(nil), "types.BlockSize")
 	proto.RegisterType((*TxSize)(nil), "types.TxSize")
@@ -1928,6 +3072,12 @@ func init() {
 	proto.RegisterType((*SigningValidator)(nil), "types.SigningValidator")
 	proto.RegisterType((*PubKey)(nil), "types.PubKey")
 	proto.RegisterType((*Evidence)(nil), "types.Evidence")
+	proto.RegisterType((*Snapshot)(nil), "types.Snapshot")
+	proto.RegisterEnum("types.CheckTxType", CheckTxType_name, CheckTxType_value)
+	proto.RegisterEnum("types.EvictionHint", EvictionHint_name, EvictionHint_value)
+	proto.RegisterEnum("types.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
+	proto.RegisterEnum("types.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
+	proto.RegisterEnum("types.ResponseProcessProposal_Status", ResponseProcessProposal_Status_name, ResponseProcessProposal_Status_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1952,6 +3102,14 @@ type ABCIApplicationClient interface {
 	InitChain(ctx context.Context, in *RequestInitChain, opts ...grpc.CallOption) (*ResponseInitChain, error)
 	BeginBlock(ctx context.Context, in *RequestBeginBlock, opts ...grpc.CallOption) (*ResponseBeginBlock, error)
 	EndBlock(ctx context.Context, in *RequestEndBlock, opts ...grpc.CallOption) (*ResponseEndBlock, error)
+	DeliverTxBatch(ctx context.Context, in *RequestDeliverTxBatch, opts ...grpc.CallOption) (*ResponseDeliverTxBatch, error)
+	CheckTxBatch(ctx context.Context, in *RequestCheckTxBatch, opts ...grpc.CallOption) (*ResponseCheckTxBatch, error)
+	ListSnapshots(ctx context.Context, in *RequestListSnapshots, opts ...grpc.CallOption) (*ResponseListSnapshots, error)
+	OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error)
+	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
+	PrepareProposal(ctx context.Context, in *RequestPrepareProposal, opts ...grpc.CallOption) (*ResponsePrepareProposal, error)
+	ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error)
 }
 
 type aBCIApplicationClient struct {
@@ -2061,6 +3219,78 @@ func (c *aBCIApplicationClient) EndBlock(ctx context.Context, in *RequestEndBloc
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) DeliverTxBatch(ctx context.Context, in *RequestDeliverTxBatch, opts ...grpc.CallOption) (*ResponseDeliverTxBatch, error) {
+	out := new(ResponseDeliverTxBatch)
+	err := grpc.Invoke(ctx, "/types.ABCIApplication/DeliverTxBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) CheckTxBatch(ctx context.Context, in *RequestCheckTxBatch, opts ...grpc.CallOption) (*ResponseCheckTxBatch, error) {
+	out := new(ResponseCheckTxBatch)
+	err := grpc.Invoke(ctx, "/types.ABCIApplication/CheckTxBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) ListSnapshots(ctx context.Context, in *RequestListSnapshots, opts ...grpc.CallOption) (*ResponseListSnapshots, error) {
+	out := new(ResponseListSnapshots)
+	err := grpc.Invoke(ctx, "/types.ABCIApplication/ListSnapshots", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error) {
+	out := new(ResponseOfferSnapshot)
+	err := grpc.Invoke(ctx, "/types.ABCIApplication/OfferSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error) {
+	out := new(ResponseLoadSnapshotChunk)
+	err := grpc.Invoke(ctx, "/types.ABCIApplication/LoadSnapshotChunk", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error) {
+	out := new(ResponseApplySnapshotChunk)
+	err := grpc.Invoke(ctx, "/types.ABCIApplication/ApplySnapshotChunk", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) PrepareProposal(ctx context.Context, in *RequestPrepareProposal, opts ...grpc.CallOption) (*ResponsePrepareProposal, error) {
+	out := new(ResponsePrepareProposal)
+	err := grpc.Invoke(ctx, "/types.ABCIApplication/PrepareProposal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error) {
+	out := new(ResponseProcessProposal)
+	err := grpc.Invoke(ctx, "/types.ABCIApplication/ProcessProposal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ABCIApplication service
 
 type ABCIApplicationServer interface {
@@ -2075,6 +3305,14 @@ type ABCIApplicationServer interface {
 	InitChain(context.Context, *RequestInitChain) (*ResponseInitChain, error)
 	BeginBlock(context.Context, *RequestBeginBlock) (*ResponseBeginBlock, error)
 	EndBlock(context.Context, *RequestEndBlock) (*ResponseEndBlock, error)
+	DeliverTxBatch(context.Context, *RequestDeliverTxBatch) (*ResponseDeliverTxBatch, error)
+	CheckTxBatch(context.Context, *RequestCheckTxBatch) (*ResponseCheckTxBatch, error)
+	ListSnapshots(context.Context, *RequestListSnapshots) (*ResponseListSnapshots, error)
+	OfferSnapshot(context.Context, *RequestOfferSnapshot) (*ResponseOfferSnapshot, error)
+	LoadSnapshotChunk(context.Context, *RequestLoadSnapshotChunk) (*ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
+	PrepareProposal(context.Context, *RequestPrepareProposal) (*ResponsePrepareProposal, error)
+	ProcessProposal(context.Context, *RequestProcessProposal) (*ResponseProcessProposal, error)
 }
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
@@ -2279,6 +3517,150 @@ func _ABCIApplication_EndBlock_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ABCIApplication_DeliverTxBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestDeliverTxBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).DeliverTxBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/DeliverTxBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).DeliverTxBatch(ctx, req.(*RequestDeliverTxBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_CheckTxBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestCheckTxBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).CheckTxBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/CheckTxBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).CheckTxBatch(ctx, req.(*RequestCheckTxBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListSnapshots)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/ListSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ListSnapshots(ctx, req.(*RequestListSnapshots))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_OfferSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestOfferSnapshot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).OfferSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/OfferSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).OfferSnapshot(ctx, req.(*RequestOfferSnapshot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_LoadSnapshotChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestLoadSnapshotChunk)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).LoadSnapshotChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/LoadSnapshotChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).LoadSnapshotChunk(ctx, req.(*RequestLoadSnapshotChunk))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_ApplySnapshotChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestApplySnapshotChunk)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ApplySnapshotChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/ApplySnapshotChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ApplySnapshotChunk(ctx, req.(*RequestApplySnapshotChunk))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_PrepareProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPrepareProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).PrepareProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/PrepareProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).PrepareProposal(ctx, req.(*RequestPrepareProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_ProcessProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestProcessProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/ProcessProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, req.(*RequestProcessProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "types.ABCIApplication",
 	HandlerType: (*ABCIApplicationServer)(nil),
@@ -2327,6 +3709,38 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EndBlock",
 			Handler:    _ABCIApplication_EndBlock_Handler,
 		},
+		{
+			MethodName: "DeliverTxBatch",
+			Handler:    _ABCIApplication_DeliverTxBatch_Handler,
+		},
+		{
+			MethodName: "CheckTxBatch",
+			Handler:    _ABCIApplication_CheckTxBatch_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _ABCIApplication_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "OfferSnapshot",
+			Handler:    _ABCIApplication_OfferSnapshot_Handler,
+		},
+		{
+			MethodName: "LoadSnapshotChunk",
+			Handler:    _ABCIApplication_LoadSnapshotChunk_Handler,
+		},
+		{
+			MethodName: "ApplySnapshotChunk",
+			Handler:    _ABCIApplication_ApplySnapshotChunk_Handler,
+		},
+		{
+			MethodName: "PrepareProposal",
+			Handler:    _ABCIApplication_PrepareProposal_Handler,
+		},
+		{
+			MethodName: "ProcessProposal",
+			Handler:    _ABCIApplication_ProcessProposal_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "types/types.proto",
@@ -2335,121 +3749,187 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("types/types.proto", fileDescriptorTypes) }
 
 var fileDescriptorTypes = []byte{
-	// 1846 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0xcd, 0x6e, 0x1b, 0xc9,
-	0x11, 0x16, 0xff, 0x39, 0xa5, 0x1f, 0xd2, 0x2d, 0xdb, 0xa2, 0xb9, 0x08, 0x6c, 0x0c, 0x02, 0xaf,
-	0x9c, 0xd5, 0x8a, 0x89, 0x76, 0x6d, 0xd8, 0xbb, 0xc9, 0x22, 0x92, 0xd6, 0x59, 0x0a, 0x9b, 0x1f,
-	0x65, 0xec, 0x75, 0x80, 0x5c, 0x88, 0x26, 0xa7, 0x45, 0x0e, 0x4c, 0xce, 0xcc, 0x4e, 0x37, 0xb5,
-	0x94, 0x6f, 0xb9, 0x2f, 0x72, 0xcd, 0x39, 0x2f, 0x90, 0x43, 0x80, 0xbc, 0x42, 0x90, 0x97, 0x88,
-	0x0f, 0x49, 0x4e, 0x79, 0x89, 0x04, 0x55, 0xdd, 0xf3, 0xab, 0xa1, 0xe1, 0x38, 0xc7, 0xbd, 0x48,
-	0x5d, 0x5d, 0x55, 0x3d, 0x5d, 0xc5, 0xaa, 0xaf, 0xaa, 0x1a, 0x6e, 0xa8, 0xab, 0x50, 0xc8, 0x01,
-	0xfd, 0x3d, 0x0c, 0xa3, 0x40, 0x05, 0xac, 0x41, 0x44, 0xff, 0xc3, 0xa9, 0xa7, 0x66, 0xcb, 0xf1,
-	0xe1, 0x24, 0x58, 0x0c, 0xa6, 0xc1, 0x34, 0x18, 0x10, 0x77, 0xbc, 0xbc, 0x20, 0x8a, 0x08, 0x5a,
-	0x69, 0xad, 0xfe, 0x20, 0x23, 0xae, 0x84, 0xef, 0x8a, 0x68, 0xe1, 0xf9, 0x6a, 0xa0, 0x16, 0x73,
-	0x6f, 0x2c, 0x07, 0x93, 0x60, 0xb1, 0x08, 0xfc, 0xec, 0x67, 0xec, 0xbf, 0xd6, 0xa1, 0xe5, 0x88,
-	0xaf, 0x97, 0x42, 0x2a, 0xb6, 0x0f, 0x75, 0x31, 0x99, 0x05, 0xbd, 0xea, 0xbd, 0xca, 0xfe, 0xe6,
-	0x11, 0x3b, 0xd4, 0x72, 0x86, 0xfb, 0x74, 0x32, 0x0b, 0x86, 0x1b, 0x0e, 0x49, 0xb0, 0x0f, 0xa0,
-	0x71, 0x31, 0x5f, 0xca, 0x59, 0xaf, 0x46, 0xa2, 0xbb, 0x79, 0xd1, 0x9f, 0x21, 0x6b, 0xb8, 0xe1,
-	0x68, 0x19, 0x3c, 0xd6, 0xf3, 0x2f, 0x82, 0x5e, 0xbd, 0xec, 0xd8, 0x33, 0xff, 0x82, 0x8e, 0x45,
-	0x09, 0xf6, 0x18, 0x40, 0x0a, 0x35, 0x0a, 0x42, 0xe5, 0x05, 0x7e, 0xaf, 0x41, 0xf2, 0x7b, 0x79,
-	0xf9, 0x67, 0x42, 0xfd, 0x8a, 0xd8, 0xc3, 0x0d, 0xc7, 0x92, 0x31, 0x81, 0x9a, 0x9e, 0xef, 0xa9,
-	0xd1, 0x64, 0xc6, 0x3d, 0xbf, 0xd7, 0x2c, 0xd3, 0x3c, 0xf3, 0x3d, 0x75, 0x8a, 0x6c, 0xd4, 0xf4,
-	0x62, 0x02, 0x4d, 0xf9, 0x7a, 0x29, 0xa2, 0xab, 0x5e, 0xab, 0xcc, 0x94, 0x5f, 0x23, 0x0b, 0x4d,
-	0x21, 0x19, 0xf6, 0x29, 0x6c, 0x8e, 0xc5, 0xd4, 0xf3, 0x47, 0xe3, 0x79, 0x30, 0x79, 0xd9, 0x6b,
-	0x93, 0x4a, 0x2f, 0xaf, 0x72, 0x82, 0x02, 0x27, 0xc8, 0x1f, 0x6e, 0x38, 0x30, 0x4e, 0x28, 0x76,
-	0x04, 0xed, 0xc9, 0x4c, 0x4c, 0x5e, 0x8e, 0xd4, 0xaa, 0x67, 0x91, 0xe6, 0xad, 0xbc, 0xe6, 0x29,
-	0x72, 0x9f, 0xaf, 0x86, 0x1b, 0x4e, 0x6b, 0xa2, 0x97, 0x68, 0x97, 0x2b, 0xe6, 0xde, 0xa5, 0x88,
-	0x50, 0x6b, 0xb7, 0xcc, 0xae, 0xcf, 0x35, 0x9f, 0xf4, 0x2c, 0x37, 0x26, 0xd8, 0x43, 0xb0, 0x84,
-	0xef, 0x9a, 0x8b, 0x6e, 0x92, 0xe2, 0xed, 0xc2, 0x2f, 0xea, 0xbb, 0xf1, 0x35, 0xdb, 0xc2, 0xac,
-	0xd9, 0x21, 0x34, 0x31, 0x4a, 0x3c, 0xd5, 0xdb, 0x22, 0x9d, 0x9b, 0x85, 0x2b, 0x12, 0x6f, 0xb8,
-	0xe1, 0x18, 0xa9, 0x93, 0x16, 0x34, 0x2e, 0xf9, 0x7c, 0x29, 0xec, 0xf7, 0x61, 0x33, 0x13, 0x29,
-	0xac, 0x07, 0xad, 0x85, 0x90, 0x92, 0x4f, 0x45, 0xaf, 0x72, 0xaf, 0xb2, 0x6f, 0x39, 0x31, 0x69,
-	0xef, 0xc0, 0x56, 0x36, 0x4e, 0x32, 0x8a, 0x18, 0x0b, 0xa8, 0x78, 0x29, 0x22, 0x89, 0x01, 0x60,
-	0x14, 0x0d, 0x69, 0x7f, 0x02, 0xdd, 0x62, 0x10, 0xb0, 0x2e, 0xd4, 0x5e, 0x8a, 0x2b, 0x23, 0x89,
-	0x4b, 0x76, 0xd3, 0x5c, 0x88, 0xa2, 0xd8, 0x72, 0xcc, 0xed, 0xfe, 0x55, 0x49, 0x94, 0x93, 0x38,
-	0x60, 0x0c, 0xea, 0xca, 0x5b, 0xe8, 0x0b, 0xd6, 0x1c, 0x5a, 0xb3, 0x3b, 0xf8, 0x23, 0x71, 0xcf,
-	0x1f, 0x79, 0xae, 0x39, 0xa1, 0x45, 0xf4, 0x99, 0xcb, 0x8e, 0xa1, 0x3b, 0x09, 0x7c, 0x29, 0x7c,
-	0xb9, 0x94, 0xa3, 0x90, 0x47, 0x7c, 0x21, 0x4d, 0xfc, 0xc7, 0x8e, 0x3d, 0x8d, 0xd9, 0xe7, 0xc4,
-	0x75, 0x3a, 0x93, 0xfc, 0x06, 0x7b, 0x04, 0x70, 0xc9, 0xe7, 0x9e, 0xcb, 0x55, 0x10, 0xc9, 0x5e,
-	0xfd, 0x5e, 0x6d, 0x7f, 0xf3, 0xa8, 0x6b, 0x94, 0x5f, 0xc4, 0x8c, 0x93, 0xfa, 0xdf, 0x5e, 0xdf,
-	0xdd, 0x70, 0x32, 0x92, 0xec, 0x3e, 0x74, 0x78, 0x18, 0x8e, 0xa4, 0xe2, 0x4a, 0x8c, 0xc6, 0x57,
-	0x4a, 0x48, 0xca, 0x8e, 0x2d, 0x67, 0x9b, 0x87, 0xe1, 0x33, 0xdc, 0x3d, 0xc1, 0x4d, 0xdb, 0x4d,
-	0x7c, 0x4b, 0x81, 0x8b, 0x16, 0xba, 0x5c, 0x71, 0xb2, 0x70, 0xcb, 0xa1, 0x35, 0xee, 0x85, 0x5c,
-	0xcd, 0x8c, 0x75, 0xb4, 0x66, 0xb7, 0xa1, 0x39, 0x13, 0xde, 0x74, 0xa6, 0xc8, 0xa0, 0x9a, 0x63,
-	0x28, 0x74, 0x66, 0x18, 0x05, 0x97, 0x82, 0x72, 0xb7, 0xed, 0x68, 0xc2, 0xfe, 0x7b, 0x05, 0x6e,
-	0x5c, 0x0b, 0x76, 0x3c, 0x77, 0xc6, 0xe5, 0x2c, 0xfe, 0x16, 0xae, 0xd9, 0x07, 0x78, 0x2e, 0x77,
-	0x45, 0x64, 0x30, 0x65, 0xdb, 0xd8, 0x3a, 0xa4, 0x4d, 0x63, 0xa8, 0x11, 0x61, 0x3f, 0xc9, 0x39,
-	0xa7, 0x46, 0xce, 0x89, 0x63, 0xfd, 0x99, 0x37, 0xf5, 0x3d, 0x7f, 0xfa, 0x26, 0x1f, 0x0d, 0xe1,
-	0xe6, 0xf8, 0xea, 0x15, 0xf7, 0x95, 0xe7, 0x8b, 0xd1, 0x35, 0x2f, 0x77, 0xcc, 0x41, 0x4f, 0x2f,
-	0x3d, 0x57, 0xf8, 0x13, 0x61, 0x0e, 0xd8, 0x4d, 0x54, 0x92, 0xa3, 0xa5, 0x7d, 0x0f, 0x76, 0xf2,
-	0x19, 0xc9, 0x76, 0xa0, 0xaa, 0x56, 0xc6, 0xb2, 0xaa, 0x5a, 0xd9, 0x76, 0x12, 0x4d, 0x49, 0xf6,
-	0x5d, 0x93, 0x79, 0x00, 0x9d, 0x42, 0xa2, 0x65, 0xdc, 0x5c, 0xc9, 0xba, 0xd9, 0xee, 0xc0, 0x76,
-	0x2e, 0xbf, 0xec, 0x6f, 0x1b, 0xd0, 0x76, 0x84, 0x0c, 0x31, 0x7c, 0xd8, 0x63, 0xb0, 0xc4, 0x6a,
-	0x22, 0x34, 0x28, 0x56, 0x0a, 0x90, 0xa3, 0x65, 0x9e, 0xc6, 0x7c, 0xc4, 0x80, 0x44, 0x98, 0x3d,
-	0xc8, 0x01, 0xfa, 0x6e, 0x51, 0x29, 0x8b, 0xe8, 0x07, 0x79, 0x44, 0xbf, 0x59, 0x90, 0x2d, 0x40,
-	0xfa, 0x83, 0x1c, 0xa4, 0x17, 0x0f, 0xce, 0x61, 0xfa, 0x93, 0x12, 0x4c, 0x2f, 0x5e, 0x7f, 0x0d,
-	0xa8, 0x3f, 0x29, 0x01, 0xf5, 0xde, 0xb5, 0x6f, 0x95, 0xa2, 0xfa, 0x41, 0x1e, 0xd5, 0x8b, 0xe6,
-	0x14, 0x60, 0xfd, 0xc7, 0x65, 0xb0, 0x7e, 0xa7, 0xa0, 0xb3, 0x16, 0xd7, 0x3f, 0xba, 0x86, 0xeb,
-	0xb7, 0x0b, 0xaa, 0x25, 0xc0, 0xfe, 0x24, 0x07, 0xec, 0x50, 0x6a, 0xdb, 0x1a, 0x64, 0x7f, 0x74,
-	0x1d, 0xd9, 0xf7, 0x8a, 0x3f, 0x6d, 0x19, 0xb4, 0x0f, 0x0a, 0xd0, 0x7e, 0xab, 0x78, 0xcb, 0xb5,
-	0xd8, 0xfe, 0x00, 0xf3, 0xbd, 0x10, 0x69, 0x88, 0x0d, 0x22, 0x8a, 0x82, 0xc8, 0x80, 0xaf, 0x26,
-	0xec, 0x7d, 0x44, 0xa0, 0x34, 0xbe, 0xde, 0x50, 0x07, 0x28, 0xe8, 0x33, 0xd1, 0x65, 0xff, 0xa1,
-	0x92, 0xea, 0x52, 0x29, 0xc8, 0xa2, 0x97, 0x65, 0xd0, 0x2b, 0x53, 0x1e, 0xaa, 0xb9, 0xf2, 0xc0,
-	0x7e, 0x00, 0x37, 0xe6, 0x5c, 0x2a, 0xed, 0x97, 0x51, 0x0e, 0xce, 0x3a, 0xc8, 0xd0, 0x0e, 0xd1,
-	0xb8, 0xf6, 0x21, 0xec, 0x66, 0x64, 0x11, 0x5a, 0x09, 0xba, 0xea, 0x94, 0xbc, 0xdd, 0x44, 0xfa,
-	0x38, 0x0c, 0x87, 0x5c, 0xce, 0xec, 0x5f, 0xa4, 0xf6, 0xa7, 0xa5, 0x87, 0x41, 0x7d, 0x12, 0xb8,
-	0xda, 0xac, 0x6d, 0x87, 0xd6, 0x58, 0x8e, 0xe6, 0xc1, 0x94, 0xbe, 0x6a, 0x39, 0xb8, 0x44, 0xa9,
-	0x24, 0x53, 0x2c, 0x9d, 0x12, 0xf6, 0xef, 0x2b, 0xe9, 0x79, 0x69, 0x35, 0x2a, 0x2b, 0x2f, 0x95,
-	0xff, 0xa7, 0xbc, 0x54, 0xdf, 0xb6, 0xbc, 0xd8, 0x7f, 0xa9, 0xa4, 0xbf, 0x45, 0x52, 0x38, 0xde,
-	0xcd, 0x38, 0x0c, 0x0b, 0xcf, 0x77, 0xc5, 0x8a, 0x52, 0xbd, 0xe6, 0x68, 0x22, 0xae, 0xd3, 0x4d,
-	0x72, 0x70, 0xbe, 0x4e, 0xb7, 0x68, 0x4f, 0x13, 0xa6, 0xe0, 0x04, 0x17, 0x94, 0x83, 0x5b, 0x8e,
-	0x26, 0x32, 0xb8, 0x69, 0xe5, 0x70, 0xf3, 0x1c, 0xd8, 0xf5, 0xec, 0x64, 0x9f, 0x40, 0x5d, 0xf1,
-	0x29, 0x3a, 0x0f, 0xed, 0xdf, 0x39, 0xd4, 0x5d, 0xef, 0xe1, 0x97, 0x2f, 0xce, 0xb9, 0x17, 0x9d,
-	0xdc, 0x46, 0xeb, 0xff, 0xfd, 0xfa, 0xee, 0x0e, 0xca, 0x1c, 0x04, 0x0b, 0x4f, 0x89, 0x45, 0xa8,
-	0xae, 0x1c, 0xd2, 0xb1, 0xff, 0x53, 0x41, 0xd4, 0xce, 0x65, 0x6d, 0xa9, 0x2f, 0xe2, 0xd0, 0xac,
-	0x66, 0x0a, 0xeb, 0xdb, 0xf9, 0xe7, 0x7b, 0x00, 0x53, 0x2e, 0x47, 0xdf, 0x70, 0x5f, 0x09, 0xd7,
-	0x38, 0xc9, 0x9a, 0x72, 0xf9, 0x1b, 0xda, 0xc0, 0xfe, 0x03, 0xd9, 0x4b, 0x29, 0x5c, 0xf2, 0x56,
-	0xcd, 0x69, 0x4d, 0xb9, 0xfc, 0x4a, 0x0a, 0x37, 0xb1, 0xab, 0xf5, 0xbf, 0xdb, 0xc5, 0xf6, 0xa1,
-	0x76, 0x21, 0x84, 0x41, 0xb6, 0x6e, 0xa2, 0x7a, 0xf6, 0xe8, 0x63, 0x52, 0xd6, 0x21, 0x81, 0x22,
-	0xf6, 0xef, 0xaa, 0x69, 0x70, 0xa6, 0xc5, 0xed, 0xbb, 0xe5, 0x83, 0x7f, 0x52, 0xb7, 0x98, 0x87,
-	0x52, 0x76, 0x0a, 0x37, 0x92, 0x94, 0x19, 0x2d, 0x43, 0x97, 0x63, 0x17, 0x56, 0x79, 0x63, 0x8e,
-	0x75, 0x13, 0x85, 0xaf, 0xb4, 0x3c, 0xfb, 0x25, 0xec, 0x15, 0x92, 0x3c, 0x39, 0xaa, 0xfa, 0xc6,
-	0x5c, 0xbf, 0x95, 0xcf, 0xf5, 0xf8, 0xbc, 0xd8, 0x1f, 0xb5, 0x77, 0x88, 0xf5, 0xef, 0x63, 0x9b,
-	0x93, 0x85, 0xfe, 0xb2, 0x5f, 0xd4, 0xfe, 0x63, 0x05, 0x3a, 0x85, 0xcb, 0xb0, 0x01, 0x80, 0x46,
-	0x4e, 0xe9, 0xbd, 0x12, 0x06, 0xa4, 0x62, 0x1f, 0x90, 0xb3, 0x9e, 0x79, 0xaf, 0x84, 0x63, 0x8d,
-	0xe3, 0x25, 0xbb, 0x0f, 0x2d, 0xb5, 0xd2, 0xd2, 0xf9, 0x46, 0xf0, 0xf9, 0x8a, 0x44, 0x9b, 0x8a,
-	0xfe, 0xb3, 0x87, 0xb0, 0xa5, 0x0f, 0x9e, 0x06, 0x52, 0x7a, 0xa1, 0x69, 0x46, 0x58, 0xf6, 0xe8,
-	0x2f, 0x88, 0xe3, 0x6c, 0x8e, 0x53, 0xc2, 0xfe, 0x2d, 0x58, 0xc9, 0x67, 0xd9, 0x7b, 0x60, 0x2d,
-	0xf8, 0xca, 0x74, 0xc9, 0x78, 0xb7, 0x86, 0xd3, 0x5e, 0xf0, 0x15, 0x35, 0xc8, 0x6c, 0x0f, 0x5a,
-	0xc8, 0x54, 0x2b, 0xed, 0xef, 0x86, 0xd3, 0x5c, 0xf0, 0xd5, 0xf3, 0x55, 0xc2, 0x98, 0x72, 0x19,
-	0xb7, 0xc0, 0x0b, 0xbe, 0xfa, 0x82, 0x4b, 0xfb, 0x33, 0x68, 0xea, 0x4b, 0xbe, 0xd5, 0xc1, 0xa8,
-	0x5f, 0xcd, 0xe9, 0xff, 0x14, 0x36, 0x33, 0xf7, 0x66, 0x3f, 0x82, 0x5b, 0xda, 0xc2, 0x90, 0x47,
-	0x8a, 0x3c, 0x92, 0x3b, 0x90, 0x11, 0xf3, 0x9c, 0x47, 0x0a, 0x3f, 0xa9, 0x9b, 0xfa, 0x3f, 0x57,
-	0xa1, 0xa9, 0x1b, 0x66, 0x76, 0x3f, 0x33, 0x9d, 0x50, 0x55, 0x3c, 0xd9, 0xfc, 0xc7, 0xeb, 0xbb,
-	0x2d, 0x2a, 0x20, 0x67, 0x9f, 0xa7, 0xa3, 0x4a, 0x0a, 0x98, 0xd5, 0x5c, 0x3f, 0x1f, 0x4f, 0x3c,
-	0xb5, 0xcc, 0xc4, 0xb3, 0x07, 0x2d, 0x7f, 0xb9, 0x20, 0x97, 0xd4, 0xb5, 0x4b, 0xfc, 0xe5, 0x02,
-	0x5d, 0xf2, 0x1e, 0x58, 0x2a, 0x50, 0x7c, 0x4e, 0x2c, 0x9d, 0xa4, 0x6d, 0xda, 0x40, 0xe6, 0x7d,
-	0xe8, 0x64, 0xab, 0x2d, 0x56, 0x4f, 0x0d, 0xee, 0xdb, 0x69, 0xad, 0xc5, 0x09, 0xe0, 0x7d, 0xe8,
-	0xa4, 0x85, 0x46, 0xcb, 0x69, 0xc0, 0xdf, 0x49, 0xb7, 0x49, 0xf0, 0x0e, 0xb4, 0x93, 0x3a, 0xac,
-	0xc1, 0xbf, 0xc5, 0x75, 0xf9, 0xc5, 0xc1, 0x39, 0x8c, 0x82, 0x30, 0x90, 0x22, 0x32, 0x0d, 0xd6,
-	0xba, 0x84, 0x4b, 0xe4, 0x6c, 0x0f, 0xac, 0x84, 0x89, 0x4d, 0x03, 0x77, 0xdd, 0x48, 0x48, 0x69,
-	0xfa, 0xf3, 0x98, 0x64, 0x07, 0xd0, 0x0a, 0x97, 0xe3, 0x11, 0xd6, 0xa6, 0x7c, 0x60, 0x9e, 0x2f,
-	0xc7, 0x5f, 0x8a, 0xab, 0x78, 0x42, 0x09, 0x89, 0xa2, 0xea, 0x14, 0x7c, 0x23, 0x22, 0xe3, 0x3f,
-	0x4d, 0xd8, 0x0a, 0xba, 0xc5, 0xf1, 0x84, 0x7d, 0x0c, 0x56, 0x62, 0x5f, 0x21, 0x41, 0x8a, 0x77,
-	0x4e, 0x05, 0xb1, 0x85, 0x91, 0xde, 0xd4, 0x17, 0xee, 0x28, 0xf5, 0x2d, 0xdd, 0xab, 0xed, 0x74,
-	0x34, 0xe3, 0xe7, 0xb1, 0x73, 0xed, 0x1f, 0x42, 0x53, 0xdf, 0x91, 0x7e, 0xd4, 0xab, 0x30, 0xee,
-	0xaf, 0x68, 0x5d, 0x9a, 0xc9, 0x7f, 0xaa, 0x40, 0x3b, 0x1e, 0x7f, 0x4a, 0x95, 0x72, 0x97, 0xae,
-	0xbe, 0xed, 0xa5, 0xd7, 0xcd, 0x8e, 0x71, 0xac, 0xd5, 0x33, 0xb1, 0x76, 0x00, 0x4c, 0x87, 0xd4,
-	0x65, 0xa0, 0x3c, 0x7f, 0x3a, 0xd2, 0xde, 0xd4, 0xb1, 0xd5, 0x25, 0xce, 0x0b, 0x62, 0x9c, 0xe3,
-	0xfe, 0xd1, 0xb7, 0x0d, 0xe8, 0x1c, 0x9f, 0x9c, 0x9e, 0x1d, 0x87, 0xe1, 0xdc, 0x9b, 0x70, 0xea,
-	0xba, 0x06, 0x50, 0xa7, 0xbe, 0xb2, 0xe4, 0x75, 0xaa, 0x5f, 0x36, 0xe0, 0xb0, 0x23, 0x68, 0x50,
-	0x7b, 0xc9, 0xca, 0x1e, 0xa9, 0xfa, 0xa5, 0x73, 0x0e, 0x7e, 0x44, 0x37, 0xa0, 0xd7, 0xdf, 0xaa,
-	0xfa, 0x65, 0xc3, 0x0e, 0xfb, 0x0c, 0xac, 0xb4, 0x31, 0x5c, 0xf7, 0x62, 0xd5, 0x5f, 0x3b, 0xf6,
-	0xa0, 0x7e, 0x5a, 0x6b, 0xd7, 0xbd, 0xef, 0xf4, 0xd7, 0xce, 0x07, 0xec, 0x31, 0xb4, 0xe2, 0x6e,
-	0xa5, 0xfc, 0x4d, 0xa9, 0xbf, 0x66, 0x24, 0x41, 0xf7, 0xe8, 0x8e, 0xaf, 0xec, 0xe1, 0xab, 0x5f,
-	0x3a, 0x37, 0xb1, 0x87, 0xd0, 0x34, 0x05, 0xa3, 0xf4, 0x75, 0xa8, 0x5f, 0x3e, 0x58, 0xa0, 0x91,
-	0x69, 0xb7, 0xbb, 0xee, 0x71, 0xae, 0xbf, 0x76, 0xc0, 0x63, 0xc7, 0x00, 0x99, 0x2e, 0x6f, 0xed,
-	0xab, 0x5b, 0x7f, 0xfd, 0xe0, 0xc6, 0x3e, 0x85, 0x76, 0x3a, 0x8c, 0x97, 0xbf, 0x86, 0xf5, 0xd7,
-	0xcd, 0x52, 0xe3, 0x26, 0xbd, 0x98, 0x7e, 0xf4, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe5, 0xf3,
-	0xb2, 0x34, 0xad, 0x15, 0x00, 0x00,
+	// 2912 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
+	0xf1, 0xc7, 0x1b, 0xd8, 0xc6, 0x6b, 0x39, 0x7c, 0x41, 0xab, 0xbf, 0xff, 0xa2, 0xd7, 0xb1, 0x4c,
+	0x59, 0x12, 0x19, 0xd3, 0x96, 0xca, 0x8f, 0xd8, 0x65, 0x92, 0x96, 0x0d, 0x5a, 0xb6, 0x45, 0x2f,
+	0x29, 0xb9, 0x92, 0x4a, 0x05, 0x19, 0x00, 0x43, 0x60, 0x23, 0x60, 0x77, 0xbd, 0x3b, 0xa0, 0x40,
+	0xdd, 0x92, 0x4b, 0x2e, 0xa9, 0x5c, 0xf2, 0x09, 0xfc, 0x05, 0x72, 0x70, 0x55, 0xbe, 0x43, 0xbe,
+	0x43, 0x2a, 0x3a, 0x24, 0x39, 0xe5, 0x92, 0x7b, 0x4e, 0xa9, 0x79, 0xec, 0x13, 0xbb, 0x20, 0xe5,
+	0x1c, 0x73, 0x21, 0xb7, 0x67, 0xba, 0x7b, 0xa7, 0x7b, 0x67, 0xba, 0x7f, 0xdd, 0x03, 0x58, 0xa1,
+	0x17, 0x0e, 0xf1, 0x76, 0xf9, 0xdf, 0x1d, 0xc7, 0xb5, 0xa9, 0x8d, 0xca, 0x9c, 0xd0, 0xee, 0x8e,
+	0x4c, 0x3a, 0x9e, 0xf5, 0x77, 0x06, 0xf6, 0x74, 0x77, 0x64, 0x8f, 0xec, 0x5d, 0x3e, 0xdb, 0x9f,
+	0x9d, 0x71, 0x8a, 0x13, 0xfc, 0x49, 0x48, 0x69, 0xbb, 0x11, 0x76, 0x4a, 0xac, 0x21, 0x71, 0xa7,
+	0xa6, 0x45, 0x77, 0xe9, 0x74, 0x62, 0xf6, 0xbd, 0xdd, 0x81, 0x3d, 0x9d, 0xda, 0x56, 0xf4, 0x35,
+	0xfa, 0x77, 0x0a, 0x54, 0x0d, 0xf2, 0xed, 0x8c, 0x78, 0x14, 0x6d, 0x43, 0x89, 0x0c, 0xc6, 0x76,
+	0xa7, 0xb0, 0x95, 0xdf, 0xae, 0xef, 0xa1, 0x1d, 0xc1, 0x27, 0x67, 0x1f, 0x0c, 0xc6, 0x76, 0x37,
+	0x67, 0x70, 0x0e, 0x74, 0x1b, 0xca, 0x67, 0x93, 0x99, 0x37, 0xee, 0x14, 0x39, 0xeb, 0x6a, 0x9c,
+	0xf5, 0x53, 0x36, 0xd5, 0xcd, 0x19, 0x82, 0x87, 0xa9, 0x35, 0xad, 0x33, 0xbb, 0x53, 0x4a, 0x53,
+	0x7b, 0x64, 0x9d, 0x71, 0xb5, 0x8c, 0x03, 0xbd, 0x0b, 0xe0, 0x11, 0xda, 0xb3, 0x1d, 0x6a, 0xda,
+	0x56, 0xa7, 0xcc, 0xf9, 0x37, 0xe3, 0xfc, 0x27, 0x84, 0x3e, 0xe2, 0xd3, 0xdd, 0x9c, 0xa1, 0x78,
+	0x3e, 0xc1, 0x24, 0x4d, 0xcb, 0xa4, 0xbd, 0xc1, 0x18, 0x9b, 0x56, 0xa7, 0x92, 0x26, 0x79, 0x64,
+	0x99, 0xf4, 0x90, 0x4d, 0x33, 0x49, 0xd3, 0x27, 0x98, 0x29, 0xdf, 0xce, 0x88, 0x7b, 0xd1, 0xa9,
+	0xa6, 0x99, 0xf2, 0x35, 0x9b, 0x62, 0xa6, 0x70, 0x1e, 0xf4, 0x01, 0xd4, 0xfb, 0x64, 0x64, 0x5a,
+	0xbd, 0xfe, 0xc4, 0x1e, 0x3c, 0xed, 0xd4, 0xb8, 0x48, 0x27, 0x2e, 0x72, 0xc0, 0x18, 0x0e, 0xd8,
+	0x7c, 0x37, 0x67, 0x40, 0x3f, 0xa0, 0xd0, 0x1e, 0xd4, 0x06, 0x63, 0x32, 0x78, 0xda, 0xa3, 0xf3,
+	0x8e, 0xc2, 0x25, 0xd7, 0xe3, 0x92, 0x87, 0x6c, 0xf6, 0x74, 0xde, 0xcd, 0x19, 0xd5, 0x81, 0x78,
+	0x64, 0x76, 0x0d, 0xc9, 0xc4, 0x3c, 0x27, 0x2e, 0x93, 0x5a, 0x4d, 0xb3, 0xeb, 0x13, 0x31, 0xcf,
+	0xe5, 0x94, 0xa1, 0x4f, 0xa0, 0x7b, 0xa0, 0x10, 0x6b, 0x28, 0x17, 0x5a, 0xe7, 0x82, 0x1b, 0x89,
+	0x2f, 0x6a, 0x0d, 0xfd, 0x65, 0xd6, 0x88, 0x7c, 0x46, 0x3b, 0x50, 0x61, 0xbb, 0xc4, 0xa4, 0x9d,
+	0x06, 0x97, 0x59, 0x4b, 0x2c, 0x91, 0xcf, 0x75, 0x73, 0x86, 0xe4, 0x42, 0x5d, 0x50, 0xc3, 0x05,
+	0xf6, 0xfa, 0x98, 0x0e, 0xc6, 0x9d, 0x35, 0x2e, 0xf9, 0x7f, 0x19, 0xcb, 0x3c, 0x60, 0x3c, 0xdd,
+	0x9c, 0xd1, 0x1a, 0xc6, 0x46, 0xd0, 0x01, 0xb4, 0x7c, 0xf7, 0x48, 0x3d, 0xeb, 0x5c, 0x8f, 0x96,
+	0xea, 0x24, 0x5f, 0x4b, 0x63, 0x10, 0xa1, 0xd1, 0x27, 0xd0, 0x9a, 0x98, 0x1e, 0xed, 0x79, 0x16,
+	0x76, 0xbc, 0xb1, 0x4d, 0xbd, 0xce, 0x06, 0xd7, 0x71, 0x3d, 0xae, 0xe3, 0x0b, 0xd3, 0xa3, 0x27,
+	0x3e, 0x4b, 0x37, 0x67, 0x34, 0x27, 0xd1, 0x01, 0xa6, 0xc5, 0x3e, 0x3b, 0x23, 0x6e, 0xa0, 0xa6,
+	0xb3, 0x99, 0xa6, 0xe5, 0x11, 0xe3, 0xf1, 0xa5, 0x98, 0x16, 0x3b, 0x3a, 0x80, 0xbe, 0x86, 0xd5,
+	0x89, 0x8d, 0x87, 0x81, 0x92, 0xde, 0x60, 0x3c, 0xb3, 0x9e, 0x76, 0x3a, 0x5c, 0xd5, 0x8d, 0xc4,
+	0x82, 0x6c, 0x3c, 0xf4, 0x05, 0x0f, 0x19, 0x5b, 0x37, 0x67, 0xac, 0x4c, 0x92, 0x83, 0xe8, 0x14,
+	0xd6, 0xb0, 0xe3, 0x4c, 0x2e, 0x92, 0x3a, 0xaf, 0x71, 0x9d, 0x5b, 0x71, 0x9d, 0xfb, 0x8c, 0x33,
+	0xa9, 0x14, 0xe1, 0x85, 0x51, 0xf4, 0x39, 0xa8, 0x8e, 0x4b, 0x1c, 0xec, 0x92, 0x9e, 0xe3, 0xda,
+	0x8e, 0xed, 0xe1, 0x49, 0x47, 0xe3, 0x1a, 0x5f, 0x89, 0x6b, 0x3c, 0x16, 0x5c, 0xc7, 0x92, 0xa9,
+	0x9b, 0x33, 0xda, 0x4e, 0x7c, 0x48, 0xe8, 0xb2, 0x07, 0xc4, 0xf3, 0x42, 0x5d, 0xd7, 0xd3, 0x75,
+	0x71, 0xae, 0xb8, 0xae, 0xd8, 0xd0, 0x41, 0x15, 0xca, 0xe7, 0x78, 0x32, 0x23, 0xfa, 0x1b, 0x50,
+	0x8f, 0x04, 0x21, 0xd4, 0x81, 0xea, 0x94, 0x78, 0x1e, 0x1e, 0x91, 0x4e, 0x7e, 0x2b, 0xbf, 0xad,
+	0x18, 0x3e, 0xa9, 0xb7, 0xa0, 0x11, 0x0d, 0x41, 0xba, 0x0d, 0xf5, 0x48, 0x98, 0x61, 0x82, 0xe7,
+	0xc4, 0xf5, 0x58, 0x6c, 0x91, 0x82, 0x92, 0x44, 0xaf, 0x41, 0x93, 0x1f, 0x94, 0x9e, 0x3f, 0xcf,
+	0x42, 0x60, 0xc9, 0x68, 0xf0, 0xc1, 0x27, 0x92, 0xe9, 0x55, 0x68, 0xe0, 0xfe, 0xc0, 0x0c, 0x78,
+	0x8a, 0x5c, 0x47, 0x9d, 0x8d, 0x49, 0x16, 0xfd, 0x7d, 0x50, 0x93, 0x71, 0x0a, 0xa9, 0x50, 0x7c,
+	0x4a, 0x2e, 0xe4, 0x1b, 0xd9, 0x23, 0x5a, 0x93, 0x86, 0xf1, 0xb7, 0x28, 0x86, 0xb4, 0xf2, 0x1f,
+	0x79, 0x50, 0x93, 0xa1, 0x0a, 0x21, 0x28, 0x51, 0x73, 0x2a, 0x0c, 0x2d, 0x1a, 0xfc, 0x19, 0x5d,
+	0x63, 0x71, 0x04, 0x9b, 0x56, 0xcf, 0x1c, 0x4a, 0x0d, 0x55, 0x4e, 0x1f, 0x0d, 0xd1, 0x3e, 0xa8,
+	0x03, 0xdb, 0xf2, 0x88, 0xe5, 0xcd, 0xbc, 0x9e, 0x83, 0x5d, 0x3c, 0xf5, 0x3a, 0xc5, 0xd8, 0xd9,
+	0x3f, 0xf4, 0xa7, 0x8f, 0xf9, 0xac, 0xd1, 0x1e, 0xc4, 0x07, 0xd0, 0x7d, 0x80, 0x73, 0x3c, 0x31,
+	0x87, 0x98, 0xda, 0xae, 0xd7, 0x29, 0x6d, 0x15, 0xb7, 0xeb, 0x7b, 0xaa, 0x14, 0x7e, 0xe2, 0x4f,
+	0x1c, 0x94, 0xfe, 0xfc, 0xe2, 0x46, 0xce, 0x88, 0x70, 0xa2, 0x9b, 0xd0, 0xc6, 0x8e, 0xd3, 0xf3,
+	0x28, 0xa6, 0xa4, 0xd7, 0xbf, 0xa0, 0xc4, 0xe3, 0x01, 0xbc, 0x61, 0x34, 0xb1, 0xe3, 0x9c, 0xb0,
+	0xd1, 0x03, 0x36, 0xa8, 0x0f, 0xa1, 0x11, 0x8d, 0xad, 0xcc, 0xc2, 0x21, 0xa6, 0x98, 0x5b, 0xd8,
+	0x30, 0xf8, 0x33, 0x1b, 0x73, 0x30, 0x1d, 0x4b, 0xeb, 0xf8, 0x33, 0xda, 0x80, 0xca, 0x98, 0x98,
+	0xa3, 0x31, 0xe5, 0x06, 0x15, 0x0d, 0x49, 0x31, 0x67, 0x3a, 0xae, 0x7d, 0x4e, 0x78, 0x7a, 0xa9,
+	0x19, 0x82, 0xd0, 0xff, 0x9a, 0x87, 0x95, 0x85, 0x78, 0xcc, 0xf4, 0x8e, 0xb1, 0x37, 0xf6, 0xdf,
+	0xc5, 0x9e, 0xd1, 0x6d, 0xa6, 0x17, 0x0f, 0x89, 0x2b, 0xd3, 0x5e, 0x53, 0xda, 0xda, 0xe5, 0x83,
+	0xd2, 0x50, 0xc9, 0x82, 0x3e, 0x8c, 0x39, 0xa7, 0xb8, 0x55, 0x8c, 0x84, 0xe3, 0x13, 0x73, 0x64,
+	0x99, 0xd6, 0x68, 0x99, 0x8f, 0xba, 0xb0, 0xd6, 0xbf, 0x78, 0x8e, 0x2d, 0x6a, 0x5a, 0xa4, 0xb7,
+	0xe0, 0xe5, 0xb6, 0x54, 0xf4, 0xe0, 0xdc, 0x1c, 0x12, 0x6b, 0x40, 0xa4, 0x82, 0xd5, 0x40, 0x24,
+	0x50, 0xed, 0xe9, 0x5d, 0x68, 0xc5, 0xe3, 0x21, 0x6a, 0x41, 0x81, 0xce, 0xa5, 0x65, 0x05, 0x3a,
+	0x47, 0x37, 0xa1, 0xc4, 0xd4, 0x71, 0xab, 0x5a, 0x41, 0xd6, 0x95, 0xdc, 0xa7, 0x17, 0x0e, 0x31,
+	0xf8, 0xbc, 0xae, 0x83, 0x9a, 0x8c, 0xd0, 0x49, 0x5d, 0xfa, 0x2d, 0x68, 0x27, 0x72, 0x46, 0xe4,
+	0x73, 0xe4, 0xa3, 0x9f, 0x43, 0x6f, 0x43, 0x33, 0x96, 0x2a, 0xf4, 0x5b, 0xb0, 0x9e, 0x9a, 0x01,
+	0xd8, 0xb9, 0xa0, 0x73, 0xaf, 0x93, 0xdf, 0x2a, 0x6e, 0x37, 0x0c, 0xf6, 0xa8, 0x3f, 0x82, 0xd5,
+	0x94, 0x20, 0xbf, 0xc8, 0x78, 0x65, 0xdb, 0x36, 0x60, 0x2d, 0x2d, 0xe2, 0xeb, 0xbf, 0x80, 0xb5,
+	0xb4, 0x18, 0x8e, 0x6e, 0x43, 0x2d, 0x08, 0xf9, 0xf9, 0xad, 0x7c, 0xe4, 0x9b, 0xf8, 0x2c, 0x46,
+	0xc0, 0xc0, 0x8e, 0x21, 0xdb, 0xf0, 0x7c, 0x43, 0x15, 0xb8, 0xab, 0xaa, 0xd8, 0x71, 0xba, 0xd8,
+	0x1b, 0xeb, 0xbf, 0x84, 0x4e, 0x56, 0x60, 0x4f, 0x38, 0xae, 0x14, 0xec, 0xe3, 0x0d, 0xa8, 0x9c,
+	0xd9, 0xee, 0x14, 0x53, 0xae, 0xac, 0x69, 0x48, 0x8a, 0xed, 0x6f, 0x11, 0xe4, 0x8b, 0x7c, 0x58,
+	0x10, 0x7a, 0x0f, 0xae, 0x65, 0x86, 0x79, 0x26, 0x62, 0x5a, 0x43, 0x22, 0xbe, 0x60, 0xd3, 0x10,
+	0x44, 0xa8, 0x48, 0x2c, 0x56, 0x10, 0xec, 0xb5, 0x1e, 0xc7, 0x89, 0x32, 0x9c, 0x49, 0x4a, 0xbf,
+	0x80, 0x8d, 0xf4, 0xa8, 0x8f, 0xb6, 0xa0, 0x31, 0xc5, 0x73, 0x9e, 0xa5, 0xf9, 0x29, 0x17, 0xdf,
+	0x1f, 0xa6, 0x78, 0x7e, 0x3a, 0xe7, 0x47, 0xdc, 0xff, 0x60, 0x85, 0xf0, 0x83, 0x85, 0x87, 0xac,
+	0x78, 0xe9, 0x21, 0xd3, 0xbf, 0x89, 0xbc, 0x3a, 0x96, 0x11, 0x52, 0x76, 0xc2, 0xcb, 0x9c, 0x5e,
+	0xfd, 0x5f, 0x0a, 0xd4, 0x0c, 0xe2, 0x39, 0x2c, 0xe2, 0xa1, 0x77, 0x41, 0x21, 0xf3, 0x01, 0x11,
+	0x50, 0x33, 0x9f, 0x00, 0x72, 0x82, 0xe7, 0x81, 0x3f, 0xcf, 0x90, 0x55, 0xc0, 0x8c, 0x6e, 0xc5,
+	0x60, 0xf2, 0x6a, 0x52, 0x28, 0x8a, 0x93, 0xef, 0xc4, 0x71, 0xf2, 0x5a, 0x82, 0x37, 0x01, 0x94,
+	0x6f, 0xc5, 0x80, 0x72, 0x52, 0x71, 0x0c, 0x29, 0xbf, 0x97, 0x82, 0x94, 0x93, 0xcb, 0xcf, 0x80,
+	0xca, 0xef, 0xa5, 0x40, 0xe5, 0xce, 0xc2, 0xbb, 0x52, 0xb1, 0xf2, 0x9d, 0x38, 0x56, 0x4e, 0x9a,
+	0x93, 0x00, 0xcb, 0x3f, 0x49, 0x03, 0xcb, 0xd7, 0x12, 0x32, 0x99, 0x68, 0xf9, 0xed, 0x05, 0xb4,
+	0xbc, 0x91, 0x10, 0x4d, 0x81, 0xcb, 0xef, 0xc5, 0xe0, 0x32, 0xa4, 0xda, 0x96, 0x81, 0x97, 0xef,
+	0x2f, 0xe2, 0xe5, 0xcd, 0xe4, 0xa7, 0x4d, 0x03, 0xcc, 0xbb, 0x09, 0xc0, 0xbc, 0x9e, 0x5c, 0x65,
+	0x12, 0x31, 0x1f, 0xa5, 0x20, 0xe6, 0x66, 0x02, 0x22, 0x25, 0x56, 0x9a, 0x05, 0x99, 0x0f, 0x17,
+	0x20, 0x73, 0x2b, 0x01, 0x54, 0x63, 0x9e, 0x4a, 0xc7, 0xcc, 0x0f, 0x16, 0x30, 0x73, 0x3b, 0x81,
+	0xdf, 0x85, 0x92, 0x4b, 0x40, 0xf3, 0x83, 0x05, 0xd0, 0xac, 0xa6, 0xaa, 0xb9, 0x04, 0x35, 0x1b,
+	0xe9, 0xa8, 0x79, 0x25, 0x81, 0x70, 0xe5, 0x92, 0xae, 0x06, 0x9b, 0x1f, 0x67, 0xc0, 0x66, 0xc4,
+	0x95, 0xbe, 0x9a, 0x50, 0x7a, 0x65, 0xdc, 0xfc, 0x30, 0x05, 0x37, 0x8b, 0x0a, 0xed, 0xff, 0x13,
+	0x2a, 0xaf, 0x00, 0x9c, 0x1f, 0xa6, 0x00, 0xe7, 0xb5, 0x0c, 0x65, 0x57, 0x47, 0xce, 0xb7, 0x60,
+	0xc5, 0x17, 0x0b, 0x82, 0x19, 0x4b, 0x04, 0xc4, 0x75, 0x6d, 0x57, 0x42, 0x52, 0x41, 0xe8, 0xdb,
+	0xd0, 0x08, 0x58, 0x97, 0xa3, 0x6c, 0x9e, 0xe2, 0x23, 0x01, 0x4c, 0xff, 0x4b, 0x1e, 0x1a, 0xd1,
+	0x28, 0x15, 0xc3, 0x74, 0x8a, 0xc4, 0x74, 0x11, 0xf0, 0x5d, 0x88, 0x83, 0xef, 0x37, 0x61, 0x65,
+	0x82, 0x3d, 0x2a, 0x8e, 0x5e, 0x2f, 0x06, 0xf2, 0xda, 0x6c, 0x42, 0x9c, 0x39, 0x3e, 0x8c, 0xee,
+	0xc2, 0x6a, 0x84, 0x37, 0xc8, 0xbf, 0x25, 0x9e, 0xd2, 0xd4, 0x80, 0x7b, 0x5f, 0x24, 0x62, 0x74,
+	0x03, 0xea, 0x8c, 0xc7, 0x7f, 0x71, 0x99, 0x67, 0x5c, 0xc0, 0x8e, 0x93, 0x85, 0xe9, 0x2b, 0x8b,
+	0x98, 0xfe, 0x4b, 0x58, 0x59, 0x88, 0xa8, 0xcc, 0xc2, 0x81, 0x3d, 0x24, 0x32, 0xc3, 0xf2, 0x67,
+	0x96, 0x9d, 0x26, 0xf6, 0x48, 0xe6, 0x51, 0xf6, 0xc8, 0xb8, 0x82, 0x80, 0xae, 0x88, 0xc8, 0xad,
+	0xff, 0x3e, 0x0f, 0x2b, 0x0b, 0x61, 0x36, 0x15, 0xb8, 0xe7, 0xff, 0x1b, 0xe0, 0x5e, 0xb8, 0x2a,
+	0x70, 0xd7, 0xff, 0x94, 0x87, 0x66, 0x2c, 0x82, 0xff, 0x70, 0xe3, 0x42, 0xe4, 0x51, 0xe6, 0x9f,
+	0x4f, 0x10, 0x7e, 0x05, 0x54, 0xe1, 0x1f, 0x29, 0x5e, 0x01, 0x55, 0xf9, 0x98, 0x20, 0x24, 0x94,
+	0xb7, 0xcf, 0x78, 0xaa, 0x68, 0x18, 0x82, 0x88, 0x00, 0x26, 0x25, 0x86, 0x34, 0x8f, 0x01, 0x2d,
+	0x26, 0x11, 0xf4, 0x3e, 0x94, 0x28, 0x1e, 0x09, 0x8c, 0x50, 0xdf, 0x6b, 0xed, 0x88, 0x96, 0xd7,
+	0xce, 0xc3, 0x27, 0xc7, 0xd8, 0x74, 0x0f, 0x36, 0x98, 0xf5, 0xff, 0x7c, 0x71, 0xa3, 0xc5, 0x78,
+	0xee, 0xd8, 0x53, 0x93, 0x92, 0xa9, 0x43, 0x2f, 0x0c, 0x2e, 0xa3, 0xff, 0xbb, 0x00, 0x6d, 0x5f,
+	0xa5, 0x0f, 0xab, 0xd3, 0x7c, 0xe1, 0x6f, 0xef, 0x42, 0xa4, 0x64, 0xb9, 0x9a, 0x7f, 0x5e, 0x01,
+	0x18, 0x61, 0xaf, 0xf7, 0x0c, 0x5b, 0x94, 0x0c, 0xa5, 0x93, 0x94, 0x11, 0xf6, 0xbe, 0xe1, 0x03,
+	0x0c, 0x52, 0xb2, 0xe9, 0x99, 0x47, 0x86, 0xdc, 0x5b, 0x45, 0xa3, 0x3a, 0xc2, 0xde, 0x63, 0x8f,
+	0x0c, 0x03, 0xbb, 0xaa, 0x2f, 0x6f, 0x17, 0xda, 0x86, 0xe2, 0x19, 0x21, 0x32, 0x01, 0xab, 0x81,
+	0xe8, 0xd1, 0xfd, 0x77, 0xb8, 0xb0, 0xd8, 0x12, 0x8c, 0x05, 0x69, 0x50, 0x73, 0x5c, 0xd3, 0x76,
+	0x4d, 0x7a, 0x21, 0xbd, 0x1d, 0xd0, 0x11, 0xa4, 0x08, 0x51, 0xa4, 0xc8, 0xbe, 0x9a, 0x65, 0x5b,
+	0x03, 0xc2, 0x93, 0x66, 0xc9, 0x10, 0x04, 0xda, 0x85, 0x1a, 0x39, 0x37, 0x07, 0x1c, 0x9e, 0x34,
+	0x38, 0x4c, 0x5f, 0x0d, 0xcb, 0x1b, 0x3e, 0xdc, 0x35, 0x2d, 0x6a, 0x04, 0x4c, 0xfa, 0xaf, 0x0b,
+	0xb0, 0xb2, 0x90, 0xf8, 0xfe, 0xb7, 0xdc, 0xaf, 0xff, 0x9d, 0xb7, 0x00, 0xe2, 0x60, 0x03, 0x1d,
+	0xc2, 0x4a, 0x70, 0x5a, 0x7b, 0x33, 0x67, 0x88, 0x05, 0xe8, 0x5e, 0x76, 0xbc, 0xd5, 0x40, 0xe0,
+	0xb1, 0xe0, 0x47, 0x5f, 0xc1, 0x66, 0x22, 0xbe, 0x04, 0xaa, 0x0a, 0x4b, 0xc3, 0xcc, 0x7a, 0x3c,
+	0xcc, 0xf8, 0xfa, 0x7c, 0x7f, 0x14, 0x7f, 0xc0, 0x31, 0xfb, 0x11, 0xb4, 0x7c, 0x23, 0x05, 0x38,
+	0x4a, 0xfb, 0xa2, 0xfa, 0x31, 0x6c, 0xf8, 0x5c, 0x89, 0xc2, 0xf1, 0x3e, 0x28, 0xae, 0x9c, 0xf1,
+	0x1d, 0x91, 0x89, 0xf1, 0x8c, 0x90, 0x55, 0xff, 0x82, 0x55, 0x7d, 0x8b, 0x80, 0x08, 0xbd, 0xb3,
+	0xa8, 0x2f, 0x03, 0x6a, 0x46, 0xb5, 0x7d, 0xca, 0xea, 0xda, 0x14, 0x64, 0x84, 0xee, 0x82, 0x12,
+	0x42, 0xa9, 0x7c, 0xac, 0xb2, 0xf7, 0x99, 0x8c, 0x90, 0x43, 0xff, 0x3e, 0x0f, 0xeb, 0xa9, 0xd8,
+	0x08, 0x7d, 0x00, 0x15, 0x97, 0x78, 0xb3, 0x89, 0xa8, 0x14, 0x5b, 0x7b, 0xaf, 0x2d, 0x43, 0x52,
+	0x6c, 0x74, 0x36, 0xa1, 0x86, 0x14, 0xd1, 0x7f, 0x0e, 0x15, 0x31, 0x82, 0xea, 0x50, 0x7d, 0x6c,
+	0x3d, 0xb5, 0xec, 0x67, 0x96, 0x9a, 0x43, 0x00, 0x95, 0xfd, 0x01, 0x03, 0x02, 0x6a, 0x1e, 0x29,
+	0x50, 0xde, 0xef, 0xdb, 0x2e, 0x55, 0x0b, 0x6c, 0xd8, 0x20, 0xbf, 0x22, 0x03, 0xaa, 0x16, 0x91,
+	0x0a, 0x0d, 0xf1, 0xfc, 0x29, 0x2f, 0x40, 0xd5, 0x52, 0x38, 0x72, 0xc2, 0x4f, 0xbc, 0x5a, 0xd6,
+	0xdf, 0x82, 0x6b, 0xfe, 0x2a, 0x16, 0x2b, 0xdc, 0xa0, 0xd0, 0xcc, 0x47, 0x0a, 0x4d, 0xfd, 0x77,
+	0x05, 0xd0, 0xb2, 0x21, 0x16, 0xfa, 0x38, 0x61, 0xec, 0xf6, 0xa5, 0xa8, 0x2c, 0x61, 0x31, 0x7a,
+	0x1d, 0x5a, 0x2e, 0x39, 0x23, 0x74, 0x30, 0x16, 0xf0, 0x4e, 0xe4, 0xc0, 0xa6, 0xd1, 0x94, 0xa3,
+	0x5c, 0xc8, 0x13, 0x6c, 0xcc, 0x98, 0x9e, 0x88, 0x5f, 0x62, 0x0f, 0x2b, 0x46, 0x53, 0x8c, 0x0a,
+	0x13, 0x59, 0x8b, 0xe0, 0x65, 0xfc, 0xa7, 0x40, 0xd9, 0x20, 0xd4, 0xbd, 0x50, 0x8b, 0x68, 0x85,
+	0x65, 0x53, 0xea, 0x06, 0x6b, 0x55, 0x4b, 0x08, 0xb1, 0x0d, 0xcf, 0x95, 0xfb, 0x63, 0x65, 0xfd,
+	0x36, 0x6c, 0x66, 0xa0, 0xc3, 0x94, 0xc6, 0xc8, 0x6f, 0xf3, 0x51, 0xee, 0x78, 0x4d, 0xfc, 0x21,
+	0x54, 0x3c, 0x8a, 0xe9, 0xcc, 0x93, 0x8e, 0x7b, 0x7d, 0x39, 0x5c, 0xdc, 0x39, 0xe1, 0xcc, 0x86,
+	0x14, 0xd2, 0xef, 0x42, 0x45, 0x8c, 0x64, 0xdb, 0x19, 0x6e, 0x8e, 0x82, 0xfe, 0x5d, 0x1e, 0xda,
+	0x89, 0x10, 0x81, 0x76, 0x01, 0x04, 0x1c, 0xf3, 0xcc, 0xe7, 0x44, 0xa2, 0x16, 0x3f, 0x32, 0xf1,
+	0x10, 0x76, 0x62, 0x3e, 0x27, 0x86, 0xd2, 0xf7, 0x1f, 0xd1, 0x4d, 0xa8, 0xd2, 0xb9, 0xe0, 0x8e,
+	0x57, 0xed, 0xa7, 0x73, 0xce, 0x5a, 0xa1, 0xfc, 0x3f, 0xba, 0x07, 0xa2, 0x01, 0xdb, 0x1b, 0xd9,
+	0x9e, 0x67, 0x3a, 0xb2, 0x88, 0x46, 0x51, 0xd5, 0x9f, 0xf1, 0x19, 0xa3, 0xde, 0x0f, 0x09, 0xfd,
+	0x67, 0xa0, 0x04, 0xaf, 0x45, 0xd7, 0x41, 0x99, 0xe2, 0x68, 0xab, 0xa2, 0x6c, 0xd4, 0xa6, 0x58,
+	0x36, 0x2a, 0x36, 0xa1, 0x2a, 0x5a, 0x19, 0x22, 0x0a, 0x96, 0x8d, 0x0a, 0xef, 0x62, 0x04, 0x13,
+	0x23, 0xec, 0xf9, 0xdd, 0xc6, 0x29, 0x9e, 0x7f, 0x86, 0x3d, 0xfd, 0x23, 0xa8, 0x9c, 0xce, 0xaf,
+	0xac, 0x78, 0x84, 0x85, 0xe2, 0x50, 0xfe, 0x63, 0xa8, 0x47, 0xd6, 0x8d, 0xde, 0x82, 0x75, 0x61,
+	0xa1, 0x83, 0x5d, 0xca, 0x3d, 0x12, 0x53, 0x88, 0xf8, 0xe4, 0x31, 0x76, 0x29, 0x7b, 0xa5, 0xe8,
+	0x9f, 0x7e, 0x5f, 0x80, 0x8a, 0xe8, 0x6e, 0xa0, 0x9b, 0x91, 0x46, 0x30, 0x87, 0xda, 0x07, 0xf5,
+	0xbf, 0xbd, 0xb8, 0x51, 0xe5, 0x88, 0xf2, 0xe8, 0x93, 0xb0, 0x2b, 0x1c, 0x22, 0xa8, 0x42, 0xac,
+	0x75, 0xea, 0x37, 0x97, 0x8b, 0x91, 0xe6, 0xf2, 0x26, 0x54, 0xad, 0xd9, 0x94, 0xbb, 0xa4, 0x24,
+	0x5c, 0x62, 0xcd, 0xa6, 0xcc, 0x25, 0xd7, 0x41, 0xa1, 0x36, 0xc5, 0x13, 0x3e, 0x25, 0x52, 0x67,
+	0x8d, 0x0f, 0x9c, 0xf2, 0x86, 0x5c, 0x3b, 0x0a, 0xe1, 0x19, 0x24, 0x17, 0x68, 0xaf, 0x19, 0x02,
+	0x78, 0x86, 0xc7, 0xdf, 0x80, 0x76, 0x88, 0x3c, 0x05, 0x9f, 0x40, 0x80, 0xad, 0x70, 0x98, 0x33,
+	0x46, 0x9b, 0x6b, 0xb5, 0x58, 0x73, 0x8d, 0x5d, 0xa3, 0x89, 0x0a, 0x89, 0xb8, 0x1d, 0x25, 0xb6,
+	0xd9, 0x92, 0x69, 0x30, 0xe0, 0xd3, 0x4d, 0x50, 0x82, 0x49, 0x56, 0x89, 0xe0, 0xe1, 0xd0, 0x25,
+	0x9e, 0x27, 0x23, 0x94, 0x4f, 0xa2, 0x3b, 0x50, 0x75, 0x66, 0xfd, 0x1e, 0x03, 0xab, 0xf1, 0x8d,
+	0x79, 0x3c, 0xeb, 0x3f, 0x24, 0x17, 0x7e, 0x3b, 0xc9, 0xe1, 0x14, 0x87, 0xab, 0xf6, 0x33, 0xd9,
+	0xd3, 0x2a, 0x1a, 0x82, 0xd0, 0x29, 0xa8, 0xc9, 0x4e, 0x30, 0xcb, 0x30, 0x81, 0x7d, 0x89, 0x03,
+	0x92, 0x5c, 0x73, 0xc8, 0xc8, 0xea, 0x22, 0xcf, 0x1c, 0x59, 0x64, 0xd8, 0x0b, 0x7d, 0xcb, 0xd7,
+	0x55, 0x33, 0xda, 0x62, 0xe2, 0x0b, 0xdf, 0xb9, 0xfa, 0x8f, 0xa1, 0x22, 0xd6, 0x88, 0x90, 0xec,
+	0x8d, 0xca, 0xda, 0x8b, 0x3d, 0xa7, 0xe6, 0xd7, 0x3f, 0xe6, 0xa1, 0xe6, 0x77, 0x9a, 0x53, 0x85,
+	0x62, 0x8b, 0x2e, 0x5c, 0x75, 0xd1, 0x59, 0x6d, 0x7a, 0x7f, 0xaf, 0x95, 0x22, 0x7b, 0xed, 0x0e,
+	0x20, 0xb1, 0xa5, 0xce, 0x6d, 0x6a, 0x5a, 0xa3, 0x9e, 0xf0, 0xa6, 0xd8, 0x5b, 0x2a, 0x9f, 0x79,
+	0xc2, 0x27, 0x8e, 0xb9, 0x63, 0x7f, 0x93, 0x87, 0x5a, 0x90, 0x1b, 0x5f, 0xb6, 0x8b, 0xba, 0x01,
+	0x15, 0x99, 0x14, 0x44, 0x1b, 0x55, 0x52, 0xc1, 0x8d, 0x40, 0x29, 0x72, 0x23, 0xa0, 0x41, 0x6d,
+	0x4a, 0x28, 0xe6, 0x1e, 0x13, 0x57, 0x18, 0x01, 0xfd, 0xe6, 0x6b, 0x50, 0x8f, 0xb4, 0x99, 0x51,
+	0x15, 0x8a, 0x5f, 0x91, 0x67, 0x6a, 0x8e, 0x05, 0x4f, 0x83, 0xf0, 0xb6, 0x8a, 0x9a, 0x7f, 0xf3,
+	0x1e, 0x34, 0xa2, 0x20, 0x97, 0x05, 0xd0, 0xaf, 0xd8, 0x32, 0x26, 0x6a, 0x0e, 0x35, 0x41, 0xe1,
+	0x73, 0xb8, 0x3f, 0x21, 0x22, 0xb6, 0x1e, 0x9b, 0x96, 0x45, 0x86, 0x6a, 0x61, 0xef, 0x0f, 0x00,
+	0xed, 0xfd, 0x83, 0xc3, 0x23, 0x96, 0xea, 0xcc, 0x01, 0x66, 0xe2, 0x68, 0x17, 0x4a, 0xbc, 0x1a,
+	0x4f, 0xb9, 0x8c, 0xd7, 0xd2, 0x3a, 0x8f, 0x68, 0x0f, 0xca, 0xbc, 0x28, 0x47, 0x69, 0x77, 0xf2,
+	0x5a, 0x6a, 0x03, 0x92, 0xbd, 0x44, 0x94, 0xed, 0x8b, 0x57, 0xf3, 0x5a, 0x5a, 0x17, 0x12, 0x7d,
+	0x04, 0x4a, 0x58, 0x0a, 0x67, 0x5d, 0xd0, 0x6b, 0x99, 0xfd, 0x48, 0x26, 0x1f, 0x42, 0xfc, 0xac,
+	0xeb, 0x6c, 0x2d, 0x13, 0xd4, 0xa1, 0x77, 0xa1, 0xea, 0xd7, 0x67, 0xe9, 0x57, 0xe8, 0x5a, 0x06,
+	0x80, 0x63, 0xee, 0x11, 0x35, 0x6e, 0xda, 0x3d, 0xbf, 0x96, 0xda, 0xd0, 0x44, 0xf7, 0xa0, 0x22,
+	0x71, 0x6a, 0xea, 0x65, 0xb8, 0x96, 0xde, 0xf1, 0x63, 0x46, 0x86, 0xf5, 0x7d, 0xd6, 0x6f, 0x11,
+	0xb4, 0xcc, 0xce, 0x2b, 0xda, 0x07, 0x88, 0xd4, 0xb5, 0x99, 0x3f, 0x32, 0xd0, 0xb2, 0x3b, 0xaa,
+	0xe8, 0x03, 0xa8, 0x85, 0x17, 0x36, 0xe9, 0x97, 0xff, 0x5a, 0x56, 0x93, 0x13, 0x7d, 0x09, 0xad,
+	0x04, 0xf0, 0x5e, 0x7a, 0xa3, 0xaf, 0x2d, 0xef, 0x5e, 0xa2, 0xcf, 0xa0, 0x11, 0x43, 0xdd, 0x4b,
+	0xae, 0xf5, 0xb5, 0x65, 0xfd, 0x4b, 0xf4, 0x39, 0x34, 0xe3, 0x80, 0x7b, 0xd9, 0xe5, 0xbe, 0xb6,
+	0xb4, 0x8b, 0xc9, 0x74, 0xc5, 0x31, 0xf7, 0xb2, 0x2b, 0x7e, 0x6d, 0x69, 0x2b, 0x13, 0x3d, 0x81,
+	0x95, 0x45, 0x2c, 0x7c, 0xd9, 0x3d, 0xbf, 0x76, 0x69, 0x4b, 0x13, 0xfd, 0x14, 0x50, 0x0a, 0x5e,
+	0xbe, 0xf4, 0xb2, 0x5f, 0xbb, 0xbc, 0xaf, 0x89, 0x8e, 0xa1, 0x9d, 0x04, 0x9f, 0xcb, 0xaf, 0xfc,
+	0xb5, 0x4b, 0x3a, 0x9b, 0x42, 0x63, 0x1c, 0xa0, 0x2e, 0xbf, 0xf8, 0xd7, 0x2e, 0x69, 0x6f, 0xf6,
+	0x2b, 0xfc, 0x77, 0x4a, 0x6f, 0xff, 0x67, 0x00, 0x3e, 0xc2, 0x5d, 0x3d, 0x23, 0x25, 0x00, 0x00,
 }
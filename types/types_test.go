@@ -29,3 +29,88 @@ func TestConsensusParams(t *testing.T) {
 	assert.EqualValues(params.GetTxSize().GetMaxBytes(), 0)
 
 }
+
+func TestConsensusParamsValidate(t *testing.T) {
+	assert := asrt.New(t)
+
+	assert.Nil(DefaultConsensusParams().Validate())
+
+	cases := []struct {
+		msg    string
+		update func(*ConsensusParams)
+	}{
+		{"no block size", func(p *ConsensusParams) { p.BlockSize = nil }},
+		{"no tx size", func(p *ConsensusParams) { p.TxSize = nil }},
+		{"no block gossip", func(p *ConsensusParams) { p.BlockGossip = nil }},
+		{"zero block bytes", func(p *ConsensusParams) { p.BlockSize.MaxBytes = 0 }},
+		{"zero block txs", func(p *ConsensusParams) { p.BlockSize.MaxTxs = 0 }},
+		{"zero block gas", func(p *ConsensusParams) { p.BlockSize.MaxGas = 0 }},
+		{"negative tx bytes", func(p *ConsensusParams) { p.TxSize.MaxBytes = -1 }},
+		{"negative tx gas", func(p *ConsensusParams) { p.TxSize.MaxGas = -2 }},
+		{"tx bigger than block", func(p *ConsensusParams) { p.TxSize.MaxBytes = p.BlockSize.MaxBytes + 1 }},
+		{"tx gas above block gas", func(p *ConsensusParams) { p.BlockSize.MaxGas = 10; p.TxSize.MaxGas = 11 }},
+		{"zero part size", func(p *ConsensusParams) { p.BlockGossip.BlockPartSizeBytes = 0 }},
+	}
+	for _, c := range cases {
+		params := DefaultConsensusParams()
+		c.update(params)
+		assert.NotNil(params.Validate(), c.msg)
+	}
+
+	// a tx without gas limit is limited by the block
+	params := DefaultConsensusParams()
+	params.BlockSize.MaxGas = 10
+	assert.Nil(params.Validate())
+}
+
+func TestConsensusParamsUpdate(t *testing.T) {
+	assert := asrt.New(t)
+
+	params := DefaultConsensusParams()
+	updated := params.Update(&ConsensusParams{
+		BlockSize: &BlockSize{MaxGas: 1000},
+		TxSize:    &TxSize{MaxGas: 100},
+	})
+	assert.EqualValues(1000, updated.BlockSize.MaxGas)
+	assert.EqualValues(100, updated.TxSize.MaxGas)
+	assert.Equal(params.BlockSize.MaxBytes, updated.BlockSize.MaxBytes, "zero fields are unchanged")
+	assert.Equal(params.BlockGossip, updated.BlockGossip, "nil fields are unchanged")
+	assert.Equal(MaxGasUnlimited, params.BlockSize.MaxGas, "params are copied")
+	assert.Nil(updated.Validate())
+
+	assert.Equal(params, params.Update(nil))
+	var noParams *ConsensusParams
+	assert.Equal(&ConsensusParams{TxSize: &TxSize{MaxBytes: 10}},
+		noParams.Update(&ConsensusParams{TxSize: &TxSize{MaxBytes: 10}}))
+}
+
+func TestConsensusParamsHash(t *testing.T) {
+	assert := asrt.New(t)
+
+	params := DefaultConsensusParams()
+	assert.Len(params.Hash(), 32)
+	assert.Equal(params.Hash(), DefaultConsensusParams().Hash())
+	assert.NotEqual(params.Hash(), params.Update(&ConsensusParams{
+		BlockGossip: &BlockGossip{BlockPartSizeBytes: 1024},
+	}).Hash())
+
+	// nil fields hash as zero
+	var noParams *ConsensusParams
+	assert.Equal(noParams.Hash(), (&ConsensusParams{BlockSize: &BlockSize{}}).Hash())
+}
+
+func TestStringOrHexToBytes(t *testing.T) {
+	assert := asrt.New(t)
+
+	bz, err := StringOrHexToBytes(`"abc"`)
+	assert.Nil(err)
+	assert.Equal([]byte("abc"), bz)
+	bz, err = StringOrHexToBytes("0xABCD")
+	assert.Nil(err)
+	assert.Equal([]byte{0xAB, 0xCD}, bz)
+
+	for _, s := range []string{`abc`, `"`, `"abc`, "0xZZ"} {
+		_, err = StringOrHexToBytes(s)
+		assert.NotNil(err, s)
+	}
+}
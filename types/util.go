@@ -2,8 +2,11 @@ package types
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"sort"
+	"strings"
 
 	cmn "github.com/tendermint/tmlibs/common"
 )
@@ -57,3 +60,23 @@ type validatorPretty struct {
 	PubKey  []byte       `json:"pub_key"`
 	Power   int64        `json:"power"`
 }
+
+//------------------------------------------------------------------------------
+
+// StringOrHexToBytes parses an argument of the abci-cli and of its batch
+// files: a quoted string, or a hex string prefixed with 0x.
+func StringOrHexToBytes(s string) ([]byte, error) {
+	if len(s) > 2 && strings.ToLower(s[:2]) == "0x" {
+		b, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return nil, fmt.Errorf("Error decoding hex argument: %s", err.Error())
+		}
+		return b, nil
+	}
+
+	if len(s) < 2 || !strings.HasPrefix(s, "\"") || !strings.HasSuffix(s, "\"") {
+		return nil, fmt.Errorf("Invalid string arg: \"%s\". Must be quoted or a \"0x\"-prefixed hex string", s)
+	}
+
+	return []byte(s[1 : len(s)-1]), nil
+}
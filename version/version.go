@@ -1,9 +1,157 @@
 package version
 
-// NOTE: we should probably be versioning the ABCI and the abci-cli separately
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
+// The release of this repo and of the abci-cli
 const Maj = "0"
 const Min = "12"
 const Fix = "0"
 
 const Version = "0.12.0"
+
+// ABCIVersion is the version of the ABCI protocol spoken by the clients and
+// servers of this repo, reported in the AbciVersion of Info.
+// It is versioned separately from the release.
+const ABCIVersion = "0.12.0"
+
+// Semver is a semantic version: major.minor.patch[-pre][+build].
+// The build metadata is ignored.
+type Semver struct {
+	Major, Minor, Patch uint64
+	Pre                 string // the pre-release, eg. "dev" or "rc.1"
+}
+
+// ParseSemver parses a semantic version, with an optional "v" prefix.
+func ParseSemver(s string) (Semver, error) {
+	var v Semver
+	str := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(str, '+'); i >= 0 {
+		str = str[:i]
+	}
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		v.Pre = str[i+1:]
+		str = str[:i]
+		if v.Pre == "" {
+			return Semver{}, fmt.Errorf("Invalid version %q: empty pre-release", s)
+		}
+	}
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		return Semver{}, fmt.Errorf("Invalid version %q: want major.minor.patch", s)
+	}
+	nums := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Semver{}, fmt.Errorf("Invalid version %q: %v", s, err)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// MustParseSemver is ParseSemver, panicking on errors.
+func MustParseSemver(s string) Semver {
+	v, err := ParseSemver(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (v Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if v is lower, equal or greater than w,
+// a pre-release being lower than its release.
+func (v Semver) Compare(w Semver) int {
+	if c := compareUint(v.Major, w.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, w.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, w.Patch); c != 0 {
+		return c
+	}
+	return comparePre(v.Pre, w.Pre)
+}
+
+// Compatible returns whether the protocols of the versions are compatible:
+// the majors are the same, and so are the minors before 1.0.0.
+func (v Semver) Compatible(w Semver) bool {
+	if v.Major != w.Major {
+		return false
+	}
+	return v.Major != 0 || v.Minor == w.Minor
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePre compares pre-releases by identifier, the numeric ones
+// numerically and before the others, no pre-release being the greatest.
+func comparePre(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareUint(an, bn)
+		case aErr == nil:
+			c = -1
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(as[i], bs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(as)), uint64(len(bs)))
+}
+
+// Compatible returns an error if the ABCI versions a and b are invalid
+// or not Compatible.
+func Compatible(a, b string) error {
+	va, err := ParseSemver(a)
+	if err != nil {
+		return err
+	}
+	vb, err := ParseSemver(b)
+	if err != nil {
+		return err
+	}
+	if !va.Compatible(vb) {
+		return fmt.Errorf("ABCI versions %s and %s are incompatible", va, vb)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemver(t *testing.T) {
+	v, err := ParseSemver("v1.2.3-rc.1+build.5")
+	require.Nil(t, err)
+	assert.Equal(t, Semver{1, 2, 3, "rc.1"}, v)
+	assert.Equal(t, "1.2.3-rc.1", v.String())
+
+	assert.Equal(t, Version, MustParseSemver(Version).String())
+	assert.Equal(t, Maj+"."+Min+"."+Fix, Version)
+
+	for _, s := range []string{"", "1.2", "1.2.3.4", "1.2.x", "1.2.-3", "1.2.3-", "a.b.c"} {
+		_, err := ParseSemver(s)
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	// in increasing order
+	versions := []string{
+		"0.9.9", "0.12.0-alpha", "0.12.0-alpha.1", "0.12.0-alpha.beta", "0.12.0-beta.2",
+		"0.12.0-beta.11", "0.12.0-rc.1", "0.12.0", "0.12.1", "1.0.0", "1.10.0",
+	}
+	for i, a := range versions {
+		for j, b := range versions {
+			want := compareUint(uint64(i), uint64(j))
+			assert.Equal(t, want, MustParseSemver(a).Compare(MustParseSemver(b)), "%s vs %s", a, b)
+		}
+	}
+	assert.Equal(t, 0, MustParseSemver("1.0.0+a").Compare(MustParseSemver("1.0.0+b")))
+}
+
+func TestCompatible(t *testing.T) {
+	cases := []struct {
+		a, b       string
+		compatible bool
+	}{
+		{"0.12.0", "0.12.3", true},
+		{"0.12.0", "0.12.0-dev", true},
+		{"0.12.0", "0.13.0", false},
+		{"1.2.0", "1.5.1", true},
+		{"1.0.0", "2.0.0", false},
+		{"0.12.0", "1.12.0", false},
+	}
+	for _, c := range cases {
+		err := Compatible(c.a, c.b)
+		assert.Equal(t, c.compatible, err == nil, "%s vs %s: %v", c.a, c.b, err)
+	}
+	assert.NotNil(t, Compatible("0.12.0", "unknown"))
+}